@@ -16,32 +16,341 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
 )
 
 const completionDesc = `
-Generate bash autocompletions script for Helm.
+Generate autocompletions script for Helm for the specified shell (bash or zsh).
 
-This command can generate shell autocompletions.
+This command can generate shell autocompletions. e.g.
 
-	$ helm completion
+	$ helm completion bash
 
 Can be sourced as such
 
-	$ source <(helm completion)
+	$ source <(helm completion bash)
+
+The zsh script loads the bash completion with zsh's bashcompinit and needs
+zsh 5.2 or later:
+
+	$ source <(helm completion zsh)
+
+The shell defaults to bash.
+
+Besides commands and flags, the script completes release names from Tiller,
+charts from the cached repository indexes and the names of the configured
+repositories.
+`
+
+// bashCompletionFunc completes the arguments of the commands that take
+// release names, charts or repository names. It is called by the completion
+// that cobra generates when a command has no other completions.
+const bashCompletionFunc = `
+__helm_override_flag_list=(--home --host --kube-context --tiller-namespace)
+__helm_override_flags()
+{
+    local ${__helm_override_flag_list[*]##*-} two_word_of of var
+    for w in "${words[@]}"; do
+        if [ -n "${two_word_of}" ]; then
+            eval "${two_word_of##*-}=\"${two_word_of}=\${w}\""
+            two_word_of=
+            continue
+        fi
+        for of in "${__helm_override_flag_list[@]}"; do
+            case "${w}" in
+                ${of}=*)
+                    eval "${of##*-}=\"${w}\""
+                    ;;
+                ${of})
+                    two_word_of="${of}"
+                    ;;
+            esac
+        done
+    done
+    for var in "${__helm_override_flag_list[@]##*-}"; do
+        if eval "test -n \"\$${var}\""; then
+            eval "echo \${${var}}"
+        fi
+    done
+}
+
+__helm_list_releases()
+{
+    local out
+    if out=$(helm list $(__helm_override_flags) -a -q 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
+__helm_list_charts()
+{
+    local out
+    if out=$(helm search $(__helm_override_flags) 2>/dev/null | awk 'NR>1 {print $1}'); then
+        COMPREPLY=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
+__helm_list_repos()
+{
+    local out
+    if out=$(helm repo list $(__helm_override_flags) 2>/dev/null | awk 'NR>1 {print $1}'); then
+        COMPREPLY=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
+__custom_func()
+{
+    case ${last_command} in
+        helm_delete | helm_get | helm_get_hooks | helm_get_manifest | helm_get_notes | \
+        helm_get_values | helm_history | helm_status | helm_test)
+            __helm_list_releases
+            return
+            ;;
+        helm_rollback)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __helm_list_releases
+            fi
+            return
+            ;;
+        helm_diff | helm_upgrade)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __helm_list_releases
+            else
+                __helm_list_charts
+            fi
+            return
+            ;;
+        helm_fetch | helm_inspect | helm_inspect_chart | helm_inspect_values | helm_install)
+            __helm_list_charts
+            return
+            ;;
+        helm_repo_remove)
+            __helm_list_repos
+            return
+            ;;
+        *)
+            ;;
+    esac
+}
 `
 
+var completionShells = map[string]func(out io.Writer, cmd *cobra.Command) error{
+	"bash": runCompletionBash,
+	"zsh":  runCompletionZsh,
+}
+
 func newCompletionCmd(out io.Writer) *cobra.Command {
+	shells := []string{}
+	for s := range completionShells {
+		shells = append(shells, s)
+	}
+
 	cmd := &cobra.Command{
-		Use:    "completion",
-		Short:  "Generate bash autocompletions script",
-		Long:   completionDesc,
-		Hidden: false,
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			return cmd.Root().GenBashCompletion(out)
+		Use:   "completion [SHELL]",
+		Short: "Generate autocompletions script for the specified shell (bash or zsh)",
+		Long:  completionDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompletion(out, cmd, args)
 		},
+		ValidArgs: shells,
 	}
 	return cmd
 }
+
+func runCompletion(out io.Writer, cmd *cobra.Command, args []string) error {
+	shell := "bash"
+	switch len(args) {
+	case 0:
+	case 1:
+		shell = args[0]
+	default:
+		return fmt.Errorf("too many arguments, expected only the shell type")
+	}
+	run, found := completionShells[shell]
+	if !found {
+		return fmt.Errorf("unsupported shell type %q", shell)
+	}
+	return run(out, cmd.Root())
+}
+
+func runCompletionBash(out io.Writer, cmd *cobra.Command) error {
+	return cmd.GenBashCompletion(out)
+}
+
+// runCompletionZsh writes the bash completion of cmd, converted to run
+// under zsh's bashcompinit.
+func runCompletionZsh(out io.Writer, cmd *cobra.Command) error {
+	zshInitialization := `
+__helm_bash_source() {
+	alias shopt=':'
+	alias _expand=_bash_expand
+	alias _complete=_bash_comp
+	emulate -L sh
+	setopt kshglob noshglob braceexpand
+
+	source "$@"
+}
+
+__helm_type() {
+	# -t is not supported by zsh
+	if [ "$1" == "-t" ]; then
+		shift
+
+		# fake Bash 4 to disable "complete -o nospace". Instead
+		# "compopt +-o nospace" is used in the code to toggle trailing
+		# spaces. We don't support that, but leave trailing spaces on
+		# all the time
+		if [ "$1" = "__helm_compopt" ]; then
+			echo builtin
+			return 0
+		fi
+	fi
+	type "$@"
+}
+
+__helm_compgen() {
+	local completions w
+	completions=( $(compgen "$@") ) || return $?
+
+	# filter by given word as prefix
+	while [[ "$1" = -* && "$1" != -- ]]; do
+		shift
+		shift
+	done
+	if [[ "$1" == -- ]]; then
+		shift
+	fi
+	for w in "${completions[@]}"; do
+		if [[ "${w}" = "$1"* ]]; then
+			echo "${w}"
+		fi
+	done
+}
+
+__helm_compopt() {
+	true # don't do anything. Not supported by bashcompinit in zsh
+}
+
+__helm_declare() {
+	if [ "$1" == "-F" ]; then
+		whence -w "$@"
+	else
+		builtin declare "$@"
+	fi
+}
+
+__helm_ltrim_colon_completions()
+{
+	if [[ "$1" == *:* && "$COMP_WORDBREAKS" == *:* ]]; then
+		# Remove colon-word prefix from COMPREPLY items
+		local colon_word=${1%${1##*:}}
+		local i=${#COMPREPLY[*]}
+		while [[ $((--i)) -ge 0 ]]; do
+			COMPREPLY[$i]=${COMPREPLY[$i]#"$colon_word"}
+		done
+	fi
+}
+
+__helm_get_comp_words_by_ref() {
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	prev="${COMP_WORDS[${COMP_CWORD}-1]}"
+	words=("${COMP_WORDS[@]}")
+	cword=("${COMP_CWORD[@]}")
+}
+
+__helm_filedir() {
+	local RET OLD_IFS w qw
+
+	__debug "_filedir $@ cur=$cur"
+	if [[ "$1" = \~* ]]; then
+		# somehow does not work. Maybe, zsh does not call this at all
+		eval echo "$1"
+		return 0
+	fi
+
+	OLD_IFS="$IFS"
+	IFS=$'\n'
+	if [ "$1" = "-d" ]; then
+		shift
+		RET=( $(compgen -d) )
+	else
+		RET=( $(compgen -f) )
+	fi
+	IFS="$OLD_IFS"
+
+	IFS="," __debug "RET=${RET[@]} len=${#RET[@]}"
+
+	for w in ${RET[@]}; do
+		if [[ ! "${w}" = "${cur}"* ]]; then
+			continue
+		fi
+		if eval "[[ \"\${w}\" = *.$1 || -d \"\${w}\" ]]"; then
+			qw="$(__helm_quote "${w}")"
+			if [ -d "${w}" ]; then
+				COMPREPLY+=("${qw}/")
+			else
+				COMPREPLY+=("${qw}")
+			fi
+		fi
+	done
+}
+
+__helm_quote() {
+    if [[ $1 == \'* || $1 == \"* ]]; then
+        # Leave out first character
+        printf %q "${1:1}"
+    else
+    	printf %q "$1"
+    fi
+}
+
+autoload -U +X bashcompinit && bashcompinit
+
+# use word boundary patterns for BSD or GNU sed
+LWORD='[[:<:]]'
+RWORD='[[:>:]]'
+if sed --help 2>&1 | grep -q GNU; then
+	LWORD='\<'
+	RWORD='\>'
+fi
+
+__helm_convert_bash_to_zsh() {
+	sed \
+	-e 's/declare -F/whence -w/' \
+	-e 's/local \([a-zA-Z0-9_]*\)=/local \1; \1=/' \
+	-e 's/flags+=("\(--.*\)=")/flags+=("\1"); two_word_flags+=("\1")/' \
+	-e 's/must_have_one_flag+=("\(--.*\)=")/must_have_one_flag+=("\1")/' \
+	-e "s/${LWORD}_filedir${RWORD}/__helm_filedir/g" \
+	-e "s/${LWORD}_get_comp_words_by_ref${RWORD}/__helm_get_comp_words_by_ref/g" \
+	-e "s/${LWORD}__ltrim_colon_completions${RWORD}/__helm_ltrim_colon_completions/g" \
+	-e "s/${LWORD}compgen${RWORD}/__helm_compgen/g" \
+	-e "s/${LWORD}compopt${RWORD}/__helm_compopt/g" \
+	-e "s/${LWORD}declare${RWORD}/__helm_declare/g" \
+	-e "s/\\\$(type${RWORD}/\$(__helm_type/g" \
+	<<'BASH_COMPLETION_EOF'
+`
+	if _, err := io.WriteString(out, zshInitialization); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := cmd.GenBashCompletion(buf); err != nil {
+		return err
+	}
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	zshTail := `
+BASH_COMPLETION_EOF
+}
+
+__helm_bash_source <(__helm_convert_bash_to_zsh)
+`
+	_, err := io.WriteString(out, zshTail)
+	return err
+}
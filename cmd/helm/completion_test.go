@@ -0,0 +1,80 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		expect []string
+		err    bool
+	}{
+		{"default", []string{}, []string{"_helm_install()", "flags+=(\"--force\")"}, false},
+		{"bash", []string{"bash"}, []string{"_helm_install()", "flags+=(\"--force\")"}, false},
+		{"zsh", []string{"zsh"}, []string{"bashcompinit", "_helm_install()", "__helm_bash_source <(__helm_convert_bash_to_zsh)"}, false},
+		{"unsupported shell", []string{"fish"}, nil, true},
+		{"too many arguments", []string{"bash", "zsh"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		root := &cobra.Command{Use: "helm"}
+		child := &cobra.Command{Use: "install", Run: func(*cobra.Command, []string) {}}
+		child.Flags().Bool("force", false, "")
+		root.AddCommand(child)
+		cmd := newCompletionCmd(buf)
+		root.AddCommand(cmd)
+
+		err := cmd.RunE(cmd, tt.args)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.name, tt.err, err)
+			continue
+		}
+		for _, e := range tt.expect {
+			if !strings.Contains(buf.String(), e) {
+				t.Errorf("%q: expected output to contain %q", tt.name, e)
+			}
+		}
+	}
+}
+
+func TestBashCompletionFuncCommands(t *testing.T) {
+	commands := map[string]bool{}
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		commands[strings.Replace(c.CommandPath(), " ", "_", -1)] = true
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(newRootCmd(new(bytes.Buffer)))
+
+	for _, name := range regexp.MustCompile(`\bhelm_[a-z_]+`).FindAllString(bashCompletionFunc, -1) {
+		if !commands[name] {
+			t.Errorf("%q completes the arguments of a command that does not exist", name)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/Masterminds/semver"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
@@ -52,13 +53,26 @@ something like this:
 do not exist, Helm will attempt to create them as it goes. If the given
 destination exists and there are files in that directory, conflicting files
 will be overwritten, but other files will be left alone.
+
+The generated Chart.yaml gets the version 0.1.0 and no appVersion. Use
+'--chart-version' and '--app-version' to set them when the chart is created:
+
+	$ helm create --chart-version 1.0.0 --app-version 2.4.1 foo
+
+Use '--starter' to scaffold the chart from a starter chart in
+$HELM_HOME/starters instead. '<CHARTNAME>' in the templates and values of the
+starter is replaced with the name of the new chart:
+
+	$ helm create foo --starter mystarter
 `
 
 type createCmd struct {
-	home    helmpath.Home
-	name    string
-	out     io.Writer
-	starter string
+	home         helmpath.Home
+	name         string
+	out          io.Writer
+	starter      string
+	chartVersion string
+	appVersion   string
 }
 
 func newCreateCmd(out io.Writer) *cobra.Command {
@@ -80,18 +94,27 @@ func newCreateCmd(out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&cc.starter, "starter", "p", "", "the named Helm starter scaffold")
+	f := cmd.Flags()
+	f.StringVarP(&cc.starter, "starter", "p", "", "the named Helm starter scaffold")
+	f.StringVar(&cc.chartVersion, "chart-version", "0.1.0", "the semver version to set in the generated Chart.yaml")
+	f.StringVar(&cc.appVersion, "app-version", "", "the appVersion to set in the generated Chart.yaml")
 	return cmd
 }
 
 func (c *createCmd) run() error {
+	// Verify that the chart version is a SemVer, and error out if it is not.
+	if _, err := semver.NewVersion(c.chartVersion); err != nil {
+		return fmt.Errorf("invalid chart version %q: %s", c.chartVersion, err)
+	}
+
 	fmt.Fprintf(c.out, "Creating %s\n", c.name)
 
 	chartname := filepath.Base(c.name)
 	cfile := &chart.Metadata{
 		Name:        chartname,
 		Description: "A Helm chart for Kubernetes",
-		Version:     "0.1.0",
+		Version:     c.chartVersion,
+		AppVersion:  c.appVersion,
 		ApiVersion:  chartutil.ApiVersionV1,
 	}
 
@@ -72,6 +72,38 @@ func TestCreateCmd(t *testing.T) {
 	}
 }
 
+func TestCreateCmdVersions(t *testing.T) {
+	cname := "testchart"
+	tdir, err := ioutil.TempDir("", "helm-create-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	cmd := newCreateCmd(ioutil.Discard)
+	cmd.ParseFlags([]string{"--chart-version", "1.2.3", "--app-version", "4.5.6"})
+	if err := cmd.RunE(cmd, []string{filepath.Join(tdir, cname)}); err != nil {
+		t.Fatalf("Failed to run create: %s", err)
+	}
+
+	c, err := chartutil.LoadDir(filepath.Join(tdir, cname))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Metadata.Version != "1.2.3" {
+		t.Errorf("Expected version %q, got %q", "1.2.3", c.Metadata.Version)
+	}
+	if c.Metadata.AppVersion != "4.5.6" {
+		t.Errorf("Expected appVersion %q, got %q", "4.5.6", c.Metadata.AppVersion)
+	}
+
+	cmd = newCreateCmd(ioutil.Discard)
+	cmd.ParseFlags([]string{"--chart-version", "not-a-version"})
+	if err := cmd.RunE(cmd, []string{filepath.Join(tdir, "other")}); err == nil {
+		t.Error("Expected error for invalid chart version")
+	}
+}
+
 func TestCreateStarterCmd(t *testing.T) {
 	cname := "testchart"
 	// Make a temp dir
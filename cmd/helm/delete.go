@@ -32,6 +32,17 @@ It removes all of the resources associated with the last release of the chart.
 
 Use the '--dry-run' flag to see which releases will be deleted without actually
 deleting them.
+
+Unless '--purge' is set, the history of the release is kept and the release is
+marked as DELETED. It can still be inspected with 'helm history', and brought
+back with 'helm rollback'. Passing revision 0 to 'helm rollback' restores the
+revision that was deleted:
+
+	$ helm delete my-release
+	$ helm rollback my-release 0
+
+To install a new chart under the name of a deleted release instead, use
+'helm install --replace'.
 `
 
 type deleteCmd struct {
@@ -31,6 +31,9 @@ Build is used to reconstruct a chart's dependencies to the state specified in
 the lock file. This will not re-negotiate dependencies, as 'helm dependency update'
 does.
 
+The lock file records the SHA256 digest of every chart that is downloaded from
+a repository. If a downloaded chart does not match its digest, the build fails.
+
 If no lock file is found, 'helm dependency build' will mirror the behavior
 of 'helm dependency update'.
 `
@@ -41,6 +44,7 @@ type dependencyBuildCmd struct {
 	verify    bool
 	keyring   string
 	helmhome  helmpath.Home
+	workers   int
 }
 
 func newDependencyBuildCmd(out io.Writer) *cobra.Command {
@@ -66,16 +70,23 @@ func newDependencyBuildCmd(out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVar(&dbc.verify, "verify", false, "verify the packages against signatures")
 	f.StringVar(&dbc.keyring, "keyring", defaultKeyring(), "keyring containing public keys")
+	f.IntVar(&dbc.workers, "workers", downloader.DefaultWorkers, "number of charts to download concurrently")
 
 	return cmd
 }
 
 func (d *dependencyBuildCmd) run() error {
+	plugins, err := downloaderPlugins(d.helmhome)
+	if err != nil {
+		return err
+	}
 	man := &downloader.Manager{
 		Out:       d.out,
 		ChartPath: d.chartpath,
 		HelmHome:  d.helmhome,
 		Keyring:   d.keyring,
+		Plugins:   plugins,
+		Workers:   d.workers,
 	}
 	if d.verify {
 		man.Verify = downloader.VerifyIfPossible
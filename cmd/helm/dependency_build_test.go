@@ -17,11 +17,15 @@ package main
 
 import (
 	"bytes"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
@@ -116,4 +120,29 @@ func TestDependencyBuildCmd(t *testing.T) {
 		t.Errorf("mismatched versions. Expected %q, got %q", "0.1.0", v)
 	}
 
+	// The lock pins the digest of each chart, and a chart that does not
+	// match it fails the build.
+	data, err := ioutil.ReadFile(lockfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := &chartutil.RequirementsLock{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		t.Fatal(err)
+	}
+	for _, dep := range lock.Dependencies {
+		if dep.Name == "reqtest" && dep.Digest != "sha256:"+hash {
+			t.Errorf("Expected the lock to pin reqtest to sha256:%s, got %q", hash, dep.Digest)
+		}
+		dep.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	}
+	if data, err = yaml.Marshal(lock); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(lockfile, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbc.run(); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected a digest mismatch error, got %v", err)
+	}
 }
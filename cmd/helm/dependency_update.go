@@ -32,7 +32,8 @@ are present in 'charts/' and are at an acceptable version. It will pull down
 the latest charts that satisfy the dependencies, and clean up old dependencies.
 
 On successful update, this will generate a lock file that can be used to
-rebuild the requirements to an exact version.
+rebuild the requirements to an exact version, and to the exact archives that
+were downloaded.
 
 Dependencies are not required to be represented in 'requirements.yaml'. For that
 reason, an update command will not remove charts unless they are (a) present
@@ -47,6 +48,7 @@ type dependencyUpdateCmd struct {
 	verify      bool
 	keyring     string
 	skipRefresh bool
+	workers     int
 }
 
 // newDependencyUpdateCmd creates a new dependency update command.
@@ -82,18 +84,25 @@ func newDependencyUpdateCmd(out io.Writer) *cobra.Command {
 	f.BoolVar(&duc.verify, "verify", false, "verify the packages against signatures")
 	f.StringVar(&duc.keyring, "keyring", defaultKeyring(), "keyring containing public keys")
 	f.BoolVar(&duc.skipRefresh, "skip-refresh", false, "do not refresh the local repository cache")
+	f.IntVar(&duc.workers, "workers", downloader.DefaultWorkers, "number of charts to download concurrently")
 
 	return cmd
 }
 
 // run runs the full dependency update process.
 func (d *dependencyUpdateCmd) run() error {
+	plugins, err := downloaderPlugins(d.helmhome)
+	if err != nil {
+		return err
+	}
 	man := &downloader.Manager{
 		Out:        d.out,
 		ChartPath:  d.chartpath,
 		HelmHome:   d.helmhome,
 		Keyring:    d.keyring,
 		SkipUpdate: d.skipRefresh,
+		Plugins:    plugins,
+		Workers:    d.workers,
 	}
 	if d.verify {
 		man.Verify = downloader.VerifyIfPossible
@@ -103,3 +112,20 @@ func (d *dependencyUpdateCmd) run() error {
 	}
 	return man.Update()
 }
+
+// updateDependencies updates the charts/ directory of the chart directory
+// chartpath, as 'helm dependency update' does with its default flags.
+func updateDependencies(out io.Writer, chartpath string, home helmpath.Home) error {
+	plugins, err := downloaderPlugins(home)
+	if err != nil {
+		return err
+	}
+	man := &downloader.Manager{
+		Out:       out,
+		ChartPath: chartpath,
+		HelmHome:  home,
+		Plugins:   plugins,
+		Debug:     flagDebug,
+	}
+	return man.Update()
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	util "k8s.io/helm/pkg/releaseutil"
+)
+
+const diffDesc = `
+This command shows what an upgrade would change in a release. It compares the
+manifests of the currently deployed release with the manifests that Tiller
+renders for the given chart and values, without changing anything.
+
+The arguments and the value flags are the same as for 'helm upgrade':
+
+	$ helm diff -f myvalues.yaml redis ./redis
+
+The changes are shown as a unified diff per resource. Use '--color' to colorize
+the diff, or '--output json' to get the changes as a JSON array.
+`
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+const (
+	colorReset = "\x1b[0m"
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+	colorBold  = "\x1b[1m"
+)
+
+type diffCmd struct {
+	release      string
+	chart        string
+	out          io.Writer
+	client       helm.Interface
+	valueFiles   valueFiles
+	values       []string
+	stringValues []string
+	fileValues   []string
+	verify       bool
+	keyring      string
+	version      string
+	resetValues  bool
+	reuseValues  bool
+	color        bool
+	output       string
+}
+
+func newDiffCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	d := &diffCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "diff [flags] RELEASE CHART",
+		Short:             "show the changes an upgrade would make to a release",
+		Long:              diffDesc,
+		PersistentPreRunE: setupConnection,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "chart path"); err != nil {
+				return err
+			}
+			d.release = args[0]
+			d.chart = args[1]
+			d.client = ensureHelmClient(d.client)
+			return d.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.VarP(&d.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
+	f.StringArrayVar(&d.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&d.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&d.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.BoolVar(&d.verify, "verify", false, "verify the provenance of the chart before comparing")
+	f.StringVar(&d.keyring, "keyring", defaultKeyring(), "path to the keyring that contains public signing keys")
+	f.StringVar(&d.version, "version", "", "specify the exact chart version to use. If this is not specified, the latest version is used")
+	f.BoolVar(&d.resetValues, "reset-values", false, "compare as if upgrading with --reset-values")
+	f.BoolVar(&d.reuseValues, "reuse-values", false, "compare as if upgrading with --reuse-values")
+	f.BoolVar(&d.color, "color", false, "colorize the diff")
+	f.StringVarP(&d.output, "output", "o", "", "output format. Allowed values: json")
+
+	return cmd
+}
+
+func (d *diffCmd) run() error {
+	if d.output != "" && d.output != "json" {
+		return fmt.Errorf("unknown output format %q", d.output)
+	}
+
+	chartPath, err := locateChartPath(d.chart, d.version, d.verify, d.keyring)
+	if err != nil {
+		return err
+	}
+
+	rawVals, err := vals(d.valueFiles, d.values, d.stringValues, d.fileValues)
+	if err != nil {
+		return err
+	}
+
+	current, err := d.client.ReleaseContent(d.release)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	proposed, err := d.client.UpdateRelease(
+		d.release,
+		chartPath,
+		helm.UpdateValueOverrides(rawVals),
+		helm.UpgradeDryRun(true),
+		helm.ResetValues(d.resetValues),
+		helm.ReuseValues(d.reuseValues))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	diffs, err := diffManifests(current.Release.Manifest, proposed.Release.Manifest)
+	if err != nil {
+		return err
+	}
+
+	if d.output == "json" {
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(d.out, string(data))
+		return nil
+	}
+
+	for _, rd := range diffs {
+		d.printDiff(rd)
+	}
+	return nil
+}
+
+func (d *diffCmd) printDiff(rd *resourceDiff) {
+	paint := func(color, s string) string {
+		if !d.color {
+			return s
+		}
+		return color + s + colorReset
+	}
+
+	fmt.Fprintln(d.out, paint(colorBold, fmt.Sprintf("%s, %s/%s has been %s:", rd.Namespace, rd.Kind, rd.Name, rd.Change)))
+	for _, line := range strings.Split(strings.TrimSuffix(rd.Diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "@@"):
+			line = paint(colorCyan, line)
+		case strings.HasPrefix(line, "-"):
+			line = paint(colorRed, line)
+		case strings.HasPrefix(line, "+"):
+			line = paint(colorGreen, line)
+		}
+		fmt.Fprintln(d.out, line)
+	}
+	fmt.Fprintln(d.out)
+}
+
+// resourceDiff describes the change to a single resource of a release.
+type resourceDiff struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Change is one of "added", "removed" or "changed".
+	Change string `json:"change"`
+	// Diff is the unified diff of the resource manifest.
+	Diff string `json:"diff"`
+}
+
+// diffManifests compares two manifest streams resource by resource. Only the
+// resources that differ are returned, sorted by kind, namespace and name.
+func diffManifests(current, proposed string) ([]*resourceDiff, error) {
+	cur, err := manifestResources(current)
+	if err != nil {
+		return nil, err
+	}
+	prop, err := manifestResources(proposed)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for k := range cur {
+		keys = append(keys, k)
+	}
+	for k := range prop {
+		if _, ok := cur[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	diffs := []*resourceDiff{}
+	for _, k := range keys {
+		before, after := cur[k], prop[k]
+		var rd *resourceDiff
+		switch {
+		case before == nil:
+			rd = &resourceDiff{Kind: after.kind, Name: after.name, Namespace: after.namespace, Change: "added"}
+			rd.Diff = unifiedDiff(nil, lines(after.content), "/dev/null", after.title())
+		case after == nil:
+			rd = &resourceDiff{Kind: before.kind, Name: before.name, Namespace: before.namespace, Change: "removed"}
+			rd.Diff = unifiedDiff(lines(before.content), nil, before.title(), "/dev/null")
+		case before.content != after.content:
+			rd = &resourceDiff{Kind: after.kind, Name: after.name, Namespace: after.namespace, Change: "changed"}
+			rd.Diff = unifiedDiff(lines(before.content), lines(after.content), before.title(), after.title())
+		default:
+			continue
+		}
+		diffs = append(diffs, rd)
+	}
+	return diffs, nil
+}
+
+type manifestResource struct {
+	kind      string
+	name      string
+	namespace string
+	content   string
+}
+
+func (m *manifestResource) title() string {
+	return m.kind + "/" + m.name
+}
+
+// manifestResources splits a manifest stream into its resources, keyed by
+// kind, namespace and name.
+func manifestResources(manifest string) (map[string]*manifestResource, error) {
+	res := map[string]*manifestResource{}
+	for k, doc := range util.SplitManifests(manifest) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var head util.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil {
+			return nil, fmt.Errorf("YAML parse error on %s: %s", k, err)
+		}
+		if head.Kind == "" || head.Metadata == nil {
+			continue
+		}
+		r := &manifestResource{
+			kind:      head.Kind,
+			name:      head.Metadata.Name,
+			namespace: head.Metadata.Namespace,
+			content:   strings.TrimSpace(doc) + "\n",
+		}
+		res[r.kind+"/"+r.namespace+"/"+r.name] = r
+	}
+	return res, nil
+}
+
+func lines(s string) []string {
+	return strings.SplitAfter(s, "\n")[:strings.Count(s, "\n")]
+}
+
+// unifiedDiff returns the differences between a and b in the unified diff
+// format. Every line of a and b must end with a newline.
+func unifiedDiff(a, b []string, fromName, toName string) string {
+	// Compute the longest common subsequence table, from the end.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	// Walk the table to produce the edit script.
+	type edit struct {
+		op   byte
+		line string
+		ai   int
+		bi   int
+	}
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i], i, j})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			edits = append(edits, edit{'+', b[j], i, j})
+			j++
+		default:
+			edits = append(edits, edit{'-', a[i], i, j})
+			i++
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(edits); {
+		// Find the next change.
+		for start < len(edits) && edits[start].op == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+		// Extend the hunk until diffContext*2 unchanged lines separate it
+		// from the next change.
+		end := start
+		for k := start; k < len(edits); k++ {
+			if edits[k].op != ' ' {
+				end = k + 1
+			} else if k-end >= 2*diffContext {
+				break
+			}
+		}
+		from := start - diffContext
+		if from < 0 {
+			from = 0
+		}
+		to := end + diffContext
+		if to > len(edits) {
+			to = len(edits)
+		}
+
+		aStart, bStart := edits[from].ai, edits[from].bi
+		aLen, bLen := 0, 0
+		for _, e := range edits[from:to] {
+			if e.op != '+' {
+				aLen++
+			}
+			if e.op != '-' {
+				bLen++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		for _, e := range edits[from:to] {
+			out.WriteByte(e.op)
+			out.WriteString(e.line)
+		}
+		start = to
+	}
+	return out.String()
+}
+
+// hunkRange formats the range of a hunk as in GNU diff.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
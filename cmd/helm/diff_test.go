@@ -0,0 +1,120 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   string
+		expect string
+	}{
+		{
+			name:   "changed line",
+			a:      "a\nb\nc\n",
+			b:      "a\nx\nc\n",
+			expect: "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			name:   "added file",
+			a:      "",
+			b:      "a\nb\n",
+			expect: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:   "separate hunks",
+			a:      "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			b:      "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\ny\n",
+			expect: "--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -8,4 +8,4 @@\n 8\n 9\n 10\n-11\n+y\n",
+		},
+	}
+
+	for _, tt := range tests {
+		got := unifiedDiff(lines(tt.a), lines(tt.b), "from", "to")
+		if got != tt.expect {
+			t.Errorf("%s: expected\n%q\ngot\n%q", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestDiffManifests(t *testing.T) {
+	current := `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: old
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+`
+	proposed := `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: new
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+`
+
+	diffs, err := diffManifests(current, proposed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []struct {
+		kind, name, change string
+	}{
+		{"ConfigMap", "config", "changed"},
+		{"Pod", "pod", "added"},
+		{"Secret", "secret", "removed"},
+	}
+	if len(diffs) != len(expect) {
+		t.Fatalf("expected %d diffs, got %d", len(expect), len(diffs))
+	}
+	for i, e := range expect {
+		d := diffs[i]
+		if d.Kind != e.kind || d.Name != e.name || d.Change != e.change {
+			t.Errorf("expected %s/%s %s, got %s/%s %s", e.kind, e.name, e.change, d.Kind, d.Name, d.Change)
+		}
+	}
+	if expect := "-  key: old\n+  key: new\n"; !strings.Contains(diffs[0].Diff, expect) {
+		t.Errorf("expected diff to contain %q, got %q", expect, diffs[0].Diff)
+	}
+}
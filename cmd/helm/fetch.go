@@ -42,6 +42,18 @@ directory for the chart and uncomparess into that directory.
 If the --verify flag is specified, the requested chart MUST have a provenance
 file, and MUST pass the verification process. Failure in any part of this will
 result in an error, and the chart will not be saved locally.
+
+A chart can also be fetched from any URL. If the --digest flag is specified,
+the archive MUST match the given SHA256 digest, or it will not be saved:
+
+	$ helm fetch https://example.com/charts/mychart-0.1.0.tgz --digest sha256:6a6e...
+
+Charts stored in OCI registries are fetched with an 'oci://' reference. The
+version may be given as the tag of the reference or with '--version'. Registry
+credentials are read from the Docker configuration, as written by
+'docker login':
+
+	$ helm fetch oci://registry.example.com/charts/mychart:0.1.0
 `
 
 type fetchCmd struct {
@@ -54,6 +66,7 @@ type fetchCmd struct {
 	verify      bool
 	verifyLater bool
 	keyring     string
+	digest      string
 
 	out io.Writer
 }
@@ -62,7 +75,7 @@ func newFetchCmd(out io.Writer) *cobra.Command {
 	fch := &fetchCmd{out: out}
 
 	cmd := &cobra.Command{
-		Use:   "fetch [flags] [chart URL | repo/chartname] [...]",
+		Use:   "fetch [flags] [chart URL | repo/chartname | oci://registry/chart] [...]",
 		Short: "download a chart from a repository and (optionally) unpack it in local directory",
 		Long:  fetchDesc,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -86,17 +99,25 @@ func newFetchCmd(out io.Writer) *cobra.Command {
 	f.BoolVar(&fch.verifyLater, "prov", false, "fetch the provenance file, but don't perform verification")
 	f.StringVar(&fch.version, "version", "", "specific version of a chart. Without this, the latest version is fetched")
 	f.StringVar(&fch.keyring, "keyring", defaultKeyring(), "keyring containing public keys")
+	f.StringVar(&fch.digest, "digest", "", "expected SHA256 digest of the chart archive (sha256:HEX). The chart is not saved if it does not match")
 	f.StringVarP(&fch.destdir, "destination", "d", ".", "location to write the chart. If this and tardir are specified, tardir is appended to this")
 
 	return cmd
 }
 
 func (f *fetchCmd) run() error {
+	home := helmpath.Home(homePath())
+	plugins, err := downloaderPlugins(home)
+	if err != nil {
+		return err
+	}
 	c := downloader.ChartDownloader{
-		HelmHome: helmpath.Home(homePath()),
+		HelmHome: home,
 		Out:      f.out,
 		Keyring:  f.keyring,
 		Verify:   downloader.VerifyNever,
+		Plugins:  plugins,
+		Digest:   f.digest,
 	}
 
 	if f.verify {
@@ -109,7 +130,6 @@ func (f *fetchCmd) run() error {
 	// verification.
 	dest := f.destdir
 	if f.untar {
-		var err error
 		dest, err = ioutil.TempDir("", "helm-")
 		if err != nil {
 			return fmt.Errorf("Failed to untar: %s", err)
@@ -146,7 +166,22 @@ func (f *fetchCmd) run() error {
 	return nil
 }
 
-// defaultKeyring returns the expanded path to the default keyring.
+// defaultKeyring returns the expanded path to the default keyring for
+// verification: the keyring managed by 'helm keys' if it exists, and the
+// GnuPG public keyring otherwise.
 func defaultKeyring() string {
+	if ring := helmpath.Home(defaultHelmHome()).Keyring(); fileExists(ring) {
+		return ring
+	}
+	return gnupgKeyring()
+}
+
+// gnupgKeyring returns the expanded path to the GnuPG public keyring.
+func gnupgKeyring() string {
 	return os.ExpandEnv("$HOME/.gnupg/pubring.gpg")
 }
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -88,6 +88,19 @@ func TestFetchCmd(t *testing.T) {
 			failExpect: "Failed to fetch provenance",
 			fail:       true,
 		},
+		{
+			name:       "Fetch with digest",
+			chart:      "test/signtest",
+			flags:      []string{"--digest", "sha256:dee72947753628425b82814516bdaa37aef49f25e8820dd2a6e15a33a007823b"},
+			expectFile: "./signtest-0.1.0.tgz",
+		},
+		{
+			name:       "Fail fetch with wrong digest",
+			chart:      "test/signtest",
+			flags:      []string{"--digest", "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+			failExpect: "does not match",
+			fail:       true,
+		},
 		{
 			name:       "Fetch and untar",
 			chart:      "test/signtest",
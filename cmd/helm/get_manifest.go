@@ -19,10 +19,19 @@ package main
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	util "k8s.io/helm/pkg/releaseutil"
 )
 
 var getManifestHelp = `
@@ -31,13 +40,27 @@ This command fetches the generated manifest for a given release.
 A manifest is a YAML-encoded representation of the Kubernetes resources that
 were generated from this release's chart(s). If a chart is dependent on other
 charts, those resources will also be included in the manifest.
+
+With '--split', each resource is written to its own file named 'kind-name.yaml'
+in the directory given by '--output-dir' instead of being printed. If two
+resources would share a file name, the namespace is appended to the name.
+
+Use '--filter' to select resources by kind and name, for example
+'--filter kind=Deployment,name=web'. Both keys are optional, and the kind is
+matched case-insensitively. With several filters, resources that match any of
+them are selected:
+
+	$ helm get manifest --filter kind=Deployment --filter kind=Service,name=web RELEASE_NAME
 `
 
 type getManifestCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	version   int32
+	split     bool
+	outputDir string
+	filters   []string
 }
 
 func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -55,22 +78,189 @@ func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
 			}
 			get.release = args[0]
 			if get.client == nil {
-				get.client = helm.NewClient(helm.Host(tillerHost))
+				get.client = helm.NewClient(helm.Host(tillerHost), helm.ConnectTimeout(tillerConnectionTimeout))
 			}
 			return get.run()
 		},
 	}
 
-	cmd.Flags().Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	f := cmd.Flags()
+	f.Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	f.BoolVar(&get.split, "split", false, "write each resource in the manifest to its own file")
+	f.StringVar(&get.outputDir, "output-dir", ".", "directory to write the resource files to. Used if --split is true")
+	f.StringArrayVar(&get.filters, "filter", []string{}, "only include resources of the given kind and name (can specify multiple): kind=KIND,name=NAME")
 	return cmd
 }
 
 // getManifest implements 'helm get manifest'
 func (g *getManifestCmd) run() error {
+	filters, err := parseManifestFilters(g.filters)
+	if err != nil {
+		return err
+	}
 	res, err := g.client.ReleaseContent(g.release, helm.ContentReleaseVersion(g.version))
 	if err != nil {
 		return prettyError(err)
 	}
-	fmt.Fprintln(g.out, res.Release.Manifest)
+	if g.split {
+		return g.writeSplit(res.Release, filters)
+	}
+	if len(filters) == 0 {
+		fmt.Fprintln(g.out, res.Release.Manifest)
+		return nil
+	}
+
+	resources, err := resourceDocs(res.Release.Manifest)
+	if err != nil {
+		return err
+	}
+	for _, r := range resources {
+		if matchManifestFilters(filters, r.head) {
+			fmt.Fprintf(g.out, "---\n%s\n", strings.TrimSpace(r.doc))
+		}
+	}
 	return nil
 }
+
+// writeSplit writes every resource of the release manifest that matches the
+// filters to its own file. Resources whose file name would leave the output
+// directory are rejected.
+func (g *getManifestCmd) writeSplit(rel *release.Release, filters []manifestFilter) error {
+	resources, err := resourceDocs(rel.Manifest)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(g.outputDir, 0755); err != nil {
+		return fmt.Errorf("Could not create %s: %s", g.outputDir, err)
+	}
+
+	seen := map[string]bool{}
+	for _, r := range resources {
+		doc, head := r.doc, r.head
+		if !matchManifestFilters(filters, head) {
+			continue
+		}
+
+		base := strings.ToLower(head.Kind) + "-" + head.Metadata.Name
+		name := base
+		if seen[name] {
+			ns := head.Metadata.Namespace
+			if ns == "" {
+				ns = rel.Namespace
+			}
+			name = base + "-" + ns
+		}
+		for i := 1; seen[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		seen[name] = true
+		if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+			return fmt.Errorf("cannot write %s %q to %s: the file name %q must not contain path separators or \"..\"", head.Kind, head.Metadata.Name, g.outputDir, name+".yaml")
+		}
+
+		filename := filepath.Join(g.outputDir, name+".yaml")
+		if err := ioutil.WriteFile(filename, []byte(strings.TrimSpace(doc)+"\n"), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(g.out, "wrote %s\n", filename)
+	}
+	return nil
+}
+
+// resourceDoc is a document of a manifest that holds a resource.
+type resourceDoc struct {
+	doc  string
+	head util.SimpleHead
+}
+
+// resourceDocs splits manifest into the resources it holds, in document
+// order.
+func resourceDocs(manifest string) ([]resourceDoc, error) {
+	docs := util.SplitManifests(manifest)
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Sort(manifestKeys(keys))
+
+	resources := make([]resourceDoc, 0, len(keys))
+	for _, k := range keys {
+		doc := docs[k]
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var head util.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil {
+			return nil, fmt.Errorf("YAML parse error on %s: %s", k, err)
+		}
+		if head.Kind == "" || head.Metadata == nil || head.Metadata.Name == "" {
+			// Documents holding only comments carry no resource.
+			continue
+		}
+		resources = append(resources, resourceDoc{doc: doc, head: head})
+	}
+	return resources, nil
+}
+
+// manifestFilter selects resources by kind and name. Empty fields match any
+// resource.
+type manifestFilter struct {
+	kind string
+	name string
+}
+
+// parseManifestFilters parses filters of the form kind=KIND,name=NAME.
+func parseManifestFilters(filters []string) ([]manifestFilter, error) {
+	parsed := make([]manifestFilter, 0, len(filters))
+	for _, f := range filters {
+		var mf manifestFilter
+		for _, kv := range strings.Split(f, ",") {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid filter %q: expected kind=KIND,name=NAME", f)
+			}
+			switch strings.TrimSpace(parts[0]) {
+			case "kind":
+				mf.kind = strings.TrimSpace(parts[1])
+			case "name":
+				mf.name = strings.TrimSpace(parts[1])
+			default:
+				return nil, fmt.Errorf("invalid filter %q: unknown key %q", f, parts[0])
+			}
+		}
+		parsed = append(parsed, mf)
+	}
+	return parsed, nil
+}
+
+// matchManifestFilters reports whether the resource with head matches any of
+// the filters. Without filters, every resource matches.
+func matchManifestFilters(filters []manifestFilter, head util.SimpleHead) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		if f.kind != "" && !strings.EqualFold(f.kind, head.Kind) {
+			continue
+		}
+		if f.name != "" && f.name != head.Metadata.Name {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// manifestKeys sorts the keys returned by SplitManifests in document order.
+type manifestKeys []string
+
+func (m manifestKeys) Len() int      { return len(m) }
+func (m manifestKeys) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m manifestKeys) Less(i, j int) bool {
+	return manifestIndex(m[i]) < manifestIndex(m[j])
+}
+
+func manifestIndex(key string) int {
+	i, _ := strconv.Atoi(key[strings.LastIndex(key, "-")+1:])
+	return i
+}
@@ -17,10 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 func TestGetManifest(t *testing.T) {
@@ -31,6 +37,34 @@ func TestGetManifest(t *testing.T) {
 			expected: mockManifest,
 			resp:     releaseMock(&releaseOptions{name: "juno"}),
 		},
+		{
+			name:     "get manifest with a kind filter",
+			args:     []string{"juno"},
+			flags:    []string{"--filter", "kind=service"},
+			expected: "^---\n# Source: foo/templates/service.yaml\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n$",
+			resp:     releaseWithManifest(splitManifest),
+		},
+		{
+			name:     "get manifest with several filters",
+			args:     []string{"juno"},
+			flags:    []string{"--filter", "kind=Secret,name=fixture", "--filter", "name=web"},
+			expected: "secret.yaml(.|\n)*other-secret.yaml(.|\n)*service.yaml",
+			resp:     releaseWithManifest(splitManifest),
+		},
+		{
+			name:     "get manifest with a filter that matches nothing",
+			args:     []string{"juno"},
+			flags:    []string{"--filter", "kind=Deployment"},
+			expected: "^$",
+			resp:     releaseWithManifest(splitManifest),
+		},
+		{
+			name:  "get manifest with an invalid filter",
+			args:  []string{"juno"},
+			flags: []string{"--filter", "namespace=other"},
+			err:   true,
+			resp:  releaseWithManifest(splitManifest),
+		},
 		{
 			name: "get manifest without args",
 			args: []string{},
@@ -41,3 +75,102 @@ func TestGetManifest(t *testing.T) {
 		return newGetManifestCmd(c, out)
 	})
 }
+
+var splitManifest = `---
+# Source: foo/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: fixture
+---
+# Source: foo/templates/other-secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: fixture
+  namespace: other
+---
+# Source: foo/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+func releaseWithManifest(manifest string) *release.Release {
+	rel := releaseMock(&releaseOptions{name: "juno"})
+	rel.Manifest = manifest
+	return rel
+}
+
+func TestGetManifestSplit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-get-manifest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rel := releaseMock(&releaseOptions{name: "juno"})
+	rel.Manifest = splitManifest
+
+	var buf bytes.Buffer
+	c := &fakeReleaseClient{rels: []*release.Release{rel}}
+	cmd := newGetManifestCmd(c, &buf)
+	cmd.ParseFlags([]string{"--split", "--output-dir", dir})
+	if err := cmd.RunE(cmd, []string{"juno"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]string{
+		"secret-fixture.yaml":       "# Source: foo/templates/secret.yaml",
+		"secret-fixture-other.yaml": "# Source: foo/templates/other-secret.yaml",
+		"service-web.yaml":          "# Source: foo/templates/service.yaml",
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(expect) {
+		t.Errorf("expected %d files, got %d", len(expect), len(files))
+	}
+	for name, source := range expect {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("expected file %s: %s", name, err)
+			continue
+		}
+		if !bytes.HasPrefix(data, []byte(source)) {
+			t.Errorf("expected %s to start with %q, got %q", name, source, data)
+		}
+	}
+}
+
+func TestGetManifestSplitRejectsPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-get-manifest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "out")
+
+	for _, manifest := range []string{
+		"apiVersion: v1\nkind: Secret\nmetadata:\n  name: ../../escaped\n",
+		"apiVersion: v1\nkind: Secret\nmetadata:\n  name: a/b\n",
+		"apiVersion: v1\nkind: ../Secret\nmetadata:\n  name: fixture\n",
+	} {
+		var buf bytes.Buffer
+		c := &fakeReleaseClient{rels: []*release.Release{releaseWithManifest(manifest)}}
+		cmd := newGetManifestCmd(c, &buf)
+		cmd.ParseFlags([]string{"--split", "--output-dir", out})
+		if err := cmd.RunE(cmd, []string{"juno"}); err == nil {
+			t.Errorf("expected an error for %q", manifest)
+		}
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "out" {
+		t.Errorf("expected nothing to be written outside the output directory, got %v", files)
+	}
+}
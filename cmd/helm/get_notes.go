@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const getNotesHelp = `
+This command shows the notes of a given release, as rendered from the
+NOTES.txt of its chart.
+`
+
+type getNotesCmd struct {
+	release string
+	out     io.Writer
+	client  helm.Interface
+	version int32
+}
+
+func newGetNotesCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	get := &getNotesCmd{
+		out:    out,
+		client: client,
+	}
+	cmd := &cobra.Command{
+		Use:   "notes [flags] RELEASE_NAME",
+		Short: "display the notes of a named release",
+		Long:  getNotesHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			get.release = args[0]
+			get.client = ensureHelmClient(get.client)
+			return get.run()
+		},
+	}
+	cmd.Flags().Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	return cmd
+}
+
+// getNotes implements 'helm get notes'
+func (g *getNotesCmd) run() error {
+	res, err := g.client.ReleaseContent(g.release, helm.ContentReleaseVersion(g.version))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	if notes := res.Release.Info.Status.Notes; len(notes) > 0 {
+		fmt.Fprintf(g.out, "NOTES:\n%s\n", notes)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetNotesCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "get notes with a release",
+			resp:     releaseWithNotes("Visit http://example.com"),
+			args:     []string{"flummoxed-chickadee"},
+			expected: "^NOTES:\nVisit http://example.com\n$",
+		},
+		{
+			name:     "get notes of a release without notes",
+			resp:     releaseMock(&releaseOptions{name: "flummoxed-chickadee"}),
+			args:     []string{"flummoxed-chickadee"},
+			expected: "^$",
+		},
+		{
+			name: "get notes requires release name arg",
+			err:  true,
+		},
+	}
+	runReleaseCases(t, tests, func(c *fakeReleaseClient, out io.Writer) *cobra.Command {
+		return newGetNotesCmd(c, out)
+	})
+}
+
+func releaseWithNotes(notes string) *release.Release {
+	rel := releaseMock(&releaseOptions{name: "flummoxed-chickadee"})
+	rel.Info.Status.Notes = notes
+	return rel
+}
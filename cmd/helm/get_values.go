@@ -28,11 +28,15 @@ import (
 
 var getValuesHelp = `
 This command downloads a values file for a given release.
+
+Use '--compact' to leave out keys whose values are null, empty strings, empty
+maps or empty lists. This is most useful together with '--all'.
 `
 
 type getValuesCmd struct {
 	release   string
 	allValues bool
+	compact   bool
 	out       io.Writer
 	client    helm.Interface
 	version   int32
@@ -59,6 +63,7 @@ func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	cmd.Flags().Int32Var(&get.version, "revision", 0, "get the named release with revision")
 	cmd.Flags().BoolVarP(&get.allValues, "all", "a", false, "dump all (computed) values")
+	cmd.Flags().BoolVar(&get.compact, "compact", false, "omit keys with null or empty values")
 	return cmd
 }
 
@@ -75,6 +80,23 @@ func (g *getValuesCmd) run() error {
 		if err != nil {
 			return err
 		}
+		if g.compact {
+			cfg = compactValues(cfg)
+		}
+		cfgStr, err := cfg.YAML()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(g.out, cfgStr)
+		return nil
+	}
+
+	if g.compact {
+		cfg, err := chartutil.ReadValues([]byte(res.Release.Config.Raw))
+		if err != nil {
+			return err
+		}
+		cfg = compactValues(cfg)
 		cfgStr, err := cfg.YAML()
 		if err != nil {
 			return err
@@ -86,3 +108,44 @@ func (g *getValuesCmd) run() error {
 	fmt.Fprintln(g.out, res.Release.Config.Raw)
 	return nil
 }
+
+// compactValues recursively removes keys whose values are null, empty strings,
+// empty maps or empty lists. Maps that become empty are removed as well.
+func compactValues(vals map[string]interface{}) map[string]interface{} {
+	for k, v := range vals {
+		if v = compactValue(v); isEmptyValue(v) {
+			delete(vals, k)
+		} else {
+			vals[k] = v
+		}
+	}
+	return vals
+}
+
+func compactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return compactValues(val)
+	case chartutil.Values:
+		return compactValues(val)
+	case []interface{}:
+		for i := range val {
+			val[i] = compactValue(val[i])
+		}
+	}
+	return v
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case map[string]interface{}:
+		return len(val) == 0
+	case []interface{}:
+		return len(val) == 0
+	}
+	return false
+}
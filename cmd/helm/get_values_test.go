@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 func TestGetValuesCmd(t *testing.T) {
@@ -31,6 +34,20 @@ func TestGetValuesCmd(t *testing.T) {
 			args:     []string{"thomas-guide"},
 			expected: "name: \"value\"",
 		},
+		{
+			name:     "get values with --compact",
+			resp:     releaseWithConfig("name: value\nempty: \"\"\nnothing: null\nlist: []\nnested:\n  keep: 1\n  drop: {}\nallEmpty:\n  inner: null\n"),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--compact"},
+			expected: "^name: value\nnested:\n  keep: 1\n\n$",
+		},
+		{
+			name:     "get values with --all",
+			resp:     releaseWithDefaults("name: default\nreplicas: 1\n"),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--all"},
+			expected: "^name: value\nreplicas: 1\n\n$",
+		},
 		{
 			name: "get values requires release name arg",
 			err:  true,
@@ -41,3 +58,15 @@ func TestGetValuesCmd(t *testing.T) {
 	}
 	runReleaseCases(t, tests, cmd)
 }
+
+func releaseWithConfig(raw string) *release.Release {
+	rel := releaseMock(&releaseOptions{name: "thomas-guide"})
+	rel.Config = &chart.Config{Raw: raw}
+	return rel
+}
+
+func releaseWithDefaults(raw string) *release.Release {
+	rel := releaseMock(&releaseOptions{name: "thomas-guide"})
+	rel.Chart.Values = &chart.Config{Raw: raw}
+	return rel
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
@@ -60,6 +61,8 @@ var (
 	tillerHost      string
 	tillerNamespace string
 	kubeContext     string
+	// tillerConnectionTimeout is the time in seconds to wait for a connection to tiller
+	tillerConnectionTimeout int64
 	// TODO refactor out this global var
 	tillerTunnel *kube.Tunnel
 )
@@ -93,10 +96,11 @@ Environment:
 
 func newRootCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "helm",
-		Short:        "The Helm package manager for Kubernetes.",
-		Long:         globalUsage,
-		SilenceUsage: true,
+		Use:                    "helm",
+		Short:                  "The Helm package manager for Kubernetes.",
+		Long:                   globalUsage,
+		SilenceUsage:           true,
+		BashCompletionFunction: bashCompletionFunc,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			tlsCaCertFile = os.ExpandEnv(tlsCaCertFile)
 			tlsCertFile = os.ExpandEnv(tlsCertFile)
@@ -112,6 +116,7 @@ func newRootCmd(out io.Writer) *cobra.Command {
 	p.StringVar(&kubeContext, "kube-context", "", "name of the kubeconfig context to use")
 	p.BoolVar(&flagDebug, "debug", false, "enable verbose output")
 	p.StringVar(&tillerNamespace, "tiller-namespace", defaultTillerNamespace(), "namespace of tiller")
+	p.Int64Var(&tillerConnectionTimeout, "tiller-connection-timeout", 300, "the duration (in seconds) Helm will wait to establish a connection to tiller")
 
 	cmd.AddCommand(
 		// chart commands
@@ -119,15 +124,19 @@ func newRootCmd(out io.Writer) *cobra.Command {
 		newDependencyCmd(out),
 		newFetchCmd(out),
 		newInspectCmd(out),
+		newKeysCmd(out),
 		newLintCmd(out),
 		newPackageCmd(out),
+		newPushCmd(out),
 		newRepoCmd(out),
 		newSearchCmd(out),
 		newServeCmd(out),
+		newTemplateCmd(out),
 		newVerifyCmd(out),
 
 		// release commands
 		addFlagsTLS(newDeleteCmd(nil, out)),
+		addFlagsTLS(newDiffCmd(nil, out)),
 		addFlagsTLS(newGetCmd(nil, out)),
 		addFlagsTLS(newHistoryCmd(nil, out)),
 		addFlagsTLS(newInstallCmd(nil, out)),
@@ -184,7 +193,7 @@ func setupConnection(c *cobra.Command, args []string) error {
 			return err
 		}
 
-		tunnel, err := portforwarder.New(tillerNamespace, client, config)
+		tunnel, err := newTunnel(client, config)
 		if err != nil {
 			return err
 		}
@@ -203,6 +212,33 @@ func setupConnection(c *cobra.Command, args []string) error {
 	return nil
 }
 
+// newTunnel opens a port-forward tunnel to the tiller pod, giving up once
+// tillerConnectionTimeout has passed.
+func newTunnel(client *internalclientset.Clientset, config *restclient.Config) (*kube.Tunnel, error) {
+	type result struct {
+		tunnel *kube.Tunnel
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		t, err := portforwarder.New(tillerNamespace, client, config)
+		done <- result{t, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.tunnel, r.err
+	case <-time.After(time.Duration(tillerConnectionTimeout) * time.Second):
+		// Close the tunnel should it still come up, so that it does not leak.
+		go func() {
+			if r := <-done; r.tunnel != nil {
+				r.tunnel.Close()
+			}
+		}()
+		return nil, fmt.Errorf("timed out after %ds waiting to establish a tunnel to tiller in namespace %q (see --tiller-connection-timeout)", tillerConnectionTimeout, tillerNamespace)
+	}
+}
+
 func teardown() {
 	if tillerTunnel != nil {
 		tillerTunnel.Close()
@@ -285,7 +321,7 @@ func ensureHelmClient(h helm.Interface) helm.Interface {
 }
 
 func newClient() helm.Interface {
-	options := []helm.Option{helm.Host(tillerHost)}
+	options := []helm.Option{helm.Host(tillerHost), helm.ConnectTimeout(tillerConnectionTimeout)}
 
 	if tlsVerify || tlsEnable {
 		tlsopts := tlsutil.Options{KeyFile: tlsKeyFile, CertFile: tlsCertFile, InsecureSkipVerify: true}
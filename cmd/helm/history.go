@@ -17,9 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
@@ -43,13 +46,17 @@ The historical release set is printed as a formatted table, e.g:
     2           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Upgraded successfully
     3           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Rolled back to 2
     4           Mon Oct 3 10:15:13 2016     DEPLOYED        alpine-0.1.0  Upgraded successfully
+
+Use '--output json' or '--output yaml' to print the revisions as a list of
+objects with the same fields instead.
 `
 
 type historyCmd struct {
-	max   int32
-	rls   string
-	out   io.Writer
-	helmc helm.Interface
+	max    int32
+	rls    string
+	out    io.Writer
+	helmc  helm.Interface
+	output string
 }
 
 func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
@@ -66,7 +73,7 @@ func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
 			case len(args) == 0:
 				return errReleaseRequired
 			case his.helmc == nil:
-				his.helmc = helm.NewClient(helm.Host(tillerHost))
+				his.helmc = helm.NewClient(helm.Host(tillerHost), helm.ConnectTimeout(tillerConnectionTimeout))
 			}
 			his.rls = args[0]
 			return his.run()
@@ -74,23 +81,69 @@ func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().Int32Var(&his.max, "max", 256, "maximum number of revision to include in history")
+	cmd.Flags().StringVarP(&his.output, "output", "o", "table", "output format. Allowed values: table, json, yaml")
 
 	return cmd
 }
 
 func (cmd *historyCmd) run() error {
+	if cmd.output != "table" && cmd.output != "json" && cmd.output != "yaml" {
+		return fmt.Errorf("unknown output format %q", cmd.output)
+	}
+
 	r, err := cmd.helmc.ReleaseHistory(cmd.rls, helm.WithMaxHistory(cmd.max))
 	if err != nil {
 		return prettyError(err)
 	}
-	if len(r.Releases) == 0 {
-		return nil
-	}
 
-	fmt.Fprintln(cmd.out, formatHistory(r.Releases))
+	switch cmd.output {
+	case "json":
+		data, err := json.MarshalIndent(newReleaseHistory(r.Releases), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.out, string(data))
+	case "yaml":
+		data, err := yaml.Marshal(newReleaseHistory(r.Releases))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.out, strings.TrimSpace(string(data)))
+	default:
+		if len(r.Releases) == 0 {
+			return nil
+		}
+		fmt.Fprintln(cmd.out, formatHistory(r.Releases))
+	}
 	return nil
 }
 
+// releaseRevision is the machine-readable representation of a revision in
+// the history of a release.
+type releaseRevision struct {
+	Revision    int32  `json:"revision"`
+	Updated     string `json:"updated"`
+	Status      string `json:"status"`
+	Chart       string `json:"chart"`
+	Description string `json:"description"`
+}
+
+// newReleaseHistory returns the revisions of rls, oldest first.
+func newReleaseHistory(rls []*release.Release) []*releaseRevision {
+	history := make([]*releaseRevision, 0, len(rls))
+	for i := len(rls) - 1; i >= 0; i-- {
+		r := rls[i]
+		history = append(history, &releaseRevision{
+			Revision:    r.Version,
+			Updated:     timeconv.String(r.Info.LastDeployed),
+			Status:      r.Info.Status.Code.String(),
+			Chart:       formatChartname(r.Chart),
+			Description: r.Info.Description,
+		})
+	}
+	return history
+}
+
 func formatHistory(rls []*release.Release) string {
 	tbl := uitable.New()
 	tbl.MaxColWidth = 60
@@ -62,6 +62,33 @@ func TestHistoryCmd(t *testing.T) {
 			},
 			xout: "REVISION\tUPDATED                 \tSTATUS    \tCHART           \tDESCRIPTION \n3       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\tRelease mock\n4       \t(.*)\tDEPLOYED  \tfoo-0.1.0-beta.1\tRelease mock\n",
 		},
+		{
+			cmds: "helm history --output=json RELEASE_NAME",
+			desc: "get history as JSON",
+			args: []string{"--max=2", "--output=json", "angry-bird"},
+			resp: []*rpb.Release{
+				mk("angry-bird", 4, rpb.Status_DEPLOYED),
+				mk("angry-bird", 3, rpb.Status_SUPERSEDED),
+			},
+			xout: `^\[\n  {\n    "revision": 3,\n    "updated": ".*",\n    "status": "SUPERSEDED",\n    "chart": "foo-0.1.0-beta.1",\n    "description": "Release mock"\n  },\n  {\n    "revision": 4,(.|\n)*"status": "DEPLOYED"(.|\n)*\n\]\n$`,
+		},
+		{
+			cmds: "helm history --output=yaml RELEASE_NAME",
+			desc: "get history as YAML",
+			args: []string{"--output=yaml", "angry-bird"},
+			resp: []*rpb.Release{
+				mk("angry-bird", 2, rpb.Status_DEPLOYED),
+				mk("angry-bird", 1, rpb.Status_SUPERSEDED),
+			},
+			xout: "^- chart: foo-0.1.0-beta.1\n  description: Release mock\n  revision: 1\n  status: SUPERSEDED\n  updated: .*\n- chart: foo-0.1.0-beta.1\n  description: Release mock\n  revision: 2\n  status: DEPLOYED\n  updated: .*\n$",
+		},
+		{
+			cmds: "helm history --output=json RELEASE_NAME",
+			desc: "get empty history as JSON",
+			args: []string{"--output=json", "angry-bird"},
+			resp: []*rpb.Release{},
+			xout: "^\\[\\]\n$",
+		},
 	}
 
 	var buf bytes.Buffer
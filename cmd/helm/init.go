@@ -17,11 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
@@ -48,8 +51,38 @@ frequently working on the latest code, the flag '--canary-image' will install
 the latest pre-release version of Tiller (e.g. the HEAD commit in the GitHub
 repository on the master branch).
 
-To dump a manifest containing the Tiller deployment YAML, combine the
-'--dry-run' and '--debug' flags.
+To print the manifests of the Tiller resources instead of installing them, for
+example to manage them in version control, use '--output yaml' or
+'--output json'. Combining the '--dry-run' and '--debug' flags dumps the same
+YAML manifests.
+
+Every upgrade of a release stores a new revision. To keep the number of stored
+revisions in check, use '--history-max': Tiller then removes the oldest
+revisions of a release once it has more than the given number. The current
+revision, the one it replaced and the latest deployed revision are always kept.
+
+To secure the gRPC endpoint of Tiller with TLS, use '--tiller-tls' with
+'--tiller-tls-cert' and '--tiller-tls-key'. With '--tiller-tls-verify', Tiller
+also requires clients to present a certificate signed by '--tls-ca-cert'. The
+CA certificate and the client certificate and key given by '--client-tls-cert'
+and '--client-tls-key' are copied into $HELM_HOME, where the '--tls' flags of
+the other commands look for them.
+
+On clusters that authorize with RBAC, Tiller needs a service account that may
+manage the resources of releases. Use '--service-account' to run Tiller as the
+given service account, and add '--create-rbac' to create that service account
+and bind it to the cluster-admin role.
+
+For high availability, use '--replicas' to run several replicas of Tiller. The
+replicas elect a leader, which alone serves the requests that change releases,
+and helm connects to the leader. Connecting with '--host', for example through
+the tiller-deploy Service, is not supported with several replicas, as the
+other replicas refuse requests that change releases.
+
+To choose the nodes Tiller runs on, use '--node-selectors' and '--tolerations'.
+Any other field of the Tiller Deployment can be set with '--override', which
+takes paths in the syntax of 'helm install --set', for example
+'--override spec.template.spec.dnsPolicy=Default'.
 `
 
 const (
@@ -69,6 +102,9 @@ type initCmd struct {
 	namespace   string
 	dryRun      bool
 	skipRefresh bool
+	clientCert  string
+	clientKey   string
+	output      string
 	out         io.Writer
 	home        helmpath.Home
 	opts        installer.Options
@@ -98,15 +134,26 @@ func newInitCmd(out io.Writer) *cobra.Command {
 	f.StringVarP(&i.image, "tiller-image", "i", "", "override tiller image")
 	f.BoolVar(&i.canary, "canary-image", false, "use the canary tiller image")
 	f.BoolVar(&i.upgrade, "upgrade", false, "upgrade if tiller is already installed")
+	f.BoolVar(&i.opts.ForceUpgrade, "force-upgrade", false, "force upgrade of tiller to the current helm version, even if that downgrades it")
 	f.BoolVarP(&i.clientOnly, "client-only", "c", false, "if set does not install tiller")
 	f.BoolVar(&i.dryRun, "dry-run", false, "do not install local or remote")
+	f.StringVarP(&i.output, "output", "o", "", "skip installation and output the manifests of tiller in the specified format (json or yaml)")
 	f.BoolVar(&i.skipRefresh, "skip-refresh", false, "do not refresh (download) the local repository cache")
+	f.StringVar(&i.opts.ServiceAccount, "service-account", "", "name of service account to run tiller as")
+	f.BoolVar(&i.opts.CreateRBAC, "create-rbac", false, "create the service account of tiller and bind it to the cluster-admin role")
+	f.IntVar(&i.opts.Replicas, "replicas", 0, "number of tiller replicas (1 if not set on install). More than one replica elect a leader that serves the requests that change releases")
+	f.StringVar(&i.opts.NodeSelectors, "node-selectors", "", "labels to specify the node on which tiller is installed (app=tiller,helm=rocks)")
+	f.StringVar(&i.opts.Tolerations, "tolerations", "", "taints that tiller tolerates, as key[=value]:effect (dedicated=tiller:NoSchedule)")
+	f.StringArrayVar(&i.opts.Values, "override", []string{}, "override values for the tiller deployment manifest (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.IntVar(&i.opts.MaxHistory, "history-max", 0, "limit the maximum number of revisions saved per release. Use 0 for no limit")
 
 	f.BoolVar(&tlsEnable, "tiller-tls", false, "install tiller with TLS enabled")
 	f.BoolVar(&tlsVerify, "tiller-tls-verify", false, "install tiller with TLS enabled and to verify remote certificates")
 	f.StringVar(&tlsKeyFile, "tiller-tls-key", "", "path to TLS key file to install with tiller")
 	f.StringVar(&tlsCertFile, "tiller-tls-cert", "", "path to TLS certificate file to install with tiller")
 	f.StringVar(&tlsCaCertFile, "tls-ca-cert", "", "path to CA root certificate")
+	f.StringVar(&i.clientCert, "client-tls-cert", "", "path to TLS certificate file of the helm client to copy into $HELM_HOME")
+	f.StringVar(&i.clientKey, "client-tls-key", "", "path to TLS key file of the helm client to copy into $HELM_HOME")
 
 	return cmd
 }
@@ -146,60 +193,22 @@ func (i *initCmd) run() error {
 	i.opts.UseCanary = i.canary
 	i.opts.ImageSpec = i.image
 
-	if flagDebug {
-		writeYAMLManifest := func(apiVersion, kind, body string, first, last bool) error {
-			w := i.out
-			if !first {
-				// YAML starting document boundary marker
-				if _, err := fmt.Fprintln(w, "---"); err != nil {
-					return err
-				}
-			}
-			if _, err := fmt.Fprintln(w, "apiVersion:", apiVersion); err != nil {
-				return err
-			}
-			if _, err := fmt.Fprintln(w, "kind:", kind); err != nil {
-				return err
-			}
-			if _, err := fmt.Fprint(w, body); err != nil {
-				return err
-			}
-			if !last {
-				return nil
-			}
-			// YAML ending document boundary marker
-			_, err := fmt.Fprintln(w, "...")
-			return err
-		}
-
-		var body string
-		var err error
+	if i.opts.CreateRBAC && i.opts.ServiceAccount == "" {
+		return errors.New("--create-rbac requires --service-account")
+	}
 
-		// write Deployment manifest
-		if body, err = installer.DeploymentManifest(&i.opts); err != nil {
-			return err
-		}
-		if err := writeYAMLManifest("extensions/v1beta1", "Deployment", body, true, false); err != nil {
-			return err
-		}
+	if i.output != "" {
+		return i.writeManifests()
+	}
 
-		// write Service manifest
-		if body, err = installer.ServiceManifest(i.namespace); err != nil {
+	if flagDebug {
+		ms, err := i.manifests()
+		if err != nil {
 			return err
 		}
-		if err := writeYAMLManifest("v1", "Service", body, false, !i.opts.EnableTLS); err != nil {
+		if err := writeYAMLManifests(i.out, ms); err != nil {
 			return err
 		}
-
-		// write Secret manifest
-		if i.opts.EnableTLS {
-			if body, err = installer.SecretManifest(&i.opts); err != nil {
-				return err
-			}
-			if err := writeYAMLManifest("v1", "Secret", body, false, true); err != nil {
-				return err
-			}
-		}
 	}
 
 	if i.dryRun {
@@ -215,6 +224,9 @@ func (i *initCmd) run() error {
 	if err := ensureRepoFileFormat(i.home.RepositoryFile(), i.out); err != nil {
 		return err
 	}
+	if err := i.ensureTLSFiles(); err != nil {
+		return err
+	}
 	fmt.Fprintf(i.out, "$HELM_HOME has been configured at %s.\n", helmHome)
 
 	if !i.clientOnly {
@@ -229,7 +241,7 @@ func (i *initCmd) run() error {
 			if !kerrors.IsAlreadyExists(err) {
 				return fmt.Errorf("error installing: %s", err)
 			}
-			if i.upgrade {
+			if i.upgrade || i.opts.ForceUpgrade {
 				if err := installer.Upgrade(i.kubeClient, &i.opts); err != nil {
 					return fmt.Errorf("error when upgrading: %s", err)
 				}
@@ -249,6 +261,106 @@ func (i *initCmd) run() error {
 	return nil
 }
 
+// manifest is a Kubernetes resource that helm init installs.
+type manifest struct {
+	apiVersion, kind string
+	// body is the YAML of the resource without apiVersion and kind.
+	body string
+}
+
+// manifests returns the resources that helm init installs, in the order it
+// installs them.
+func (i *initCmd) manifests() ([]manifest, error) {
+	type generator struct {
+		apiVersion, kind string
+		body             func() (string, error)
+	}
+	var gens []generator
+	if i.opts.CreateRBAC {
+		gens = append(gens,
+			generator{"v1", "ServiceAccount", func() (string, error) { return installer.ServiceAccountManifest(&i.opts) }},
+			generator{"rbac.authorization.k8s.io/v1alpha1", "ClusterRoleBinding", func() (string, error) { return installer.ClusterRoleBindingManifest(&i.opts) }},
+		)
+	}
+	gens = append(gens,
+		generator{"extensions/v1beta1", "Deployment", func() (string, error) { return installer.DeploymentManifest(&i.opts) }},
+		generator{"v1", "Service", func() (string, error) { return installer.ServiceManifest(i.namespace) }},
+	)
+	if i.opts.EnableTLS {
+		gens = append(gens, generator{"v1", "Secret", func() (string, error) { return installer.SecretManifest(&i.opts) }})
+	}
+
+	ms := make([]manifest, 0, len(gens))
+	for _, g := range gens {
+		body, err := g.body()
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, manifest{g.apiVersion, g.kind, body})
+	}
+	return ms, nil
+}
+
+// writeManifests writes the resources that helm init installs in the format
+// of --output, instead of installing them.
+func (i *initCmd) writeManifests() error {
+	ms, err := i.manifests()
+	if err != nil {
+		return err
+	}
+	switch i.output {
+	case "yaml":
+		return writeYAMLManifests(i.out, ms)
+	case "json":
+		return writeJSONManifests(i.out, ms)
+	default:
+		return fmt.Errorf("unknown output format %q, expected json or yaml", i.output)
+	}
+}
+
+// writeYAMLManifests writes ms as a stream of YAML documents.
+func writeYAMLManifests(w io.Writer, ms []manifest) error {
+	for n, m := range ms {
+		if n > 0 {
+			// YAML starting document boundary marker
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "apiVersion: %s\nkind: %s\n%s", m.apiVersion, m.kind, m.body); err != nil {
+			return err
+		}
+	}
+	// YAML ending document boundary marker
+	_, err := fmt.Fprintln(w, "...")
+	return err
+}
+
+// writeJSONManifests writes ms as a JSON List of resources.
+func writeJSONManifests(w io.Writer, ms []manifest) error {
+	items := make([]map[string]interface{}, 0, len(ms))
+	for _, m := range ms {
+		item := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(m.body), &item); err != nil {
+			return err
+		}
+		item["apiVersion"] = m.apiVersion
+		item["kind"] = m.kind
+		items = append(items, item)
+	}
+	list := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}
+	b, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
 // ensureDirectories checks to see if $HELM_HOME exists
 //
 // If $HELM_HOME does not exist, this function will create it.
@@ -275,6 +387,39 @@ func ensureDirectories(home helmpath.Home, out io.Writer) error {
 	return nil
 }
 
+// ensureTLSFiles copies the CA certificate and the client certificate and key
+// into $HELM_HOME, where the TLS flags of the other commands default to.
+func (i *initCmd) ensureTLSFiles() error {
+	if !i.opts.EnableTLS {
+		return nil
+	}
+	if (i.clientCert == "") != (i.clientKey == "") {
+		return errors.New("--client-tls-cert and --client-tls-key must be given together")
+	}
+	files := []struct {
+		src, dst string
+		mode     os.FileMode
+	}{
+		{tlsCaCertFile, i.home.TLSCaCert(), 0644},
+		{i.clientCert, i.home.TLSCert(), 0644},
+		{i.clientKey, i.home.TLSKey(), 0600},
+	}
+	for _, f := range files {
+		if f.src == "" {
+			continue
+		}
+		b, err := ioutil.ReadFile(f.src)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(i.out, "Copying %s to %s \n", f.src, f.dst)
+		if err := ioutil.WriteFile(f.dst, b, f.mode); err != nil {
+			return fmt.Errorf("Could not write %s: %s", f.dst, err)
+		}
+	}
+	return nil
+}
+
 func ensureDefaultRepos(home helmpath.Home, out io.Writer, skipRefresh bool) error {
 	repoFile := home.RepositoryFile()
 	if fi, err := os.Stat(repoFile); err != nil {
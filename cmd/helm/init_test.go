@@ -18,8 +18,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -172,6 +174,116 @@ func TestInitCmd_dryRun(t *testing.T) {
 	}
 }
 
+func TestInitCmd_output(t *testing.T) {
+	home, err := ioutil.TempDir("", "helm_home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	for _, format := range []string{"json", "yaml"} {
+		var buf bytes.Buffer
+		fc := fake.NewSimpleClientset()
+		cmd := &initCmd{
+			out:        &buf,
+			home:       helmpath.Home(home),
+			kubeClient: fc,
+			output:     format,
+			namespace:  api.NamespaceDefault,
+		}
+		cmd.opts.ServiceAccount = "tiller"
+		cmd.opts.CreateRBAC = true
+		if err := cmd.run(); err != nil {
+			t.Fatal(err)
+		}
+		if got := len(fc.Actions()); got != 0 {
+			t.Errorf("expected no server calls, got %d", got)
+		}
+		if _, err := os.Stat(helmpath.Home(home).Repository()); !os.IsNotExist(err) {
+			t.Errorf("expected $HELM_HOME to be left alone, got %v", err)
+		}
+
+		var kinds []string
+		if format == "json" {
+			var list struct {
+				Kind  string
+				Items []struct{ Kind string }
+			}
+			if err := json.Unmarshal(buf.Bytes(), &list); err != nil {
+				t.Fatalf("Expected parseable JSON, got %q\n\t%s", buf.String(), err)
+			}
+			for _, item := range list.Items {
+				kinds = append(kinds, item.Kind)
+			}
+		} else {
+			for _, doc := range bytes.Split(buf.Bytes(), []byte("\n---")) {
+				var y struct{ Kind string }
+				if err := yaml.Unmarshal(doc, &y); err != nil {
+					t.Errorf("Expected parseable YAML, got %q\n\t%s", doc, err)
+				}
+				kinds = append(kinds, y.Kind)
+			}
+		}
+		expect := []string{"ServiceAccount", "ClusterRoleBinding", "Deployment", "Service"}
+		if !reflect.DeepEqual(kinds, expect) {
+			t.Errorf("expected %s manifests of %v, got %v", format, expect, kinds)
+		}
+	}
+
+	cmd := &initCmd{out: ioutil.Discard, output: "xml"}
+	if err := cmd.run(); err == nil {
+		t.Error("expected an error for an unknown output format")
+	}
+}
+
+func TestInitCmd_tlsFiles(t *testing.T) {
+	home, err := ioutil.TempDir("", "helm_home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	src := map[string]string{"ca": "CA", "cert": "CERT", "key": "KEY"}
+	for name, content := range src {
+		if err := ioutil.WriteFile(home+"/src-"+name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	caFile := tlsCaCertFile
+	tlsCaCertFile = home + "/src-ca"
+	defer func() { tlsCaCertFile = caFile }()
+
+	hh := helmpath.Home(home)
+	cmd := &initCmd{
+		out:        ioutil.Discard,
+		home:       hh,
+		clientCert: home + "/src-cert",
+		clientKey:  home + "/src-key",
+	}
+	cmd.opts.EnableTLS = true
+	if err := cmd.ensureTLSFiles(); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]string{hh.TLSCaCert(): "CA", hh.TLSCert(): "CERT", hh.TLSKey(): "KEY"}
+	for file, content := range expect {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Error(err)
+		} else if string(b) != content {
+			t.Errorf("expected %s to contain %q, got %q", file, content, b)
+		}
+	}
+	if fi, err := os.Stat(hh.TLSKey()); err == nil && fi.Mode().Perm() != 0600 {
+		t.Errorf("expected the key to be private, got mode %s", fi.Mode())
+	}
+
+	cmd.clientKey = ""
+	if err := cmd.ensureTLSFiles(); err == nil {
+		t.Error("expected an error for a client certificate without a key")
+	}
+}
+
 func TestEnsureHome(t *testing.T) {
 	home, err := ioutil.TempDir("", "helm_home")
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -63,12 +64,50 @@ contained a key called 'Test', the value set in override.yaml would take precede
 
 	$ helm install -f myvalues.yaml -f override.yaml ./redis
 
+Values files can also be URLs. They are downloaded in the same way as charts,
+with the credentials of the repository they belong to and with downloader
+plugins for other protocols than HTTP and HTTPS:
+
+	$ helm install -f https://example.com/prod-values.yaml ./redis
+
 You can specify the '--set' flag multiple times. The priority will be given to the
 last (right-most) set specified. For example, if both 'bar' and 'newbar' values are
 set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm install --set foo=bar --set foo=newbar ./redis
 
+The '--set' flag converts values like 'true' and '0777' to booleans and
+numbers. To keep them as strings, use '--set-string', which has the same syntax:
+
+	$ helm install --set-string image.tag=0777 ./redis
+
+To set a value to the contents of a file, like a certificate or a script, use
+'--set-file' with the path of the file:
+
+	$ helm install --set-file tls.cert=./server.crt ./redis
+
+When '--wait' is set, custom resources can be waited for by naming the status
+condition that marks them as ready. The resource is considered ready once the
+condition of that type in its 'status.conditions' has the status "True". The
+'--wait-condition' flag can be specified multiple times:
+
+	$ helm install --wait --wait-condition kind=Database,type=Ready ./redis
+
+With '--atomic', the install is only successful once all resources are ready,
+as with '--wait'. If the install fails or times out, the release is deleted
+again.
+
+Without '--name', a random release name is generated. Use '--name-template' to
+generate names that follow a convention instead. The template can use the
+Sprig functions and refer to the chart's metadata as '.Chart':
+
+	$ helm install --name-template '{{ .Chart.Name }}-{{ randAlpha 5 | lower }}' ./redis
+
+When installing from a chart directory, '--dep-up' updates the charts/
+directory from requirements.yaml first, as 'helm dependency update' does.
+
+Only the NOTES.txt of the chart itself is shown after the install. With
+'--render-subchart-notes', the notes of its subcharts are rendered as well.
 
 To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined. This will still require a
@@ -111,10 +150,16 @@ type installCmd struct {
 	out          io.Writer
 	client       helm.Interface
 	values       []string
+	stringValues []string
+	fileValues   []string
 	nameTemplate string
 	version      string
 	timeout      int64
 	wait         bool
+	waitConds    []string
+	atomic       bool
+	depUp        bool
+	subNotes     bool
 }
 
 type valueFiles []string
@@ -167,12 +212,18 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&inst.disableHooks, "no-hooks", false, "prevent hooks from running during install")
 	f.BoolVar(&inst.replace, "replace", false, "re-use the given name, even if that name is already used. This is unsafe in production")
 	f.StringArrayVar(&inst.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&inst.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&inst.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.StringVar(&inst.nameTemplate, "name-template", "", "specify template used to name the release")
+	f.BoolVar(&inst.depUp, "dep-up", false, "run helm dependency update before installing the chart")
+	f.BoolVar(&inst.subNotes, "render-subchart-notes", false, "render subchart notes along with the parent")
 	f.BoolVar(&inst.verify, "verify", false, "verify the package before installing it")
 	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "location of public keys used for verification")
 	f.StringVar(&inst.version, "version", "", "specify the exact chart version to install. If this is not specified, the latest version is installed")
 	f.Int64Var(&inst.timeout, "timeout", 300, "time in seconds to wait for any individual kubernetes operation (like Jobs for hooks)")
-	f.BoolVar(&inst.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&inst.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state, Jobs have completed and CustomResourceDefinitions are established before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&inst.atomic, "atomic", false, "if set, the release is deleted again if the install fails. The --wait flag is set automatically")
+	f.StringArrayVar(&inst.waitConds, "wait-condition", []string{}, "when waiting, treat resources of a kind as ready once the given status condition is True (can specify multiple): kind=KIND,type=TYPE")
 
 	return cmd
 }
@@ -186,19 +237,22 @@ func (i *installCmd) run() error {
 		i.namespace = defaultNamespace()
 	}
 
-	rawVals, err := i.vals()
+	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues)
 	if err != nil {
 		return err
 	}
 
-	// If template is specified, try to run the template.
-	if i.nameTemplate != "" {
-		i.name, err = generateName(i.nameTemplate)
-		if err != nil {
-			return err
+	waitConds, err := parseWaitConditions(i.waitConds)
+	if err != nil {
+		return err
+	}
+
+	if i.depUp {
+		if fi, err := os.Stat(i.chartPath); err == nil && fi.IsDir() {
+			if err := updateDependencies(i.out, i.chartPath, helmpath.Home(homePath())); err != nil {
+				return err
+			}
 		}
-		// Print the final name so the user knows what the final name of the release is.
-		fmt.Printf("FINAL NAME: %s\n", i.name)
 	}
 
 	// Check chart requirements to make sure all dependencies are present in /charts
@@ -207,6 +261,16 @@ func (i *installCmd) run() error {
 		return prettyError(err)
 	}
 
+	// If template is specified, try to run the template.
+	if i.nameTemplate != "" {
+		i.name, err = generateName(i.nameTemplate, chartRequested.Metadata)
+		if err != nil {
+			return err
+		}
+		// Print the final name so the user knows what the final name of the release is.
+		fmt.Printf("FINAL NAME: %s\n", i.name)
+	}
+
 	if req, err := chartutil.LoadRequirements(chartRequested); err == nil {
 		checkDependencies(chartRequested, req, i.out)
 	}
@@ -220,7 +284,10 @@ func (i *installCmd) run() error {
 		helm.InstallReuseName(i.replace),
 		helm.InstallDisableHooks(i.disableHooks),
 		helm.InstallTimeout(i.timeout),
-		helm.InstallWait(i.wait))
+		helm.InstallWait(i.wait),
+		helm.InstallWaitConditions(waitConds),
+		helm.InstallAtomic(i.atomic),
+		helm.InstallSubNotes(i.subNotes))
 	if err != nil {
 		return prettyError(err)
 	}
@@ -277,13 +344,15 @@ func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[st
 	return dest
 }
 
-func (i *installCmd) vals() ([]byte, error) {
+// vals merges values from files specified via -f/--values and
+// directly via --set, --set-string and --set-file, marshaling them to YAML
+func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string) ([]byte, error) {
 	base := map[string]interface{}{}
 
 	// User specified a values files via -f/--values
-	for _, filePath := range i.valueFiles {
+	for _, filePath := range valueFiles {
 		currentMap := map[string]interface{}{}
-		bytes, err := ioutil.ReadFile(filePath)
+		bytes, err := readFile(filePath)
 		if err != nil {
 			return []byte{}, err
 		}
@@ -296,15 +365,59 @@ func (i *installCmd) vals() ([]byte, error) {
 	}
 
 	// User specified a value via --set
-	for _, value := range i.values {
+	for _, value := range values {
 		if err := strvals.ParseInto(value, base); err != nil {
 			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
 		}
 	}
 
+	// User specified a value via --set-string
+	for _, value := range stringValues {
+		if err := strvals.ParseIntoString(value, base); err != nil {
+			return []byte{}, fmt.Errorf("failed parsing --set-string data: %s", err)
+		}
+	}
+
+	// User specified a value via --set-file
+	for _, value := range fileValues {
+		reader := func(rs []rune) (interface{}, error) {
+			bytes, err := ioutil.ReadFile(string(rs))
+			return string(bytes), err
+		}
+		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
+			return []byte{}, fmt.Errorf("failed parsing --set-file data: %s", err)
+		}
+	}
+
 	return yaml.Marshal(base)
 }
 
+// readFile reads a values file from the local filesystem, or downloads it if
+// filePath is a URL.
+func readFile(filePath string) ([]byte, error) {
+	u, err := url.Parse(filePath)
+	// A single letter scheme is a Windows drive letter.
+	if err != nil || len(u.Scheme) < 2 {
+		return ioutil.ReadFile(filePath)
+	}
+
+	home := helmpath.Home(homePath())
+	plugins, err := downloaderPlugins(home)
+	if err != nil {
+		return nil, err
+	}
+	dl := downloader.ChartDownloader{
+		HelmHome: home,
+		Out:      ioutil.Discard,
+		Plugins:  plugins,
+	}
+	data, err := dl.DownloadURL(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
 // printRelease prints info about a release if the flagDebug is true.
 func (i *installCmd) printRelease(rel *release.Release) {
 	if rel == nil {
@@ -352,13 +465,20 @@ func locateChartPath(name, version string, verify bool, keyring string) (string,
 
 	crepo := filepath.Join(helmpath.Home(homePath()).Repository(), name)
 	if _, err := os.Stat(crepo); err == nil {
-		return filepath.Abs(crepo)
+		// Resolve the chart in the repository directory like any other local
+		// chart, so that it is verified as well.
+		return locateChartPath(crepo, version, verify, keyring)
 	}
 
+	plugins, err := downloaderPlugins(helmpath.Home(homePath()))
+	if err != nil {
+		return name, err
+	}
 	dl := downloader.ChartDownloader{
 		HelmHome: helmpath.Home(homePath()),
 		Out:      os.Stdout,
 		Keyring:  keyring,
+		Plugins:  plugins,
 	}
 	if verify {
 		dl.Verify = downloader.VerifyAlways
@@ -374,6 +494,13 @@ func locateChartPath(name, version string, verify bool, keyring string) (string,
 			fmt.Printf("Fetched %s to %s\n", name, filename)
 		}
 		return lname, nil
+	} else if verify {
+		// Do not leave a chart that failed verification behind.
+		if filename != "" {
+			os.Remove(filename)
+			os.Remove(filename + ".prov")
+		}
+		return filename, err
 	} else if flagDebug {
 		return filename, err
 	}
@@ -381,19 +508,49 @@ func locateChartPath(name, version string, verify bool, keyring string) (string,
 	return filename, fmt.Errorf("file %q not found", name)
 }
 
-func generateName(nameTemplate string) (string, error) {
+// generateName renders the release name template, which can refer to the
+// chart metadata as .Chart.
+func generateName(nameTemplate string, metadata *chart.Metadata) (string, error) {
 	t, err := template.New("name-template").Funcs(sprig.TxtFuncMap()).Parse(nameTemplate)
 	if err != nil {
 		return "", err
 	}
 	var b bytes.Buffer
-	err = t.Execute(&b, nil)
+	err = t.Execute(&b, map[string]interface{}{"Chart": metadata})
 	if err != nil {
 		return "", err
 	}
 	return b.String(), nil
 }
 
+// parseWaitConditions parses '--wait-condition' values of the form
+// "kind=KIND,type=TYPE" into a map of resource kinds to condition types.
+func parseWaitConditions(conds []string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for _, c := range conds {
+		var kind, condType string
+		for _, kv := range strings.Split(c, ",") {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid wait condition %q: expected kind=KIND,type=TYPE", c)
+			}
+			switch strings.TrimSpace(parts[0]) {
+			case "kind":
+				kind = strings.TrimSpace(parts[1])
+			case "type":
+				condType = strings.TrimSpace(parts[1])
+			default:
+				return nil, fmt.Errorf("invalid wait condition %q: unknown key %q", c, parts[0])
+			}
+		}
+		if kind == "" || condType == "" {
+			return nil, fmt.Errorf("invalid wait condition %q: expected kind=KIND,type=TYPE", c)
+		}
+		parsed[kind] = condType
+	}
+	return parsed, nil
+}
+
 func defaultNamespace() string {
 	if ns, _, err := kube.GetConfig(kubeContext).Namespace(); err == nil {
 		return ns
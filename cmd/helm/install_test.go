@@ -17,13 +17,25 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/repo"
+	"k8s.io/helm/pkg/repo/repotest"
 )
 
 func TestInstall(t *testing.T) {
@@ -60,6 +72,14 @@ func TestInstall(t *testing.T) {
 			resp:     releaseMock(&releaseOptions{name: "virgil"}),
 			expected: "virgil",
 		},
+		// Install, string values from cli
+		{
+			name:     "install with string values",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--set-string foo=0777", " "),
+			resp:     releaseMock(&releaseOptions{name: "virgil"}),
+			expected: "virgil",
+		},
 		// Install, values from yaml
 		{
 			name:     "install with values",
@@ -151,6 +171,37 @@ type nameTemplateTestCase struct {
 	expectedErrorStr string
 }
 
+func TestInstallDepUp(t *testing.T) {
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldhome := helmHome
+	helmHome = thome
+	defer func() {
+		helmHome = oldhome
+		os.RemoveAll(thome)
+	}()
+	if err := repo.NewRepoFile().WriteFile(helmpath.Home(thome).RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	chartpath := createChartWithLocalDependency(t, thome)
+
+	var buf bytes.Buffer
+	c := &fakeReleaseClient{rels: []*release.Release{releaseMock(&releaseOptions{name: "withdeps"})}}
+	cmd := newInstallCmd(c, &buf)
+	cmd.ParseFlags([]string{"--name", "withdeps", "--dep-up"})
+	if err := cmd.RunE(cmd, []string{chartpath}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(chartpath, "charts", "alpine-0.1.0.tgz")); err != nil {
+		t.Errorf("Expected the dependencies to be updated: %s", err)
+	}
+	if strings.Contains(buf.String(), "Warning") {
+		t.Errorf("Expected no missing dependencies, got %q", buf.String())
+	}
+}
+
 func TestNameTemplate(t *testing.T) {
 	testCases := []nameTemplateTestCase{
 		// Just a straight up nop please
@@ -171,6 +222,17 @@ func TestNameTemplate(t *testing.T) {
 			expected:         "foobar-[0-9]{4}-baz$",
 			expectedErrorStr: "",
 		},
+		// Chart metadata
+		{
+			tpl:              "{{ .Chart.Name }}-{{ randAlpha 5 | lower }}",
+			expected:         "^alpine-[a-z]{5}$",
+			expectedErrorStr: "",
+		},
+		{
+			tpl:              "{{.Chart.Name}}-{{.Chart.Version}}",
+			expected:         "^alpine-0.1.0$",
+			expectedErrorStr: "",
+		},
 		// No such function
 		{
 			tpl:              "foobar-{{randInt}}",
@@ -187,7 +249,7 @@ func TestNameTemplate(t *testing.T) {
 
 	for _, tc := range testCases {
 
-		n, err := generateName(tc.tpl)
+		n, err := generateName(tc.tpl, &chart.Metadata{Name: "alpine", Version: "0.1.0"})
 		if err != nil {
 			if tc.expectedErrorStr == "" {
 				t.Errorf("Was not expecting error, but got: %v", err)
@@ -274,3 +336,137 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+func TestVals(t *testing.T) {
+	got, err := vals(valueFiles{"testdata/testcharts/alpine/extra_values.yaml"}, []string{"mode=0777,enabled=true"}, []string{"tag=0777", "enabled=true"}, []string{"script=testdata/testcharts/alpine/extra_values.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "enabled: \"true\"\nmode: 777\nscript: |\n  test:\n    Name: extra-values\ntag: \"0777\"\ntest:\n  Name: extra-values\n"
+	if string(got) != expect {
+		t.Errorf("Expected %q, got %q", expect, string(got))
+	}
+
+	if _, err := vals(nil, nil, []string{"name1,name2"}, nil); err == nil {
+		t.Error("Expected an error for --set-string without values")
+	}
+	if _, err := vals(nil, nil, nil, []string{"script=testdata/nope"}); err == nil {
+		t.Error("Expected an error for --set-file with a missing file")
+	}
+}
+
+func TestValsURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prod-values.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "replicaCount: 3\n")
+	}))
+	defer srv.Close()
+
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldhome := helmHome
+	helmHome = thome
+	defer func() {
+		helmHome = oldhome
+		os.RemoveAll(thome)
+	}()
+
+	got, err := vals(valueFiles{"testdata/testcharts/alpine/extra_values.yaml", srv.URL + "/prod-values.yaml"}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "replicaCount: 3\ntest:\n  Name: extra-values\n"; string(got) != expect {
+		t.Errorf("Expected %q, got %q", expect, string(got))
+	}
+
+	if _, err := vals(valueFiles{srv.URL + "/missing.yaml"}, nil, nil, nil); err == nil {
+		t.Error("Expected an error for a missing values URL")
+	}
+}
+
+func TestParseWaitConditions(t *testing.T) {
+	tests := []struct {
+		name   string
+		conds  []string
+		expect map[string]string
+		err    bool
+	}{
+		{
+			name:   "no conditions",
+			expect: map[string]string{},
+		},
+		{
+			name:   "multiple conditions",
+			conds:  []string{"kind=Database,type=Ready", "type=Available, kind=Cache"},
+			expect: map[string]string{"Database": "Ready", "Cache": "Available"},
+		},
+		{
+			name:  "missing type",
+			conds: []string{"kind=Database"},
+			err:   true,
+		},
+		{
+			name:  "unknown key",
+			conds: []string{"kind=Database,type=Ready,status=True"},
+			err:   true,
+		},
+		{
+			name:  "malformed",
+			conds: []string{"Database"},
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWaitConditions(tt.conds)
+		if (err != nil) != tt.err {
+			t.Errorf("%q. expected error: %v, got %v", tt.name, tt.err, err)
+			continue
+		}
+		if !tt.err && !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%q. expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestLocateChartPathVerify(t *testing.T) {
+	hh, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldhome := helmHome
+	helmHome = hh
+	defer func() {
+		helmHome = oldhome
+		os.RemoveAll(hh)
+	}()
+
+	srv := repotest.NewServer(hh)
+	defer srv.Stop()
+	if _, err := srv.CopyCharts("testdata/testcharts/*.tgz*"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.LinkIndices(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := locateChartPath("test/reqtest", "", true, "testdata/helm-test-key.pub"); err == nil || !strings.Contains(err.Error(), "provenance") {
+		t.Errorf("Expected a provenance error for a chart without one, got %v", err)
+	}
+	if _, err := os.Stat("reqtest-0.1.0.tgz"); err == nil {
+		os.Remove("reqtest-0.1.0.tgz")
+		t.Error("Expected the unverified chart to be removed")
+	}
+
+	p, err := locateChartPath("test/signtest", "", true, "testdata/helm-test-key.pub")
+	if err != nil {
+		t.Fatalf("Expected the signed chart to verify, got %s", err)
+	}
+	os.Remove(p)
+	os.Remove(p + ".prov")
+}
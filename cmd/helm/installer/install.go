@@ -17,23 +17,37 @@ limitations under the License.
 package installer // import "k8s.io/helm/cmd/helm/installer"
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/ghodss/yaml"
 
 	"k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/apis/rbac"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/typed/core/internalversion"
 	extensionsclient "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/typed/extensions/internalversion"
 	"k8s.io/kubernetes/pkg/util/intstr"
+
+	"k8s.io/helm/pkg/strvals"
 )
 
 // Install uses kubernetes client to install tiller.
 //
 // Returns an error if the command failed.
 func Install(client internalclientset.Interface, opts *Options) error {
+	if opts.CreateRBAC {
+		if err := createRBAC(client, opts); err != nil {
+			return err
+		}
+	}
 	if err := createDeployment(client.Extensions(), opts); err != nil {
 		return err
 	}
@@ -50,13 +64,30 @@ func Install(client internalclientset.Interface, opts *Options) error {
 
 // Upgrade uses kubernetes client to upgrade tiller to current version.
 //
-// Returns an error if the command failed.
+// Returns an error if the command failed, or if the installed tiller is newer
+// than the one to upgrade to, unless opts.ForceUpgrade is set.
 func Upgrade(client internalclientset.Interface, opts *Options) error {
 	obj, err := client.Extensions().Deployments(opts.Namespace).Get("tiller-deploy")
 	if err != nil {
 		return err
 	}
-	obj.Spec.Template.Spec.Containers[0].Image = opts.selectImage()
+	image := opts.selectImage()
+	if !opts.ForceUpgrade && isDowngrade(obj.Spec.Template.Spec.Containers[0].Image, image) {
+		return fmt.Errorf("current Tiller image %s is newer than %s, use --force-upgrade to downgrade", obj.Spec.Template.Spec.Containers[0].Image, image)
+	}
+	obj.Spec.Template.Spec.Containers[0].Image = image
+	if opts.ServiceAccount != "" {
+		obj.Spec.Template.Spec.ServiceAccountName = opts.ServiceAccount
+	}
+	if opts.MaxHistory > 0 {
+		setEnvVar(&obj.Spec.Template.Spec.Containers[0], "TILLER_HISTORY_MAX", strconv.Itoa(opts.MaxHistory))
+	}
+	if opts.Replicas > 0 {
+		setReplicas(obj, opts.Replicas)
+	}
+	if obj, err = customizeDeployment(obj, opts); err != nil {
+		return err
+	}
 	if _, err := client.Extensions().Deployments(opts.Namespace).Update(obj); err != nil {
 		return err
 	}
@@ -73,16 +104,270 @@ func Upgrade(client internalclientset.Interface, opts *Options) error {
 	return nil
 }
 
+// isDowngrade reports whether the version tag of image to is older than that
+// of image from. Images without a semantic version tag, like canary images,
+// are never downgrades.
+func isDowngrade(from, to string) bool {
+	fromVersion, err := semver.NewVersion(imageTag(from))
+	if err != nil {
+		return false
+	}
+	toVersion, err := semver.NewVersion(imageTag(to))
+	if err != nil {
+		return false
+	}
+	return toVersion.LessThan(fromVersion)
+}
+
+// imageTag returns the tag of image, or "" if it has none.
+func imageTag(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
 // createDeployment creates the Tiller deployment reource
 func createDeployment(client extensionsclient.DeploymentsGetter, opts *Options) error {
-	obj := deployment(opts)
-	_, err := client.Deployments(obj.Namespace).Create(obj)
+	obj, err := deployment(opts)
+	if err != nil {
+		return err
+	}
+	_, err = client.Deployments(obj.Namespace).Create(obj)
 	return err
 }
 
 // deployment gets the deployment object that installs Tiller.
-func deployment(opts *Options) *extensions.Deployment {
-	return generateDeployment(opts)
+func deployment(opts *Options) (*extensions.Deployment, error) {
+	return customizeDeployment(generateDeployment(opts), opts)
+}
+
+// customizeDeployment applies the node selectors, tolerations and overrides
+// of opts to the Tiller deployment d.
+func customizeDeployment(d *extensions.Deployment, opts *Options) (*extensions.Deployment, error) {
+	if opts.NodeSelectors != "" {
+		sel, err := parseNodeSelectors(opts.NodeSelectors)
+		if err != nil {
+			return nil, err
+		}
+		d.Spec.Template.Spec.NodeSelector = sel
+	}
+	if opts.Tolerations != "" {
+		t, err := parseTolerations(opts.Tolerations)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		if d.Spec.Template.Annotations == nil {
+			d.Spec.Template.Annotations = map[string]string{}
+		}
+		d.Spec.Template.Annotations[api.TolerationsAnnotationKey] = string(b)
+	}
+	if len(opts.Values) > 0 {
+		return overrideDeployment(d, opts.Values)
+	}
+	return d, nil
+}
+
+// parseNodeSelectors parses a comma separated list of label=value pairs.
+func parseNodeSelectors(s string) (map[string]string, error) {
+	sel := map[string]string{}
+	for _, kv := range strings.Split(s, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid node selector %q, expected label=value", kv)
+		}
+		sel[parts[0]] = parts[1]
+	}
+	return sel, nil
+}
+
+// parseTolerations parses a comma separated list of key[=value]:effect
+// tolerations.
+func parseTolerations(s string) ([]api.Toleration, error) {
+	var tolerations []api.Toleration
+	for _, item := range strings.Split(s, ",") {
+		i := strings.LastIndex(item, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid toleration %q, expected key[=value]:effect", item)
+		}
+		t := api.Toleration{Effect: api.TaintEffect(item[i+1:])}
+		if parts := strings.SplitN(item[:i], "=", 2); len(parts) == 2 {
+			t.Key, t.Value, t.Operator = parts[0], parts[1], api.TolerationOpEqual
+		} else {
+			t.Key, t.Operator = parts[0], api.TolerationOpExists
+		}
+		if t.Key == "" {
+			return nil, fmt.Errorf("invalid toleration %q, expected key[=value]:effect", item)
+		}
+		tolerations = append(tolerations, t)
+	}
+	return tolerations, nil
+}
+
+// overrideDeployment applies the overrides in the syntax of 'helm install
+// --set' to d. A value is taken as a string if the field it overrides does not
+// take the type that --set infers for it, like "true" for an annotation. An
+// override of a field that the Deployment of this version of Kubernetes does
+// not have is an error, rather than dropped silently.
+func overrideDeployment(d *extensions.Deployment, values []string) (*extensions.Deployment, error) {
+	obj, err := toUnstructured(d)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		o, od, err := override(obj, v, strvals.ParseInto)
+		if err != nil {
+			var serr error
+			if o, od, serr = override(obj, v, strvals.ParseIntoString); serr != nil {
+				return nil, err
+			}
+		}
+		obj, d = o, od
+	}
+	return d, nil
+}
+
+// override applies the override v to a copy of the unstructured deployment
+// obj with parse and returns the copy and its deployment.
+func override(obj map[string]interface{}, v string, parse func(string, map[string]interface{}) error) (map[string]interface{}, *extensions.Deployment, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	dst := map[string]interface{}{}
+	if err := json.Unmarshal(b, &dst); err != nil {
+		return nil, nil, err
+	}
+	if err := parse(v, dst); err != nil {
+		return nil, nil, fmt.Errorf("failed parsing --override data: %s", err)
+	}
+	if b, err = json.Marshal(dst); err != nil {
+		return nil, nil, err
+	}
+	// Use the JSON types of the parsed values for the comparison below.
+	dst = map[string]interface{}{}
+	if err := json.Unmarshal(b, &dst); err != nil {
+		return nil, nil, err
+	}
+	d := &extensions.Deployment{}
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, nil, fmt.Errorf("invalid --override data %q: %s", v, err)
+	}
+	back, err := toUnstructured(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path, ok := contains(back, dst, ""); !ok {
+		return nil, nil, fmt.Errorf("invalid --override data %q: the Tiller deployment has no field %s", v, path)
+	}
+	return back, d, nil
+}
+
+// toUnstructured converts the deployment d to its JSON object.
+func toUnstructured(d *extensions.Deployment) (map[string]interface{}, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	return obj, json.Unmarshal(b, &obj)
+}
+
+// contains reports whether every field of the JSON value sub is in the JSON
+// value v with the same value, or else the path of the first field that is
+// not. Fields with zero values may be missing, as they are omitted.
+func contains(v, sub interface{}, path string) (string, bool) {
+	switch sub := sub.(type) {
+	case map[string]interface{}:
+		m, _ := v.(map[string]interface{})
+		for k, sv := range sub {
+			if p, ok := contains(m[k], sv, path+"."+k); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case []interface{}:
+		l, _ := v.([]interface{})
+		for i, sv := range sub {
+			var lv interface{}
+			if i < len(l) {
+				lv = l[i]
+			}
+			if p, ok := contains(lv, sv, fmt.Sprintf("%s[%d]", path, i)); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case nil, string, bool, float64:
+		if v == nil {
+			return path, sub == nil || sub == "" || sub == false || sub == float64(0)
+		}
+		return path, reflect.DeepEqual(v, sub)
+	}
+	return path, false
+}
+
+// createRBAC creates the service account of Tiller and binds it to the
+// cluster-admin ClusterRole. Existing objects are kept.
+func createRBAC(client internalclientset.Interface, opts *Options) error {
+	if _, err := client.Core().ServiceAccounts(opts.Namespace).Create(serviceAccount(opts)); err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	if _, err := client.Rbac().ClusterRoleBindings().Create(clusterRoleBinding(opts)); err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// serviceAccount gets the service account object that Tiller runs as.
+func serviceAccount(opts *Options) *api.ServiceAccount {
+	return &api.ServiceAccount{
+		ObjectMeta: api.ObjectMeta{
+			Namespace: opts.Namespace,
+			Name:      opts.ServiceAccount,
+			Labels:    generateLabels(map[string]string{"name": "tiller"}),
+		},
+	}
+}
+
+// clusterRoleBinding gets the ClusterRoleBinding object that binds the service
+// account of Tiller to the cluster-admin ClusterRole.
+func clusterRoleBinding(opts *Options) *rbac.ClusterRoleBinding {
+	return &rbac.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{
+			Name:   opts.Namespace + ":" + opts.ServiceAccount,
+			Labels: generateLabels(map[string]string{"name": "tiller"}),
+		},
+		Subjects: []rbac.Subject{{
+			Kind:      rbac.ServiceAccountKind,
+			Name:      opts.ServiceAccount,
+			Namespace: opts.Namespace,
+		}},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		},
+	}
+}
+
+// ServiceAccountManifest gets the manifest (as a string) that describes the
+// ServiceAccount of Tiller.
+func ServiceAccountManifest(opts *Options) (string, error) {
+	buf, err := yaml.Marshal(serviceAccount(opts))
+	return string(buf), err
+}
+
+// ClusterRoleBindingManifest gets the manifest (as a string) that describes
+// the ClusterRoleBinding of the ServiceAccount of Tiller.
+func ClusterRoleBindingManifest(opts *Options) (string, error) {
+	buf, err := yaml.Marshal(clusterRoleBinding(opts))
+	return string(buf), err
 }
 
 // createService creates the Tiller service resource
@@ -100,7 +385,10 @@ func service(namespace string) *api.Service {
 // DeploymentManifest gets the manifest (as a string) that describes the Tiller Deployment
 // resource.
 func DeploymentManifest(opts *Options) (string, error) {
-	obj := deployment(opts)
+	obj, err := deployment(opts)
+	if err != nil {
+		return "", err
+	}
 	buf, err := yaml.Marshal(obj)
 	return string(buf), err
 }
@@ -119,6 +407,38 @@ func generateLabels(labels map[string]string) map[string]string {
 	return labels
 }
 
+// setEnvVar sets an environment variable of a container, replacing any
+// existing value.
+func setEnvVar(c *api.Container, name, value string) {
+	for i := range c.Env {
+		if c.Env[i].Name == name {
+			c.Env[i].Value = value
+			return
+		}
+	}
+	c.Env = append(c.Env, api.EnvVar{Name: name, Value: value})
+}
+
+func unsetEnvVar(c *api.Container, name string) {
+	for i := range c.Env {
+		if c.Env[i].Name == name {
+			c.Env = append(c.Env[:i], c.Env[i+1:]...)
+			return
+		}
+	}
+}
+
+// setReplicas sets the replicas of the Tiller deployment d. Replicas elect a
+// leader if there are more than one.
+func setReplicas(d *extensions.Deployment, replicas int) {
+	d.Spec.Replicas = int32(replicas)
+	if replicas > 1 {
+		setEnvVar(&d.Spec.Template.Spec.Containers[0], "TILLER_LEADER_ELECTION", "1")
+	} else {
+		unsetEnvVar(&d.Spec.Template.Spec.Containers[0], "TILLER_LEADER_ELECTION")
+	}
+}
+
 func generateDeployment(opts *Options) *extensions.Deployment {
 	labels := generateLabels(map[string]string{"name": "tiller"})
 	d := &extensions.Deployment{
@@ -134,6 +454,7 @@ func generateDeployment(opts *Options) *extensions.Deployment {
 					Labels: labels,
 				},
 				Spec: api.PodSpec{
+					ServiceAccountName: opts.ServiceAccount,
 					Containers: []api.Container{
 						{
 							Name:            "tiller",
@@ -172,6 +493,13 @@ func generateDeployment(opts *Options) *extensions.Deployment {
 		},
 	}
 
+	if opts.MaxHistory > 0 {
+		setEnvVar(&d.Spec.Template.Spec.Containers[0], "TILLER_HISTORY_MAX", strconv.Itoa(opts.MaxHistory))
+	}
+	if opts.Replicas > 0 {
+		setReplicas(d, opts.Replicas)
+	}
+
 	if opts.tls() {
 		const certsDir = "/etc/certs"
 
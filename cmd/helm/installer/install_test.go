@@ -18,12 +18,14 @@ package installer // import "k8s.io/helm/cmd/helm/installer"
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/apis/rbac"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
 	testcore "k8s.io/kubernetes/pkg/client/testing/core"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -64,6 +66,122 @@ func TestDeploymentManifest(t *testing.T) {
 	}
 }
 
+func TestDeploymentManifestMaxHistory(t *testing.T) {
+	o, err := DeploymentManifest(&Options{Namespace: api.NamespaceDefault, MaxHistory: 10})
+	if err != nil {
+		t.Fatalf("error %q", err)
+	}
+	var dep extensions.Deployment
+	if err := yaml.Unmarshal([]byte(o), &dep); err != nil {
+		t.Fatalf("error %q", err)
+	}
+
+	found := false
+	for _, env := range dep.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "TILLER_HISTORY_MAX" {
+			found = true
+			if env.Value != "10" {
+				t.Errorf("expected TILLER_HISTORY_MAX to be %q, got %q", "10", env.Value)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected TILLER_HISTORY_MAX to be set")
+	}
+}
+
+func TestDeploymentManifestReplicas(t *testing.T) {
+	for _, replicas := range []int{1, 3} {
+		o, err := DeploymentManifest(&Options{Namespace: api.NamespaceDefault, Replicas: replicas})
+		if err != nil {
+			t.Fatalf("error %q", err)
+		}
+		var dep extensions.Deployment
+		if err := yaml.Unmarshal([]byte(o), &dep); err != nil {
+			t.Fatalf("error %q", err)
+		}
+		if dep.Spec.Replicas != int32(replicas) {
+			t.Errorf("expected %d replicas, got %d", replicas, dep.Spec.Replicas)
+		}
+		leaderElection := false
+		for _, env := range dep.Spec.Template.Spec.Containers[0].Env {
+			leaderElection = leaderElection || env.Name == "TILLER_LEADER_ELECTION" && env.Value != ""
+		}
+		if leaderElection != (replicas > 1) {
+			t.Errorf("expected leader election %t for %d replicas", replicas > 1, replicas)
+		}
+	}
+}
+
+func TestDeploymentManifestCustomized(t *testing.T) {
+	o, err := DeploymentManifest(&Options{
+		Namespace:     api.NamespaceDefault,
+		NodeSelectors: "app=tiller,helm=rocks",
+		Tolerations:   "dedicated=tiller:NoSchedule,master:PreferNoSchedule",
+		Values:        []string{"spec.replicas=2,spec.template.spec.dnsPolicy=Default", "spec.template.spec.containers[0].name=rudder"},
+	})
+	if err != nil {
+		t.Fatalf("error %q", err)
+	}
+	var dep extensions.Deployment
+	if err := yaml.Unmarshal([]byte(o), &dep); err != nil {
+		t.Fatalf("error %q", err)
+	}
+
+	if sel := map[string]string{"app": "tiller", "helm": "rocks"}; !reflect.DeepEqual(dep.Spec.Template.Spec.NodeSelector, sel) {
+		t.Errorf("expected node selector %v, got %v", sel, dep.Spec.Template.Spec.NodeSelector)
+	}
+	tolerations := `[{"key":"dedicated","operator":"Equal","value":"tiller","effect":"NoSchedule"},{"key":"master","operator":"Exists","effect":"PreferNoSchedule"}]`
+	if got := dep.Spec.Template.Annotations[api.TolerationsAnnotationKey]; got != tolerations {
+		t.Errorf("expected tolerations %s, got %s", tolerations, got)
+	}
+	if dep.Spec.Replicas != 2 {
+		t.Errorf("expected 2 replicas, got %d", dep.Spec.Replicas)
+	}
+	if dep.Spec.Template.Spec.DNSPolicy != api.DNSDefault {
+		t.Errorf("expected DNS policy Default, got %q", dep.Spec.Template.Spec.DNSPolicy)
+	}
+	c := dep.Spec.Template.Spec.Containers[0]
+	if c.Name != "rudder" || c.Image == "" {
+		t.Errorf("expected the container to be renamed and keep its image, got %q with image %q", c.Name, c.Image)
+	}
+}
+
+func TestDeploymentManifestOverrideStrings(t *testing.T) {
+	o, err := DeploymentManifest(&Options{
+		Namespace: api.NamespaceDefault,
+		Values:    []string{"spec.template.metadata.annotations.sidecar=true,metadata.labels.tier=1"},
+	})
+	if err != nil {
+		t.Fatalf("error %q", err)
+	}
+	var dep extensions.Deployment
+	if err := yaml.Unmarshal([]byte(o), &dep); err != nil {
+		t.Fatalf("error %q", err)
+	}
+	if got := dep.Spec.Template.Annotations["sidecar"]; got != "true" {
+		t.Errorf("expected annotation 'true', got %q", got)
+	}
+	if got := dep.Labels["tier"]; got != "1" {
+		t.Errorf("expected label '1', got %q", got)
+	}
+}
+
+func TestDeploymentManifestInvalid(t *testing.T) {
+	for _, opts := range []*Options{
+		{NodeSelectors: "app"},
+		{Tolerations: "dedicated"},
+		{Tolerations: "=tiller:NoSchedule"},
+		{Values: []string{"spec.replicas"}},
+		{Values: []string{"spec.replicas=many"}},
+		{Values: []string{"spec.template.spec.priorityClassName=high"}},
+	} {
+		if _, err := DeploymentManifest(opts); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+}
+
 func TestServiceManifest(t *testing.T) {
 	o, err := ServiceManifest(api.NamespaceDefault)
 	if err != nil {
@@ -143,10 +261,52 @@ func TestInstall_canary(t *testing.T) {
 	}
 }
 
+func TestInstall_serviceAccount(t *testing.T) {
+	fc := &fake.Clientset{}
+	fc.AddReactor("create", "serviceaccounts", func(action testcore.Action) (bool, runtime.Object, error) {
+		obj := action.(testcore.CreateAction).GetObject().(*api.ServiceAccount)
+		if obj.Name != "tiller" || obj.Namespace != "kube-system" {
+			t.Errorf("expected service account kube-system/tiller, got %s/%s", obj.Namespace, obj.Name)
+		}
+		return true, nil, errors.NewAlreadyExists(api.Resource("serviceaccounts"), obj.Name)
+	})
+	fc.AddReactor("create", "clusterrolebindings", func(action testcore.Action) (bool, runtime.Object, error) {
+		obj := action.(testcore.CreateAction).GetObject().(*rbac.ClusterRoleBinding)
+		s := obj.Subjects[0]
+		if s.Kind != rbac.ServiceAccountKind || s.Name != "tiller" || s.Namespace != "kube-system" {
+			t.Errorf("expected the binding of service account kube-system/tiller, got %v", s)
+		}
+		if obj.RoleRef.Name != "cluster-admin" {
+			t.Errorf("expected role cluster-admin, got '%s'", obj.RoleRef.Name)
+		}
+		return true, obj, nil
+	})
+	fc.AddReactor("create", "deployments", func(action testcore.Action) (bool, runtime.Object, error) {
+		obj := action.(testcore.CreateAction).GetObject().(*extensions.Deployment)
+		if sa := obj.Spec.Template.Spec.ServiceAccountName; sa != "tiller" {
+			t.Errorf("expected service account = 'tiller', got '%s'", sa)
+		}
+		return true, obj, nil
+	})
+	fc.AddReactor("create", "services", func(action testcore.Action) (bool, runtime.Object, error) {
+		obj := action.(testcore.CreateAction).GetObject().(*api.Service)
+		return true, obj, nil
+	})
+
+	opts := &Options{Namespace: "kube-system", ServiceAccount: "tiller", CreateRBAC: true}
+	if err := Install(fc, opts); err != nil {
+		t.Errorf("unexpected error: %#+v", err)
+	}
+
+	if actions := fc.Actions(); len(actions) != 4 {
+		t.Errorf("unexpected actions: %v, expected 4 actions got %d", actions, len(actions))
+	}
+}
+
 func TestUpgrade(t *testing.T) {
 	image := "gcr.io/kubernetes-helm/tiller:v2.0.0"
 
-	existingDeployment := deployment(&Options{
+	existingDeployment, _ := deployment(&Options{
 		Namespace: api.NamespaceDefault,
 		ImageSpec: "imageToReplace",
 		UseCanary: false,
@@ -179,10 +339,108 @@ func TestUpgrade(t *testing.T) {
 	}
 }
 
+func TestUpgrade_customized(t *testing.T) {
+	existingDeployment, _ := deployment(&Options{
+		Namespace: api.NamespaceDefault,
+		ImageSpec: "gcr.io/kubernetes-helm/tiller:v2.0.0",
+	})
+	existingService := service(api.NamespaceDefault)
+
+	var updated *extensions.Deployment
+	fc := &fake.Clientset{}
+	fc.AddReactor("get", "deployments", func(action testcore.Action) (bool, runtime.Object, error) {
+		return true, existingDeployment, nil
+	})
+	fc.AddReactor("update", "deployments", func(action testcore.Action) (bool, runtime.Object, error) {
+		updated = action.(testcore.UpdateAction).GetObject().(*extensions.Deployment)
+		return true, updated, nil
+	})
+	fc.AddReactor("get", "services", func(action testcore.Action) (bool, runtime.Object, error) {
+		return true, existingService, nil
+	})
+
+	opts := &Options{
+		Namespace:     api.NamespaceDefault,
+		ImageSpec:     "gcr.io/kubernetes-helm/tiller:v2.1.0",
+		NodeSelectors: "app=tiller",
+		Tolerations:   "dedicated=tiller:NoSchedule",
+		Values:        []string{"spec.template.spec.dnsPolicy=Default"},
+	}
+	if err := Upgrade(fc, opts); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updated == nil {
+		t.Fatal("expected the deployment to be updated")
+	}
+	if sel := map[string]string{"app": "tiller"}; !reflect.DeepEqual(updated.Spec.Template.Spec.NodeSelector, sel) {
+		t.Errorf("expected node selector %v, got %v", sel, updated.Spec.Template.Spec.NodeSelector)
+	}
+	if _, ok := updated.Spec.Template.Annotations[api.TolerationsAnnotationKey]; !ok {
+		t.Error("expected the tolerations to be set")
+	}
+	if updated.Spec.Template.Spec.DNSPolicy != api.DNSDefault {
+		t.Errorf("expected DNS policy Default, got %q", updated.Spec.Template.Spec.DNSPolicy)
+	}
+	if i := updated.Spec.Template.Spec.Containers[0].Image; i != opts.ImageSpec {
+		t.Errorf("expected image %s, got %s", opts.ImageSpec, i)
+	}
+}
+
+func TestUpgrade_downgrade(t *testing.T) {
+	existingDeployment, _ := deployment(&Options{
+		Namespace: api.NamespaceDefault,
+		ImageSpec: "gcr.io/kubernetes-helm/tiller:v2.1.0",
+	})
+	existingService := service(api.NamespaceDefault)
+
+	fc := &fake.Clientset{}
+	fc.AddReactor("get", "deployments", func(action testcore.Action) (bool, runtime.Object, error) {
+		return true, existingDeployment, nil
+	})
+	fc.AddReactor("update", "deployments", func(action testcore.Action) (bool, runtime.Object, error) {
+		return true, action.(testcore.UpdateAction).GetObject(), nil
+	})
+	fc.AddReactor("get", "services", func(action testcore.Action) (bool, runtime.Object, error) {
+		return true, existingService, nil
+	})
+
+	opts := &Options{Namespace: api.NamespaceDefault, ImageSpec: "gcr.io/kubernetes-helm/tiller:v2.0.0"}
+	if err := Upgrade(fc, opts); err == nil || !strings.Contains(err.Error(), "--force-upgrade") {
+		t.Errorf("expected downgrade to fail, got %v", err)
+	}
+	if actions := fc.Actions(); len(actions) != 1 {
+		t.Errorf("unexpected actions: %v, expected 1 action got %d", actions, len(actions))
+	}
+
+	opts.ForceUpgrade = true
+	if err := Upgrade(fc, opts); err != nil {
+		t.Errorf("unexpected error: %#+v", err)
+	}
+}
+
+func TestIsDowngrade(t *testing.T) {
+	tests := []struct {
+		from, to string
+		expect   bool
+	}{
+		{"gcr.io/kubernetes-helm/tiller:v2.1.0", "gcr.io/kubernetes-helm/tiller:v2.0.0", true},
+		{"gcr.io/kubernetes-helm/tiller:v2.0.0", "gcr.io/kubernetes-helm/tiller:v2.1.0", false},
+		{"gcr.io/kubernetes-helm/tiller:v2.1.0", "gcr.io/kubernetes-helm/tiller:v2.1.0", false},
+		{"gcr.io/kubernetes-helm/tiller:canary", "gcr.io/kubernetes-helm/tiller:v2.0.0", false},
+		{"localhost:5000/tiller:v2.1.0", "localhost:5000/tiller:v2.0.0", true},
+		{"localhost:5000/tiller", "gcr.io/kubernetes-helm/tiller:v2.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := isDowngrade(tt.from, tt.to); got != tt.expect {
+			t.Errorf("isDowngrade(%q, %q): expected %t, got %t", tt.from, tt.to, tt.expect, got)
+		}
+	}
+}
+
 func TestUpgrade_serviceNotFound(t *testing.T) {
 	image := "gcr.io/kubernetes-helm/tiller:v2.0.0"
 
-	existingDeployment := deployment(&Options{
+	existingDeployment, _ := deployment(&Options{
 		Namespace: api.NamespaceDefault,
 		ImageSpec: "imageToReplace",
 		UseCanary: false,
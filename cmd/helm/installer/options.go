@@ -63,6 +63,49 @@ type Options struct {
 	//
 	// Required and valid if and only if VerifyTLS is set.
 	TLSCaCertFile string
+
+	// MaxHistory sets the maximum number of revisions tiller keeps per release.
+	//
+	// Values of 0 or less mean that no limit is imposed.
+	MaxHistory int
+
+	// Replicas is the number of replicas of Tiller. More than one replica
+	// elect a leader, which alone serves the requests that change releases.
+	//
+	// Values of 0 or less mean one replica on install, and that the replicas
+	// are kept on upgrade.
+	Replicas int
+
+	// ServiceAccount is the name of the service account Tiller runs as. Tiller
+	// runs as the default service account of its namespace if it is empty.
+	ServiceAccount string
+
+	// CreateRBAC creates the ServiceAccount and binds it to the cluster-admin
+	// ClusterRole, for clusters that authorize with RBAC.
+	//
+	// Valid if and only if ServiceAccount is set.
+	CreateRBAC bool
+
+	// NodeSelectors restricts the nodes Tiller may run on, as a comma separated
+	// list of label=value pairs.
+	NodeSelectors string
+
+	// Tolerations lets Tiller run on tainted nodes, as a comma separated list of
+	// key[=value]:effect tolerations. A toleration without a value tolerates
+	// any value of the key.
+	Tolerations string
+
+	// Values are overrides of the Tiller Deployment, in the syntax of
+	// 'helm install --set' (e.g. spec.template.spec.dnsPolicy=Default). They
+	// are applied after all other options.
+	Values []string
+
+	// PurgeReleases deletes the release history that Tiller stored in the
+	// ConfigMaps or Secrets of its namespace on uninstall.
+	PurgeReleases bool
+
+	// ForceUpgrade allows Upgrade to replace a newer Tiller with an older one.
+	ForceUpgrade bool
 }
 
 func (opts *Options) selectImage() string {
@@ -26,11 +26,15 @@ import (
 	"k8s.io/kubernetes/pkg/apis/extensions"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/typed/core/internalversion"
+	"k8s.io/kubernetes/pkg/labels"
 
 	"k8s.io/helm/pkg/kube"
 )
 
 // Uninstall uses kubernetes client to uninstall tiller
+//
+// Only the resources of Tiller in opts.Namespace are deleted. The release
+// history is kept unless opts.PurgeReleases is set.
 func Uninstall(kubeClient internalclientset.Interface, kubeCmd *kube.Client, opts *Options) error {
 	if _, err := kubeClient.Core().Services(opts.Namespace).Get("tiller-deploy"); err != nil {
 		if !kerrors.IsNotFound(err) {
@@ -46,9 +50,53 @@ func Uninstall(kubeClient internalclientset.Interface, kubeCmd *kube.Client, opt
 	} else if err := deleteDeployment(kubeCmd, opts.Namespace, obj); err != nil {
 		return err
 	}
+	if err := ignoreNotFound(kubeClient.Core().Secrets(opts.Namespace).Delete("tiller-secret", &api.DeleteOptions{})); err != nil {
+		return err
+	}
+	if err := ignoreNotFound(kubeClient.Core().Endpoints(opts.Namespace).Delete("tiller-leader", &api.DeleteOptions{})); err != nil {
+		return err
+	}
+	if opts.PurgeReleases {
+		return deleteReleases(kubeClient.Core(), opts.Namespace)
+	}
 	return nil
 }
 
+// deleteReleases deletes the release history that the configmap and secret
+// storage drivers of Tiller keep in namespace.
+func deleteReleases(client internalversion.CoreInterface, namespace string) error {
+	options := api.ListOptions{LabelSelector: labels.Set{"OWNER": "TILLER"}.AsSelector()}
+
+	cfgmaps, err := client.ConfigMaps(namespace).List(options)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cfgmaps.Items {
+		if err := ignoreNotFound(client.ConfigMaps(namespace).Delete(cm.Name, &api.DeleteOptions{})); err != nil {
+			return err
+		}
+	}
+
+	secrets, err := client.Secrets(namespace).List(options)
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets.Items {
+		if err := ignoreNotFound(client.Secrets(namespace).Delete(s.Name, &api.DeleteOptions{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ignoreNotFound returns err unless it reports a missing resource.
+func ignoreNotFound(err error) error {
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 // deleteService deletes the Tiller Service resource
 func deleteService(client internalversion.ServicesGetter, namespace string) error {
 	return client.Services(namespace).Delete("tiller-deploy", &api.DeleteOptions{})
@@ -55,7 +55,7 @@ func (f *fakeReaperFactory) Reaper(mapping *meta.RESTMapping) (kubectl.Reaper, e
 
 func TestUninstall(t *testing.T) {
 	existingService := service(api.NamespaceDefault)
-	existingDeployment := deployment(&Options{
+	existingDeployment, _ := deployment(&Options{
 		Namespace: api.NamespaceDefault,
 		ImageSpec: "image",
 		UseCanary: false,
@@ -82,8 +82,8 @@ func TestUninstall(t *testing.T) {
 		t.Errorf("unexpected error: %#+v", err)
 	}
 
-	if actions := fc.Actions(); len(actions) != 3 {
-		t.Errorf("unexpected actions: %v, expected 3 actions got %d", actions, len(actions))
+	if actions := fc.Actions(); len(actions) != 5 {
+		t.Errorf("unexpected actions: %v, expected 5 actions got %d", actions, len(actions))
 	}
 
 	if r.namespace != api.NamespaceDefault {
@@ -96,7 +96,7 @@ func TestUninstall(t *testing.T) {
 }
 
 func TestUninstall_serviceNotFound(t *testing.T) {
-	existingDeployment := deployment(&Options{Namespace: api.NamespaceDefault, ImageSpec: "imageToReplace", UseCanary: false})
+	existingDeployment, _ := deployment(&Options{Namespace: api.NamespaceDefault, ImageSpec: "imageToReplace", UseCanary: false})
 
 	fc := &fake.Clientset{}
 	fc.AddReactor("get", "services", func(action testcore.Action) (bool, runtime.Object, error) {
@@ -116,8 +116,8 @@ func TestUninstall_serviceNotFound(t *testing.T) {
 		t.Errorf("unexpected error: %#+v", err)
 	}
 
-	if actions := fc.Actions(); len(actions) != 2 {
-		t.Errorf("unexpected actions: %v, expected 2 actions got %d", actions, len(actions))
+	if actions := fc.Actions(); len(actions) != 4 {
+		t.Errorf("unexpected actions: %v, expected 4 actions got %d", actions, len(actions))
 	}
 
 	if r.namespace != api.NamespaceDefault {
@@ -153,8 +153,8 @@ func TestUninstall_deploymentNotFound(t *testing.T) {
 		t.Errorf("unexpected error: %#+v", err)
 	}
 
-	if actions := fc.Actions(); len(actions) != 3 {
-		t.Errorf("unexpected actions: %v, expected 3 actions got %d", actions, len(actions))
+	if actions := fc.Actions(); len(actions) != 5 {
+		t.Errorf("unexpected actions: %v, expected 5 actions got %d", actions, len(actions))
 	}
 
 	if r.namespace != "" {
@@ -165,3 +165,23 @@ func TestUninstall_deploymentNotFound(t *testing.T) {
 		t.Errorf("unexpected reaper name: %s", r.name)
 	}
 }
+
+func TestUninstall_purgeReleases(t *testing.T) {
+	owned := map[string]string{"OWNER": "TILLER"}
+	fc := fake.NewSimpleClientset(
+		&api.ConfigMap{ObjectMeta: api.ObjectMeta{Name: "angry-panda.v1", Namespace: api.NamespaceDefault, Labels: owned}},
+		&api.Secret{ObjectMeta: api.ObjectMeta{Name: "angry-panda.v2", Namespace: api.NamespaceDefault, Labels: owned}},
+	)
+
+	opts := &Options{Namespace: api.NamespaceDefault, PurgeReleases: true}
+	if err := Uninstall(fc, nil, opts); err != nil {
+		t.Errorf("unexpected error: %#+v", err)
+	}
+
+	if cfgmaps, _ := fc.Core().ConfigMaps(api.NamespaceDefault).List(api.ListOptions{}); len(cfgmaps.Items) != 0 {
+		t.Errorf("expected the release configmaps to be deleted, got %v", cfgmaps.Items)
+	}
+	if secrets, _ := fc.Core().Secrets(api.NamespaceDefault).List(api.ListOptions{}); len(secrets.Items) != 0 {
+		t.Errorf("expected the release secrets to be deleted, got %v", secrets.Items)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const keysHelp = `
+This command consists of multiple subcommands to manage the keyring in
+$HELM_HOME/keyring.gpg, which holds the public keys that chart provenance is
+verified with.
+
+Once the keyring exists, it is the default keyring of 'helm verify' and of
+the '--verify' flag of the commands that download charts.
+Example usage:
+    $ helm keys add signer.asc
+    $ helm keys list
+`
+
+func newKeysCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys [FLAGS] add|list|remove [ARGS]",
+		Short: "add, list, and remove the keys used to verify charts",
+		Long:  keysHelp,
+	}
+
+	cmd.AddCommand(newKeysAddCmd(out))
+	cmd.AddCommand(newKeysListCmd(out))
+	cmd.AddCommand(newKeysRemoveCmd(out))
+
+	return cmd
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/provenance"
+)
+
+const keysAddDesc = `
+Add the public keys in the given files to the Helm keyring. The files may be
+ASCII armored or binary, as exported by 'gpg --export' or 'gpg --export --armor'.
+Only the public part of secret keys is added.
+`
+
+type keysAddCmd struct {
+	out   io.Writer
+	files []string
+	home  helmpath.Home
+}
+
+func newKeysAddCmd(out io.Writer) *cobra.Command {
+	add := &keysAddCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "add [flags] [FILE] [...]",
+		Short: "add public keys to the keyring",
+		Long:  keysAddDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "path to a key file"); err != nil {
+				return err
+			}
+			add.files = args
+			add.home = helmpath.Home(homePath())
+			return add.run()
+		},
+	}
+	return cmd
+}
+
+func (a *keysAddCmd) run() error {
+	ring := a.home.Keyring()
+	keys, err := provenance.LoadKeyring(ring)
+	if err != nil {
+		return fmt.Errorf("could not load %s: %s", ring, err)
+	}
+
+	have := map[string]bool{}
+	for _, k := range keys {
+		have[provenance.KeyID(k)] = true
+	}
+
+	for _, f := range a.files {
+		in, err := os.Open(f)
+		if err != nil {
+			return err
+		}
+		read, err := provenance.ReadKeys(in)
+		in.Close()
+		if err != nil {
+			return fmt.Errorf("could not read keys from %s: %s", f, err)
+		}
+
+		for _, k := range read {
+			id := provenance.KeyID(k)
+			if have[id] {
+				fmt.Fprintf(a.out, "Key %s is already in the keyring\n", id)
+				continue
+			}
+			have[id] = true
+			keys = append(keys, k)
+			fmt.Fprintf(a.out, "Key %s has been added to the keyring\n", id)
+		}
+	}
+
+	return provenance.WriteKeyring(ring, keys)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/provenance"
+)
+
+type keysListCmd struct {
+	out  io.Writer
+	home helmpath.Home
+}
+
+func newKeysListCmd(out io.Writer) *cobra.Command {
+	list := &keysListCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "list [flags]",
+		Short: "list the keys in the keyring",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list.home = helmpath.Home(homePath())
+			return list.run()
+		},
+	}
+	return cmd
+}
+
+func (l *keysListCmd) run() error {
+	keys, err := provenance.LoadKeyring(l.home.Keyring())
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errors.New("no keys to show")
+	}
+
+	table := uitable.New()
+	table.AddRow("KEY ID", "CREATED", "IDENTITIES")
+	for _, k := range keys {
+		var names []string
+		for name := range k.Identities {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		table.AddRow(provenance.KeyID(k), k.PrimaryKey.CreationTime.Format("2006-01-02"), strings.Join(names, ", "))
+	}
+	fmt.Fprintln(l.out, table)
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/provenance"
+)
+
+const keysRemoveDesc = `
+Remove keys from the Helm keyring. A key is identified by its key ID, its
+fingerprint, or the name or email address of one of its identities.
+`
+
+type keysRemoveCmd struct {
+	out  io.Writer
+	ids  []string
+	home helmpath.Home
+}
+
+func newKeysRemoveCmd(out io.Writer) *cobra.Command {
+	remove := &keysRemoveCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:     "remove [flags] [KEY] [...]",
+		Aliases: []string{"rm"},
+		Short:   "remove keys from the keyring",
+		Long:    keysRemoveDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "key ID"); err != nil {
+				return err
+			}
+			remove.ids = args
+			remove.home = helmpath.Home(homePath())
+			return remove.run()
+		},
+	}
+	return cmd
+}
+
+func (r *keysRemoveCmd) run() error {
+	ring := r.home.Keyring()
+	keys, err := provenance.LoadKeyring(ring)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range r.ids {
+		kept := openpgp.EntityList{}
+		for _, k := range keys {
+			if provenance.MatchKey(k, id) {
+				fmt.Fprintf(r.out, "Key %s has been removed from the keyring\n", provenance.KeyID(k))
+				continue
+			}
+			kept = append(kept, k)
+		}
+		if len(kept) == len(keys) {
+			return fmt.Errorf("no key %q found", id)
+		}
+		keys = kept
+	}
+
+	return provenance.WriteKeyring(ring, keys)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestKeysCmd(t *testing.T) {
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldhome := helmHome
+	helmHome = thome
+	defer func() {
+		helmHome = oldhome
+		os.RemoveAll(thome)
+	}()
+
+	tests := []struct {
+		name     string
+		cmd      func(io.Writer) *cobra.Command
+		args     []string
+		expected string
+		err      bool
+	}{
+		{
+			name: "list an empty keyring",
+			cmd:  newKeysListCmd,
+			err:  true,
+		},
+		{
+			name:     "add a key",
+			cmd:      newKeysAddCmd,
+			args:     []string{"testdata/helm-test-key.pub"},
+			expected: "has been added to the keyring",
+		},
+		{
+			name:     "add a key again",
+			cmd:      newKeysAddCmd,
+			args:     []string{"testdata/helm-test-key.secret"},
+			expected: "is already in the keyring",
+		},
+		{
+			name:     "list keys",
+			cmd:      newKeysListCmd,
+			expected: "Helm Testing",
+		},
+		{
+			name: "add a missing file",
+			cmd:  newKeysAddCmd,
+			args: []string{"testdata/nope.pub"},
+			err:  true,
+		},
+		{
+			name: "remove an unknown key",
+			cmd:  newKeysRemoveCmd,
+			args: []string{"nobody@example.com"},
+			err:  true,
+		},
+		{
+			name:     "remove a key",
+			cmd:      newKeysRemoveCmd,
+			args:     []string{"helm-testing@helm.sh"},
+			expected: "has been removed from the keyring",
+		},
+		{
+			name: "list the emptied keyring",
+			cmd:  newKeysListCmd,
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		buf := bytes.NewBuffer(nil)
+		c := tt.cmd(buf)
+		err := c.RunE(c, tt.args)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.name, tt.err, err)
+			continue
+		}
+		if !strings.Contains(buf.String(), tt.expected) {
+			t.Errorf("%q: expected %q, got %q", tt.name, tt.expected, buf.String())
+		}
+	}
+}
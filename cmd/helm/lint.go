@@ -39,12 +39,20 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+The templates are rendered with the chart's default values. Values can be
+overridden with '--values'/'-f', '--set', '--set-string' and '--set-file', in the
+same way as for 'helm install'.
 `
 
 type lintCmd struct {
-	strict bool
-	paths  []string
-	out    io.Writer
+	strict       bool
+	valueFiles   valueFiles
+	values       []string
+	stringValues []string
+	fileValues   []string
+	paths        []string
+	out          io.Writer
 }
 
 func newLintCmd(out io.Writer) *cobra.Command {
@@ -64,7 +72,12 @@ func newLintCmd(out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVar(&l.strict, "strict", false, "fail on lint warnings")
+	f := cmd.Flags()
+	f.BoolVar(&l.strict, "strict", false, "fail on lint warnings")
+	f.VarP(&l.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
+	f.StringArrayVar(&l.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&l.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&l.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 
 	return cmd
 }
@@ -79,10 +92,18 @@ func (l *lintCmd) run() error {
 		lowestTolerance = support.ErrorSev
 	}
 
+	var rvals []byte
+	if len(l.valueFiles) > 0 || len(l.values) > 0 || len(l.stringValues) > 0 || len(l.fileValues) > 0 {
+		var err error
+		if rvals, err = vals(l.valueFiles, l.values, l.stringValues, l.fileValues); err != nil {
+			return err
+		}
+	}
+
 	var total int
 	var failures int
 	for _, path := range l.paths {
-		if linter, err := lintChart(path); err != nil {
+		if linter, err := lintChart(path, rvals); err != nil {
 			fmt.Println("==> Skipping", path)
 			fmt.Println(err)
 		} else {
@@ -114,7 +135,7 @@ func (l *lintCmd) run() error {
 	return nil
 }
 
-func lintChart(path string) (support.Linter, error) {
+func lintChart(path string, values []byte) (support.Linter, error) {
 	var chartPath string
 	linter := support.Linter{}
 
@@ -146,5 +167,5 @@ func lintChart(path string) (support.Linter, error) {
 		return linter, errLintNoChart
 	}
 
-	return lint.All(chartPath), nil
+	return lint.All(chartPath, values), nil
 }
@@ -26,11 +26,11 @@ var (
 )
 
 func TestLintChart(t *testing.T) {
-	if _, err := lintChart(chartDirPath); err != nil {
+	if _, err := lintChart(chartDirPath, nil); err != nil {
 		t.Errorf("%s", err)
 	}
 
-	if _, err := lintChart(archivedChartPath); err != nil {
+	if _, err := lintChart(archivedChartPath, nil); err != nil {
 		t.Errorf("%s", err)
 	}
 
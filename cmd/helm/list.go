@@ -17,10 +17,15 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig"
+	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
@@ -48,6 +53,11 @@ Only items that match the filter will be returned.
 	NAME            	UPDATED                 	CHART
 	maudlin-arachnid	Mon May  9 16:07:08 2016	alpine-0.1.0
 
+Releases that are stuck in the middle of an install, upgrade or rollback (for
+example because Tiller was interrupted) can be found with '--pending'. When
+such releases are listed, a hint on how to recover them is printed after the
+table.
+
 If no results are found, 'helm list' will exit 0, but with no output (or in
 the case of no '-q' flag, only headers).
 
@@ -55,6 +65,26 @@ By default, up to 256 items may be returned. To limit this, use the '--max' flag
 Setting '--max' to 0 will not return all results. Rather, it will return the
 server's default, which may be much higher than 256. Pairing the '--max'
 flag with the '--offset' flag allows you to page through results.
+
+Use '--output wide' to add the app version and the description of each release
+to the table.
+
+For very large result sets, '--output jsonl' writes one JSON object per release
+and per line. In this mode, '--max' is used as the page size: pages are fetched
+and written one after another until all matching releases have been listed, so
+that the full list never has to be held in memory.
+
+	$ helm list --all --max 500 --output jsonl
+
+To process a single page of results in a script, use '--output json' or
+'--output yaml'. The releases are written as a list with the name, revision,
+status, chart, namespace and last update time of each release.
+
+To pick the fields to print, use '--output go-template=TEMPLATE'. The
+template is executed on the list of releases, using the field names Name,
+Revision, Updated, Status, Chart and Namespace:
+
+	$ helm list --output go-template='{{range .}}{{.Name}} {{.Chart}}{{"\n"}}{{end}}'
 `
 
 type listCmd struct {
@@ -72,6 +102,8 @@ type listCmd struct {
 	failed     bool
 	namespace  string
 	superseded bool
+	pending    bool
+	output     string
 	client     helm.Interface
 }
 
@@ -92,7 +124,7 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 				list.filter = strings.Join(args, " ")
 			}
 			if list.client == nil {
-				list.client = helm.NewClient(helm.Host(tillerHost))
+				list.client = helm.NewClient(helm.Host(tillerHost), helm.ConnectTimeout(tillerConnectionTimeout))
 			}
 			return list.run()
 		},
@@ -109,7 +141,9 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&list.deleting, "deleting", false, "show releases that are currently being deleted")
 	f.BoolVar(&list.deployed, "deployed", false, "show deployed releases. If no other is specified, this will be automatically enabled")
 	f.BoolVar(&list.failed, "failed", false, "show failed releases")
+	f.BoolVar(&list.pending, "pending", false, "show pending releases (installs, upgrades and rollbacks that are in progress or were interrupted)")
 	f.StringVar(&list.namespace, "namespace", "", "show releases within a specific namespace")
+	f.StringVar(&list.output, "output", "", "output format. Allowed values: table, wide, json, yaml, jsonl, go-template=TEMPLATE")
 
 	// TODO: Do we want this as a feature of 'helm list'?
 	//f.BoolVar(&list.superseded, "history", true, "show historical releases")
@@ -118,32 +152,33 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (l *listCmd) run() error {
-	sortBy := services.ListSort_NAME
-	if l.byDate {
-		sortBy = services.ListSort_LAST_RELEASED
-	}
-
-	sortOrder := services.ListSort_ASC
-	if l.sortDesc {
-		sortOrder = services.ListSort_DESC
+	var tmpl *template.Template
+	switch {
+	case l.output == "", l.output == "table", l.output == "wide", l.output == "json", l.output == "yaml":
+	case l.output == "jsonl":
+		return l.streamJSONLines()
+	case strings.HasPrefix(l.output, goTemplatePrefix):
+		t, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(strings.TrimPrefix(l.output, goTemplatePrefix))
+		if err != nil {
+			return fmt.Errorf("invalid output template: %s", err)
+		}
+		tmpl = t
+	default:
+		return fmt.Errorf("unknown output format %q", l.output)
 	}
 
-	stats := l.statusCodes()
-
-	res, err := l.client.ListReleases(
-		helm.ReleaseListLimit(l.limit),
-		helm.ReleaseListOffset(l.offset),
-		helm.ReleaseListFilter(l.filter),
-		helm.ReleaseListSort(int32(sortBy)),
-		helm.ReleaseListOrder(int32(sortOrder)),
-		helm.ReleaseListStatuses(stats),
-		helm.ReleaseListNamespace(l.namespace),
-	)
-
+	res, err := l.list(l.offset)
 	if err != nil {
 		return prettyError(err)
 	}
 
+	switch {
+	case l.output == "json", l.output == "yaml":
+		return l.writeStructured(res.Releases)
+	case tmpl != nil:
+		return tmpl.Execute(l.out, newListReleases(res.Releases))
+	}
+
 	if len(res.Releases) == 0 {
 		return nil
 	}
@@ -160,7 +195,73 @@ func (l *listCmd) run() error {
 		}
 		return nil
 	}
-	fmt.Fprintln(l.out, formatList(rels))
+	fmt.Fprintln(l.out, formatList(rels, l.output == "wide"))
+	if hint := pendingHint(rels); hint != "" {
+		fmt.Fprint(l.out, hint)
+	}
+	return nil
+}
+
+// list fetches a single page of releases, starting at the given offset.
+func (l *listCmd) list(offset string) (*services.ListReleasesResponse, error) {
+	sortBy := services.ListSort_NAME
+	if l.byDate {
+		sortBy = services.ListSort_LAST_RELEASED
+	}
+
+	sortOrder := services.ListSort_ASC
+	if l.sortDesc {
+		sortOrder = services.ListSort_DESC
+	}
+
+	return l.client.ListReleases(
+		helm.ReleaseListLimit(l.limit),
+		helm.ReleaseListOffset(offset),
+		helm.ReleaseListFilter(l.filter),
+		helm.ReleaseListSort(int32(sortBy)),
+		helm.ReleaseListOrder(int32(sortOrder)),
+		helm.ReleaseListStatuses(l.statusCodes()),
+		helm.ReleaseListNamespace(l.namespace),
+	)
+}
+
+// streamJSONLines writes every matching release as a JSON object on its own
+// line, fetching the releases page by page.
+func (l *listCmd) streamJSONLines() error {
+	enc := json.NewEncoder(l.out)
+	offset := l.offset
+	for {
+		res, err := l.list(offset)
+		if err != nil {
+			return prettyError(err)
+		}
+		for _, r := range res.Releases {
+			if err := enc.Encode(newListRelease(r)); err != nil {
+				return err
+			}
+		}
+		if res.Next == "" || res.Next == offset {
+			return nil
+		}
+		offset = res.Next
+	}
+}
+
+// writeStructured writes the releases as a JSON or YAML list.
+func (l *listCmd) writeStructured(rels []*release.Release) error {
+	list := newListReleases(rels)
+
+	var data []byte
+	var err error
+	if l.output == "json" {
+		data, err = json.MarshalIndent(list, "", "  ")
+	} else {
+		data, err = yaml.Marshal(list)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(l.out, strings.TrimSpace(string(data)))
 	return nil
 }
 
@@ -173,6 +274,9 @@ func (l *listCmd) statusCodes() []release.Status_Code {
 			release.Status_DELETED,
 			release.Status_DELETING,
 			release.Status_FAILED,
+			release.Status_PENDING_INSTALL,
+			release.Status_PENDING_UPGRADE,
+			release.Status_PENDING_ROLLBACK,
 		}
 	}
 	status := []release.Status_Code{}
@@ -191,6 +295,9 @@ func (l *listCmd) statusCodes() []release.Status_Code {
 	if l.superseded {
 		status = append(status, release.Status_SUPERSEDED)
 	}
+	if l.pending {
+		status = append(status, release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK)
+	}
 
 	// Default case.
 	if len(status) == 0 {
@@ -199,17 +306,79 @@ func (l *listCmd) statusCodes() []release.Status_Code {
 	return status
 }
 
-func formatList(rels []*release.Release) string {
+// listRelease is the machine-readable representation of a listed release.
+type listRelease struct {
+	Name      string `json:"name"`
+	Revision  int32  `json:"revision"`
+	Updated   string `json:"updated"`
+	Status    string `json:"status"`
+	Chart     string `json:"chart"`
+	Namespace string `json:"namespace"`
+}
+
+// goTemplatePrefix prefixes the template given with '--output'.
+const goTemplatePrefix = "go-template="
+
+func newListReleases(rels []*release.Release) []*listRelease {
+	list := make([]*listRelease, 0, len(rels))
+	for _, r := range rels {
+		list = append(list, newListRelease(r))
+	}
+	return list
+}
+
+func newListRelease(r *release.Release) *listRelease {
+	return &listRelease{
+		Name:      r.Name,
+		Revision:  r.Version,
+		Updated:   timeconv.String(r.Info.LastDeployed),
+		Status:    r.Info.Status.Code.String(),
+		Chart:     fmt.Sprintf("%s-%s", r.Chart.Metadata.Name, r.Chart.Metadata.Version),
+		Namespace: r.Namespace,
+	}
+}
+
+// formatList formats releases as a table. If wide is set, the app version and
+// description of each release are added, and long values are wrapped rather
+// than truncated.
+func formatList(rels []*release.Release, wide bool) string {
 	table := uitable.New()
 	table.MaxColWidth = 60
-	table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "NAMESPACE")
+	if wide {
+		table.Wrap = true
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "NAMESPACE", "APP VERSION", "DESCRIPTION")
+	} else {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "NAMESPACE")
+	}
 	for _, r := range rels {
 		c := fmt.Sprintf("%s-%s", r.Chart.Metadata.Name, r.Chart.Metadata.Version)
 		t := timeconv.String(r.Info.LastDeployed)
 		s := r.Info.Status.Code.String()
 		v := r.Version
 		n := r.Namespace
-		table.AddRow(r.Name, v, t, s, c, n)
+		if wide {
+			table.AddRow(r.Name, v, t, s, c, n, r.Chart.Metadata.AppVersion, r.Info.Description)
+		} else {
+			table.AddRow(r.Name, v, t, s, c, n)
+		}
 	}
 	return table.String()
 }
+
+// pendingHint returns instructions for recovering releases that are stuck in a
+// pending state, or an empty string if none of the releases are pending.
+func pendingHint(rels []*release.Release) string {
+	var b bytes.Buffer
+	for _, r := range rels {
+		switch r.Info.Status.Code {
+		case release.Status_PENDING_INSTALL:
+			fmt.Fprintf(&b, "  %s: delete it with 'helm delete --purge %s'\n", r.Name, r.Name)
+		case release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK:
+			fmt.Fprintf(&b, "  %s: roll it back with 'helm rollback %s %d'\n", r.Name, r.Name, r.Version-1)
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\nSome releases have an operation in progress. If it was interrupted, recover with:\n" + b.String()
+}
@@ -21,7 +21,9 @@ import (
 	"regexp"
 	"testing"
 
+	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
 func TestListCmd(t *testing.T) {
@@ -47,6 +49,49 @@ func TestListCmd(t *testing.T) {
 			},
 			expected: "NAME \tREVISION\tUPDATED                 \tSTATUS  \tCHART           \tNAMESPACE\natlas\t1       \t(.*)\tDEPLOYED\tfoo-0.1.0-beta.1\tdefault  \n",
 		},
+		{
+			name: "list with wide output",
+			args: []string{"--output", "wide"},
+			resp: []*release.Release{
+				releaseMock(&releaseOptions{name: "atlas"}),
+			},
+			expected: "NAME \tREVISION\tUPDATED                 \tSTATUS  \tCHART           \tNAMESPACE\tAPP VERSION\tDESCRIPTION \natlas\t1       \t(.*)\tDEPLOYED\tfoo-0.1.0-beta.1\tdefault  \t           \tRelease mock\n",
+		},
+		{
+			name: "list with json output",
+			args: []string{"--output", "json"},
+			resp: []*release.Release{
+				releaseMock(&releaseOptions{name: "atlas"}),
+			},
+			expected: `\[\n  \{\n    "name": "atlas",\n    "revision": 1,\n    "updated": "(.*)",\n    "status": "DEPLOYED",\n    "chart": "foo-0.1.0-beta.1",\n    "namespace": "default"\n  \}\n\]\n`,
+		},
+		{
+			name: "list with yaml output",
+			args: []string{"--output", "yaml"},
+			resp: []*release.Release{
+				releaseMock(&releaseOptions{name: "atlas"}),
+			},
+			expected: "- chart: foo-0.1.0-beta.1\n  name: atlas\n  namespace: default\n  revision: 1\n  status: DEPLOYED\n  updated: (.*)\n",
+		},
+		{
+			name: "list with go-template output",
+			args: []string{"--output", `go-template={{range .}}{{.Name}}={{.Chart}} {{end}}`},
+			resp: []*release.Release{
+				releaseMock(&releaseOptions{name: "atlas"}),
+				releaseMock(&releaseOptions{name: "thomas-guide"}),
+			},
+			expected: `^atlas=foo-0.1.0-beta.1 thomas-guide=foo-0.1.0-beta.1 $`,
+		},
+		{
+			name: "list with invalid go-template output",
+			args: []string{"--output", `go-template={{range .}}`},
+			err:  true,
+		},
+		{
+			name:     "list with json output and no releases",
+			args:     []string{"--output", "json"},
+			expected: `^\[\]\n$`,
+		},
 		{
 			name: "list, one deployed, one failed",
 			args: []string{"-q"},
@@ -87,6 +132,15 @@ func TestListCmd(t *testing.T) {
 			// See note on previous test.
 			expected: "thomas-guide\natlas-guide",
 		},
+		{
+			name: "pending releases with recovery hint",
+			args: []string{"--pending"},
+			resp: []*release.Release{
+				releaseMock(&releaseOptions{name: "thomas-guide", statusCode: release.Status_PENDING_INSTALL}),
+				releaseMock(&releaseOptions{name: "atlas-guide", version: 3, statusCode: release.Status_PENDING_UPGRADE}),
+			},
+			expected: "PENDING_INSTALL(.|\\n)*PENDING_UPGRADE(.|\\n)*thomas-guide: delete it with 'helm delete --purge thomas-guide'\\n  atlas-guide: roll it back with 'helm rollback atlas-guide 2'\\n",
+		},
 		{
 			name: "namespace defined, multiple flags",
 			args: []string{"--all", "-q", "--namespace test123"},
@@ -117,3 +171,52 @@ func TestListCmd(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+// pagedReleaseClient returns one page of releases per ListReleases call.
+type pagedReleaseClient struct {
+	fakeReleaseClient
+	pages [][]*release.Release
+	calls int
+}
+
+func (c *pagedReleaseClient) ListReleases(opts ...helm.ReleaseListOption) (*rls.ListReleasesResponse, error) {
+	page := c.pages[c.calls]
+	c.calls++
+	resp := &rls.ListReleasesResponse{Count: int64(len(page)), Releases: page}
+	if c.calls < len(c.pages) {
+		resp.Next = c.pages[c.calls][0].Name
+	}
+	return resp, nil
+}
+
+func TestListCmdJSONLines(t *testing.T) {
+	c := &pagedReleaseClient{
+		pages: [][]*release.Release{
+			{
+				releaseMock(&releaseOptions{name: "atlas"}),
+				releaseMock(&releaseOptions{name: "bravo"}),
+			},
+			{
+				releaseMock(&releaseOptions{name: "charlie", statusCode: release.Status_FAILED}),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	cmd := newListCmd(c, &buf)
+	cmd.ParseFlags([]string{"--output", "jsonl", "--max", "2"})
+	if err := cmd.RunE(cmd, []string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.calls != 2 {
+		t.Errorf("Expected 2 pages to be fetched, got %d", c.calls)
+	}
+	expected := `^{"name":"atlas","revision":1,"updated":".*","status":"DEPLOYED","chart":"foo-0.1.0-beta.1","namespace":"default"}
+{"name":"bravo",.*}
+{"name":"charlie",.*"status":"FAILED",.*}
+$`
+	if !regexp.MustCompile(expected).Match(buf.Bytes()) {
+		t.Errorf("expected\n%q\ngot\n%q", expected, buf.String())
+	}
+}
@@ -21,12 +21,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/plugin"
+	"k8s.io/helm/pkg/version"
 )
 
 const pluginEnvVar = "HELM_PLUGIN"
@@ -61,6 +63,10 @@ func loadPlugins(baseCmd *cobra.Command, home helmpath.Home, out io.Writer) {
 	for _, plug := range found {
 		plug := plug
 		md := plug.Metadata
+		if err := plug.CheckHelmVersion(version.GetVersion()); err != nil {
+			fmt.Fprintf(os.Stderr, "skipping plugin: %s\n", err)
+			continue
+		}
 		if md.Usage == "" {
 			md.Usage = fmt.Sprintf("the %q plugin", md.Name)
 		}
@@ -72,7 +78,7 @@ func loadPlugins(baseCmd *cobra.Command, home helmpath.Home, out io.Writer) {
 			RunE: func(cmd *cobra.Command, args []string) error {
 
 				k, u := manuallyProcessArgs(args)
-				if err := cmd.Parent().ParseFlags(k); err != nil {
+				if err := cmd.ParseFlags(k); err != nil {
 					return err
 				}
 
@@ -103,7 +109,7 @@ func loadPlugins(baseCmd *cobra.Command, home helmpath.Home, out io.Writer) {
 			c.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 				// Parse the parent flag, but not the local flags.
 				k, _ := manuallyProcessArgs(args)
-				if err := c.Parent().ParseFlags(k); err != nil {
+				if err := c.ParseFlags(k); err != nil {
 					return err
 				}
 				return setupConnection(cmd, args)
@@ -111,19 +117,22 @@ func loadPlugins(baseCmd *cobra.Command, home helmpath.Home, out io.Writer) {
 		}
 
 		// TODO: Make sure a command with this name does not already exist.
-		baseCmd.AddCommand(c)
+		baseCmd.AddCommand(addFlagsTLS(c))
 	}
 }
 
 // manuallyProcessArgs processes an arg array, removing special args.
 //
-// Returns two sets of args: known and unknown (in that order)
+// Returns two sets of args: known and unknown (in that order). The TLS flags
+// and the two-word --tiller-namespace are in both, as plugins were passed
+// them before helm parsed them.
 func manuallyProcessArgs(args []string) ([]string, []string) {
 	known := []string{}
 	unknown := []string{}
 	kvargs := []string{"--host", "--kube-context", "--home", "--tiller-namespace"}
-	knownArg := func(a string) bool {
-		for _, pre := range kvargs {
+	tlsargs := []string{"--tls-ca-cert", "--tls-cert", "--tls-key"}
+	hasPrefix := func(a string, prefixes []string) bool {
+		for _, pre := range prefixes {
 			if strings.HasPrefix(a, pre+"=") {
 				return true
 			}
@@ -134,15 +143,26 @@ func manuallyProcessArgs(args []string) ([]string, []string) {
 		switch a := args[i]; a {
 		case "--debug":
 			known = append(known, a)
+		case "--tls", "--tls-verify":
+			known = append(known, a)
+			unknown = append(unknown, a)
 		case "--host", "--kube-context", "--home":
 			known = append(known, a, args[i+1])
 			i++
+		case "--tiller-namespace", "--tls-ca-cert", "--tls-cert", "--tls-key":
+			known = append(known, a, args[i+1])
+			unknown = append(unknown, a, args[i+1])
+			i++
 		default:
-			if knownArg(a) {
+			switch {
+			case hasPrefix(a, tlsargs):
 				known = append(known, a)
-				continue
+				unknown = append(unknown, a)
+			case hasPrefix(a, kvargs):
+				known = append(known, a)
+			default:
+				unknown = append(unknown, a)
 			}
-			unknown = append(unknown, a)
 		}
 	}
 	return known, unknown
@@ -150,16 +170,31 @@ func manuallyProcessArgs(args []string) ([]string, []string) {
 
 // findPlugins returns a list of YAML files that describe plugins.
 func findPlugins(plugdirs string) ([]*plugin.Plugin, error) {
-	found := []*plugin.Plugin{}
-	// Let's get all UNIXy and allow path separators
-	for _, p := range filepath.SplitList(plugdirs) {
-		matches, err := plugin.LoadAll(p)
+	return plugin.FindPlugins(plugdirs)
+}
+
+// downloaderPlugins returns the installed plugins that provide downloaders.
+// Plugins that fail to load are skipped, so that a single broken plugin does
+// not break every command that downloads charts.
+func downloaderPlugins(home helmpath.Home) ([]*plugin.Plugin, error) {
+	plugins := []*plugin.Plugin{}
+	for _, dir := range filepath.SplitList(pluginDirs(home)) {
+		matches, err := filepath.Glob(filepath.Join(dir, "*", plugin.PluginFileName))
 		if err != nil {
-			return matches, err
+			return nil, err
+		}
+		for _, m := range matches {
+			p, err := plugin.LoadDir(filepath.Dir(m))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "skipping plugin in %s: %s\n", filepath.Dir(m), err)
+				continue
+			}
+			if len(p.Metadata.Downloaders) > 0 && p.CheckHelmVersion(version.GetVersion()) == nil {
+				plugins = append(plugins, p)
+			}
 		}
-		found = append(found, matches...)
 	}
-	return found, nil
+	return plugins, nil
 }
 
 // setupEnv prepares os.Env for plugins. It operates on os.Env because
@@ -186,10 +221,24 @@ func setupEnv(shortname, base, plugdirs string, home helmpath.Home) {
 
 		"TILLER_HOST":         tillerHost,
 		tillerNamespaceEnvVar: tillerNamespace,
+		"KUBECONTEXT":         kubeContext,
+
+		// Set the TLS settings of the connection to Tiller.
+		"HELM_TLS_ENABLE": strconv.FormatBool(tlsEnable || tlsVerify),
+		"HELM_TLS_VERIFY": strconv.FormatBool(tlsVerify),
 	} {
 		os.Setenv(key, val)
 	}
 
+	// The TLS file paths default to files in $HELM_HOME, which is set above.
+	for key, val := range map[string]string{
+		"HELM_TLS_CA_CERT": tlsCaCertFile,
+		"HELM_TLS_CERT":    tlsCertFile,
+		"HELM_TLS_KEY":     tlsKeyFile,
+	} {
+		os.Setenv(key, os.ExpandEnv(val))
+	}
+
 	if flagDebug {
 		os.Setenv("HELM_DEBUG", "1")
 	}
@@ -45,16 +45,25 @@ If no path is given, this will look in the present working directory for a
 Chart.yaml file, and (if found) build the current directory into a chart.
 
 Versioned chart archives are used by Helm package repositories.
+
+The '--version' and '--app-version' flags override the version and appVersion
+in Chart.yaml for the packaged chart, without changing the chart source.
+
+With '--dependency-update', the dependencies in requirements.yaml are updated
+into the charts/ directory (as with 'helm dependency update') before the chart
+is packaged.
 `
 
 type packageCmd struct {
-	save        bool
-	sign        bool
-	path        string
-	key         string
-	keyring     string
-	version     string
-	destination string
+	save             bool
+	sign             bool
+	dependencyUpdate bool
+	path             string
+	key              string
+	keyring          string
+	version          string
+	appVersion       string
+	destination      string
 
 	out  io.Writer
 	home helmpath.Home
@@ -96,9 +105,11 @@ func newPackageCmd(out io.Writer) *cobra.Command {
 	f.BoolVar(&pkg.save, "save", true, "save packaged chart to local chart repository")
 	f.BoolVar(&pkg.sign, "sign", false, "use a PGP private key to sign this package")
 	f.StringVar(&pkg.key, "key", "", "name of the key to use when signing. Used if --sign is true")
-	f.StringVar(&pkg.keyring, "keyring", defaultKeyring(), "location of a public keyring")
+	f.StringVar(&pkg.keyring, "keyring", gnupgKeyring(), "location of the keyring that holds the signing key. Used if --sign is true")
 	f.StringVar(&pkg.version, "version", "", "set the version on the chart to this semver version")
+	f.StringVar(&pkg.appVersion, "app-version", "", "set the appVersion on the chart to this version")
 	f.StringVarP(&pkg.destination, "destination", "d", ".", "location to write the chart.")
+	f.BoolVarP(&pkg.dependencyUpdate, "dependency-update", "u", false, "update dependencies from requirements.yaml to dir charts/ before packaging")
 
 	return cmd
 }
@@ -109,6 +120,12 @@ func (p *packageCmd) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if p.dependencyUpdate {
+		if err := updateDependencies(p.out, path, p.home); err != nil {
+			return err
+		}
+	}
+
 	ch, err := chartutil.LoadDir(path)
 	if err != nil {
 		return err
@@ -124,6 +141,13 @@ func (p *packageCmd) run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if p.appVersion != "" {
+		ch.Metadata.AppVersion = p.appVersion
+		if flagDebug {
+			fmt.Fprintf(p.out, "Setting appVersion to %s", p.appVersion)
+		}
+	}
+
 	if filepath.Base(path) != ch.Metadata.Name {
 		return fmt.Errorf("directory name (%s) and Chart.yaml name (%s) must match", filepath.Base(path), ch.Metadata.Name)
 	}
@@ -198,7 +222,7 @@ func (p *packageCmd) clearsign(filename string) error {
 		fmt.Fprintln(p.out, sig)
 	}
 
-	return ioutil.WriteFile(filename+".prov", []byte(sig), 0755)
+	return ioutil.WriteFile(filename+".prov", []byte(sig), 0644)
 }
 
 // promptUser implements provenance.PassphraseFetcher
@@ -25,8 +25,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/repo"
 )
 
 func TestSetVersion(t *testing.T) {
@@ -195,8 +198,110 @@ func TestPackage(t *testing.T) {
 			} else if fi.Size() == 0 {
 				t.Errorf("%q: provenance file is empty", tt.name)
 			}
+			if _, err := downloader.VerifyChart(tt.hasfile, filepath.Join(origDir, "testdata/helm-test-key.pub")); err != nil {
+				t.Errorf("%q: expected the signed package to verify: %s", tt.name, err)
+			}
+		}
+	}
+}
+
+func TestPackageVersions(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-package-versions-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	c := newPackageCmd(ioutil.Discard)
+	setFlags(c, map[string]string{
+		"save":        "0",
+		"destination": tmp,
+		"version":     "0.2.0",
+		"app-version": "2.4.1",
+	})
+	if err := c.RunE(c, []string{"testdata/testcharts/alpine"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := chartutil.Load(filepath.Join(tmp, "alpine-0.2.0.tgz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Metadata.Version != "0.2.0" {
+		t.Errorf("Expected version 0.2.0, got %q", ch.Metadata.Version)
+	}
+	if ch.Metadata.AppVersion != "2.4.1" {
+		t.Errorf("Expected appVersion 2.4.1, got %q", ch.Metadata.AppVersion)
+	}
+
+	// The chart source is left untouched.
+	src, err := chartutil.LoadChartfile("testdata/testcharts/alpine/Chart.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.AppVersion == "2.4.1" {
+		t.Error("Expected Chart.yaml to be left unchanged")
+	}
+}
+
+func TestPackageDependencyUpdate(t *testing.T) {
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldhome := helmHome
+	helmHome = thome
+	defer func() {
+		helmHome = oldhome
+		os.RemoveAll(thome)
+	}()
+	// Only local dependencies are used, so no repositories are needed.
+	if err := repo.NewRepoFile().WriteFile(helmpath.Home(thome).RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	chartpath := createChartWithLocalDependency(t, thome)
+
+	c := newPackageCmd(ioutil.Discard)
+	setFlags(c, map[string]string{
+		"save":              "0",
+		"destination":       thome,
+		"dependency-update": "1",
+	})
+	if err := c.RunE(c, []string{chartpath}); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := chartutil.Load(filepath.Join(thome, "withdeps-0.1.0.tgz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ch.Dependencies) != 1 || ch.Dependencies[0].Metadata.Name != "alpine" {
+		t.Errorf("Expected the package to contain the alpine dependency, got %v", ch.Dependencies)
+	}
+}
+
+// createChartWithLocalDependency creates the chart withdeps in dir, which
+// requires the alpine test chart from a file:// repository.
+func createChartWithLocalDependency(t *testing.T, dir string) string {
+	alpine, err := filepath.Abs("testdata/testcharts/alpine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chartpath := filepath.Join(dir, "withdeps")
+	if err := os.Mkdir(chartpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"Chart.yaml":        "name: withdeps\nversion: 0.1.0\n",
+		"requirements.yaml": "dependencies:\n- name: alpine\n  version: 0.1.0\n  repository: file://" + alpine + "\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(chartpath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
 		}
 	}
+	return chartpath
 }
 
 func setFlags(cmd *cobra.Command, flags map[string]string) {
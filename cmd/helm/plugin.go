@@ -34,13 +34,14 @@ Manage client-side Helm plugins.
 func newPluginCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "plugin",
-		Short: "add, list, or remove Helm plugins",
+		Short: "add, list, remove, or update Helm plugins",
 		Long:  pluginHelp,
 	}
 	cmd.AddCommand(
 		newPluginInstallCmd(out),
 		newPluginListCmd(out),
 		newPluginRemoveCmd(out),
+		newPluginUpdateCmd(out),
 	)
 	return cmd
 }
@@ -18,10 +18,12 @@ package main
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/plugin/installer"
+	"k8s.io/helm/pkg/version"
 
 	"github.com/spf13/cobra"
 )
@@ -75,6 +77,14 @@ func (pcmd *pluginInstallCmd) run() error {
 		return err
 	}
 
+	if err := p.CheckHelmVersion(version.GetVersion()); err != nil {
+		debug("removing incompatible plugin from %s", i.Path())
+		if rerr := os.RemoveAll(i.Path()); rerr != nil {
+			return fmt.Errorf("%s (failed to remove it: %s)", err, rerr)
+		}
+		return err
+	}
+
 	if err := runHook(p, plugin.Install, pcmd.home); err != nil {
 		return err
 	}
@@ -16,7 +16,10 @@ limitations under the License.
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -24,6 +27,8 @@ import (
 	"testing"
 
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/plugin"
+	"k8s.io/helm/pkg/plugin/installer"
 
 	"github.com/spf13/cobra"
 )
@@ -36,18 +41,28 @@ func TestManuallyProcessArgs(t *testing.T) {
 		"--kube-context", "test1",
 		"--home=/tmp",
 		"--tiller-namespace=hello",
+		"--tls", "--tls-verify",
+		"--tls-ca-cert", "ca.pem",
+		"--tls-cert=cert.pem",
+		"--tiller-namespace", "world",
 		"command",
 	}
 
 	expectKnown := []string{
 		"--debug", "--host", "example.com", "--kube-context", "test1", "--home=/tmp", "--tiller-namespace=hello",
+		"--tls", "--tls-verify", "--tls-ca-cert", "ca.pem", "--tls-cert=cert.pem", "--tiller-namespace", "world",
 	}
 
+	// The TLS flags and the two-word --tiller-namespace are still passed to
+	// the plugin.
 	expectUnknown := []string{
-		"--foo", "bar", "command",
+		"--foo", "bar", "--tls", "--tls-verify", "--tls-ca-cert", "ca.pem", "--tls-cert=cert.pem", "--tiller-namespace", "world", "command",
 	}
 
 	known, unknown := manuallyProcessArgs(input)
+	if len(known) != len(expectKnown) || len(unknown) != len(expectUnknown) {
+		t.Fatalf("expected known %q and unknown %q, got %q and %q", expectKnown, expectUnknown, known, unknown)
+	}
 
 	for i, k := range known {
 		if k != expectKnown[i] {
@@ -156,14 +171,113 @@ func TestLoadPlugins_HelmNoPlugins(t *testing.T) {
 	}
 }
 
+func TestDownloaderPluginsSkipsBroken(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+	home := helmpath.Home(hh)
+	old := os.Getenv(pluginEnvVar)
+	os.Setenv(pluginEnvVar, home.Plugins())
+	defer os.Setenv(pluginEnvVar, old)
+
+	for name, metadata := range map[string]string{
+		"downloader": "name: downloader\ndownloaders:\n- command: echo\n  protocols: [\"s3\"]\n",
+		"broken":     "name: [broken\n",
+	} {
+		dir := filepath.Join(home.Plugins(), name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte(metadata), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plugins, err := downloaderPlugins(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plugins) != 1 || plugins[0].Metadata.Name != "downloader" {
+		t.Errorf("Expected only the downloader plugin, got %v", plugins)
+	}
+}
+
+func TestUpdatePluginIncompatible(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	source := filepath.Join(hh, "echo.tgz")
+	writePluginArchive(t, source, "name: echo\n")
+	i, err := installer.NewForSource(source, "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := installer.Install(i); err != nil {
+		t.Fatal(err)
+	}
+	p, err := plugin.LoadDir(i.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The update requires a Helm release that does not exist yet.
+	writePluginArchive(t, source, "name: echo\nrequiredHelmVersion: 99.0.0\n")
+	if err := updatePlugin(p, "", home); err == nil {
+		t.Fatal("Expected an error for an incompatible plugin")
+	}
+	b, err := ioutil.ReadFile(filepath.Join(i.Path(), "plugin.yaml"))
+	if err != nil {
+		t.Fatalf("Expected the installed plugin to be kept: %s", err)
+	}
+	if string(b) != "name: echo\n" {
+		t.Errorf("Expected the installed version to be kept, got %q", b)
+	}
+}
+
+// writePluginArchive writes a gzipped tarball of a plugin with the metadata
+// in pluginYAML to path.
+func writePluginArchive(t *testing.T, path, pluginYAML string) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	if err := tw.WriteHeader(&tar.Header{Name: "plugin.yaml", Mode: 0644, Size: int64(len(pluginYAML))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(pluginYAML)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestSetupEnv(t *testing.T) {
 	name := "pequod"
 	hh := helmpath.Home("testdata/helmhome")
 	base := filepath.Join(hh.Plugins(), name)
 	plugdirs := hh.Plugins()
 	flagDebug = true
+	// --tls-verify implies --tls.
+	tlsVerify, tlsCertFile = true, "$HELM_HOME/cert.pem"
 	defer func() {
 		flagDebug = false
+		tlsVerify, tlsCertFile = false, ""
 	}()
 
 	setupEnv(name, base, plugdirs, hh)
@@ -183,6 +297,10 @@ func TestSetupEnv(t *testing.T) {
 		{"HELM_PATH_STARTER", hh.Starters()},
 		{"TILLER_HOST", tillerHost},
 		{"TILLER_NAMESPACE", tillerNamespace},
+		{"KUBECONTEXT", kubeContext},
+		{"HELM_TLS_ENABLE", "true"},
+		{"HELM_TLS_VERIFY", "true"},
+		{"HELM_TLS_CERT", hh.TLSCert()},
 	} {
 		if got := os.Getenv(tt.name); got != tt.expect {
 			t.Errorf("Expected $%s=%q, got %q", tt.name, tt.expect, got)
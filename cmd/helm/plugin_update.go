@@ -0,0 +1,111 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/plugin"
+	"k8s.io/helm/pkg/plugin/installer"
+	"k8s.io/helm/pkg/version"
+
+	"github.com/spf13/cobra"
+)
+
+type pluginUpdateCmd struct {
+	names   []string
+	version string
+	home    helmpath.Home
+	out     io.Writer
+}
+
+func newPluginUpdateCmd(out io.Writer) *cobra.Command {
+	pcmd := &pluginUpdateCmd{out: out}
+	cmd := &cobra.Command{
+		Use:   "update <plugin>...",
+		Short: "update one or more Helm plugins",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return pcmd.complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pcmd.run()
+		},
+	}
+	cmd.Flags().StringVar(&pcmd.version, "version", "", "specify a version constraint. If this is not specified, the latest version is installed")
+	return cmd
+}
+
+func (pcmd *pluginUpdateCmd) complete(args []string) error {
+	if err := checkArgsLength(len(args), "plugin"); err != nil {
+		return err
+	}
+	pcmd.names = args
+	pcmd.home = helmpath.Home(homePath())
+	return nil
+}
+
+func (pcmd *pluginUpdateCmd) run() error {
+	installer.Debug = flagDebug
+	plugdirs := pluginDirs(pcmd.home)
+	debug("loading installed plugins from %s", plugdirs)
+	plugins, err := findPlugins(plugdirs)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range pcmd.names {
+		found := findPlugin(plugins, name)
+		if found == nil {
+			return fmt.Errorf("plugin %q not found", name)
+		}
+		if err := updatePlugin(found, pcmd.version, pcmd.home); err != nil {
+			return err
+		}
+		fmt.Fprintf(pcmd.out, "Updated plugin: %s\n", name)
+	}
+	return nil
+}
+
+// updatePlugin updates p from the source it was installed from and runs its
+// install hook again. The installed version is kept if the new one does not
+// load or does not support this version of Helm.
+func updatePlugin(p *plugin.Plugin, constraint string, home helmpath.Home) error {
+	debug("updating plugin installed at %s", p.Dir)
+	i, err := installer.FindSource(p.Dir, constraint, home)
+	if err != nil {
+		return err
+	}
+	err = installer.UpdateChecked(i, func(dir string) error {
+		debug("checking plugin in %s", dir)
+		updated, err := plugin.LoadDir(dir)
+		if err != nil {
+			return err
+		}
+		return updated.CheckHelmVersion(version.GetVersion())
+	})
+	if err != nil {
+		return err
+	}
+
+	debug("loading plugin from %s", i.Path())
+	updated, err := plugin.LoadDir(i.Path())
+	if err != nil {
+		return err
+	}
+	return runHook(updated, plugin.Install, home)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/repo"
+)
+
+const pushDesc = `
+This command uploads a packaged chart to a chart repository served by
+'helm serve'.
+
+The repository is given by the name it was added with in 'helm repo add', and
+the credentials and TLS settings of that repository are used for the upload.
+
+	$ helm package mychart
+	$ helm push mychart-0.1.0.tgz myrepo
+
+The repository refuses to overwrite a chart version that it already holds,
+unless '--force' is set. Run 'helm repo update' afterwards to see the new
+chart in 'helm search'.
+
+A chart can also be pushed to an OCI registry by giving an 'oci://' reference
+instead of a repository name. The chart is tagged with its version, unless the
+reference has a tag. Registry credentials are read from the Docker
+configuration, as written by 'docker login':
+
+	$ helm push mychart-0.1.0.tgz oci://registry.example.com/charts/mychart
+`
+
+type pushCmd struct {
+	chartPath string
+	repoName  string
+	force     bool
+	home      helmpath.Home
+	out       io.Writer
+
+	// registry is the client for pushes to OCI registries.
+	registry *registry.Client
+}
+
+func newPushCmd(out io.Writer) *cobra.Command {
+	push := &pushCmd{out: out, registry: registry.NewClient()}
+
+	cmd := &cobra.Command{
+		Use:   "push [flags] [CHART] [REPO | oci://registry/chart]",
+		Short: "upload a packaged chart to a chart repository",
+		Long:  pushDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "path to a packaged chart", "name of the chart repository"); err != nil {
+				return err
+			}
+			push.chartPath = args[0]
+			push.repoName = args[1]
+			push.home = helmpath.Home(homePath())
+			return push.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&push.force, "force", false, "replace the chart version if the repository already holds it")
+
+	return cmd
+}
+
+func (p *pushCmd) run() error {
+	if registry.IsReference(p.repoName) {
+		return p.pushToRegistry()
+	}
+
+	rf, err := repo.LoadRepositoriesFile(p.home.RepositoryFile())
+	if err != nil {
+		return err
+	}
+
+	var entry *repo.Entry
+	for _, e := range rf.Repositories {
+		if e.Name == p.repoName {
+			entry = e
+			break
+		}
+	}
+	if entry == nil {
+		return fmt.Errorf("no repo named %q found", p.repoName)
+	}
+
+	r, err := repo.NewChartRepository(entry)
+	if err != nil {
+		return err
+	}
+	if err := r.UploadChart(p.chartPath, p.force); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.out, "%s has been pushed to %q\n", filepath.Base(p.chartPath), p.repoName)
+	return nil
+}
+
+// pushToRegistry pushes the chart to the OCI registry that repoName refers to.
+func (p *pushCmd) pushToRegistry() error {
+	ref, err := registry.ParseReference(p.repoName)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(p.chartPath)
+	if err != nil {
+		return err
+	}
+	pushed, err := p.registry.Push(ref, data)
+	if err != nil {
+		return err
+	}
+
+	digest := pushed.Digest
+	pushed.Digest = ""
+	fmt.Fprintf(p.out, "%s has been pushed to %s\nDigest: %s\n", filepath.Base(p.chartPath), pushed, digest)
+	return nil
+}
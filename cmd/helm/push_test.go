@@ -0,0 +1,109 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/repo"
+)
+
+func TestPushCmd(t *testing.T) {
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldhome := helmHome
+	helmHome = thome
+	defer func() {
+		helmHome = oldhome
+		os.RemoveAll(thome)
+	}()
+	hh := helmpath.Home(thome)
+
+	repoDir, err := ioutil.TempDir("", "helm-push")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repoDir)
+
+	rs := &repo.RepositoryServer{RepoPath: repoDir, Username: "admin", Password: "s3cr3t"}
+	srv := httptest.NewServer(rs)
+	defer srv.Close()
+	rs.URL = srv.URL
+
+	rf, err := repo.LoadRepositoriesFile(hh.RepositoryFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rf.Add(&repo.Entry{Name: "pushed", URL: srv.URL, Cache: "pushed-index.yaml", Username: "admin", Password: "s3cr3t"})
+	if err := rf.WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "push a chart",
+			args:     []string{"testdata/testcharts/compressedchart-0.1.0.tgz", "pushed"},
+			expected: `compressedchart-0.1.0.tgz has been pushed to "pushed"`,
+		},
+		{
+			name: "push an existing chart version",
+			args: []string{"testdata/testcharts/compressedchart-0.1.0.tgz", "pushed"},
+			err:  true,
+		},
+		{
+			name: "push to an unknown repository",
+			args: []string{"testdata/testcharts/compressedchart-0.1.0.tgz", "nope"},
+			err:  true,
+		},
+		{
+			name: "push without a repository",
+			args: []string{"testdata/testcharts/compressedchart-0.1.0.tgz"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		buf := bytes.NewBuffer(nil)
+		c := newPushCmd(buf)
+		err := c.RunE(c, tt.args)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.name, tt.err, err)
+			continue
+		}
+		if !strings.Contains(buf.String(), tt.expected) {
+			t.Errorf("%q: expected %q, got %q", tt.name, tt.expected, buf.String())
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(repoDir, "compressedchart-0.1.0.tgz")); err != nil {
+		t.Errorf("Expected the chart to be in the repository: %s", err)
+	}
+}
@@ -30,14 +30,21 @@ The test command runs the tests for a release.
 
 The argument this command takes is the name of a deployed release.
 The tests to be run are defined in the chart that was installed.
+
+The tests run one after the other unless '--parallel' is set, in which case
+up to '--max-parallel' test pods run at once. With '--logs', the logs of each
+test pod are printed once it completes.
 `
 
 type releaseTestCmd struct {
-	name    string
-	out     io.Writer
-	client  helm.Interface
-	timeout int64
-	cleanup bool
+	name        string
+	out         io.Writer
+	client      helm.Interface
+	timeout     int64
+	cleanup     bool
+	parallel    bool
+	maxParallel uint32
+	logs        bool
 }
 
 func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -65,6 +72,9 @@ func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.Int64Var(&rlsTest.timeout, "timeout", 300, "time in seconds to wait for any individual kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&rlsTest.cleanup, "cleanup", false, "delete test pods upon completion")
+	f.BoolVar(&rlsTest.parallel, "parallel", false, "run test pods in parallel")
+	f.BoolVar(&rlsTest.logs, "logs", false, "print the logs of the test pods once they complete")
+	f.Uint32Var(&rlsTest.maxParallel, "max-parallel", 20, "maximum number of test pods to run in parallel, or 0 for no limit")
 
 	return cmd
 }
@@ -74,6 +84,9 @@ func (t *releaseTestCmd) run() (err error) {
 		t.name,
 		helm.ReleaseTestTimeout(t.timeout),
 		helm.ReleaseTestCleanup(t.cleanup),
+		helm.ReleaseTestParallel(t.parallel),
+		helm.ReleaseTestMaxParallel(t.maxParallel),
+		helm.ReleaseTestLogs(t.logs),
 	)
 
 	for {
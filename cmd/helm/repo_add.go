@@ -32,6 +32,9 @@ type repoAddCmd struct {
 	home     helmpath.Home
 	noupdate bool
 
+	username string
+	password string
+
 	certFile string
 	keyFile  string
 	caFile   string
@@ -65,19 +68,21 @@ func newRepoAddCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&add.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&add.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&add.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
+	f.StringVar(&add.username, "username", "", "chart repository username")
+	f.StringVar(&add.password, "password", "", "chart repository password")
 
 	return cmd
 }
 
 func (a *repoAddCmd) run() error {
-	if err := addRepository(a.name, a.url, a.home, a.certFile, a.keyFile, a.caFile, a.noupdate); err != nil {
+	if err := addRepository(a.name, a.url, a.username, a.password, a.home, a.certFile, a.keyFile, a.caFile, a.noupdate); err != nil {
 		return err
 	}
 	fmt.Fprintf(a.out, "%q has been added to your repositories\n", a.name)
 	return nil
 }
 
-func addRepository(name, url string, home helmpath.Home, certFile, keyFile, caFile string, noUpdate bool) error {
+func addRepository(name, url, username, password string, home helmpath.Home, certFile, keyFile, caFile string, noUpdate bool) error {
 	f, err := repo.LoadRepositoriesFile(home.RepositoryFile())
 	if err != nil {
 		return err
@@ -95,12 +100,17 @@ func addRepository(name, url string, home helmpath.Home, certFile, keyFile, caFi
 		CertFile: certFile,
 		KeyFile:  keyFile,
 		CAFile:   caFile,
+		Username: username,
+		Password: password,
 	}
 
 	r, err := repo.NewChartRepository(&c)
 	if err != nil {
 		return err
 	}
+	if r.Plugins, err = downloaderPlugins(home); err != nil {
+		return err
+	}
 
 	if err := r.DownloadIndexFile(home.Cache()); err != nil {
 		return fmt.Errorf("Looks like %q is not a valid chart repository or cannot be reached: %s", url, err.Error())
@@ -33,8 +33,10 @@ This tool is used for creating an 'index.yaml' file for a chart repository. To
 set an absolute URL to the charts, use '--url' flag.
 
 To merge the generated index with an existing index file, use the '--merge'
-flag. In this case, the charts found in the current directory will be merged
-into the existing index, with local charts taking priority over existing charts.
+flag. In this case, only the chart versions found in the current directory that
+are not in the existing index yet are added to it. Chart versions that are
+already indexed keep their entry, including its creation time and digest, so
+that the index can be updated incrementally.
 `
 
 type repoIndexCmd struct {
@@ -88,11 +90,13 @@ func index(dir, url, mergeTo string) error {
 		return err
 	}
 	if mergeTo != "" {
-		i2, err := repo.LoadIndexFile(mergeTo)
+		existing, err := repo.LoadIndexFile(mergeTo)
 		if err != nil {
 			return fmt.Errorf("Merge failed: %s", err)
 		}
-		i.Merge(i2)
+		existing.Merge(i)
+		existing.Generated = i.Generated
+		i = existing
 	}
 	i.SortEntries()
 	return i.WriteFile(out, 0755)
@@ -72,6 +72,8 @@ func TestRepoIndexCmd(t *testing.T) {
 		t.Errorf("expected %q, got %q", expectedVersion, vs[0].Version)
 	}
 
+	created := vs[1].Created
+
 	// Test with `--merge`
 
 	// Remove first two charts.
@@ -112,6 +114,25 @@ func TestRepoIndexCmd(t *testing.T) {
 	if vs[0].Version != expectedVersion {
 		t.Errorf("expected %q, got %q", expectedVersion, vs[0].Version)
 	}
+
+	// Re-index an existing chart version; its entry must be kept as is.
+	if err := linkOrCopy("testdata/testcharts/compressedchart-0.1.0.tgz", comp); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RunE(c, []string{dir}); err != nil {
+		t.Error(err)
+	}
+	index, err = repo.LoadIndexFile(destIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cv, err := index.Get("compressedchart", "0.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cv.Created.Equal(created) {
+		t.Errorf("expected created time %s to be kept, got %s", created, cv.Created)
+	}
 }
 
 func linkOrCopy(old, new string) error {
@@ -73,12 +73,17 @@ func (u *repoUpdateCmd) run() error {
 	if len(f.Repositories) == 0 {
 		return errNoRepositories
 	}
+	plugins, err := downloaderPlugins(u.home)
+	if err != nil {
+		return err
+	}
 	var repos []*repo.ChartRepository
 	for _, cfg := range f.Repositories {
 		r, err := repo.NewChartRepository(cfg)
 		if err != nil {
 			return err
 		}
+		r.Plugins = plugins
 		repos = append(repos, r)
 	}
 
@@ -36,6 +36,11 @@ const resetDesc = `
 This command uninstalls Tiller (the helm server side component) from your
 Kubernetes Cluster and optionally deletes local configuration in
 $HELM_HOME (default ~/.helm/)
+
+Only the Tiller in the namespace given by '--tiller-namespace' is uninstalled.
+The release history it stored is kept, so that a Tiller installed later
+manages the same releases, unless '--force' is given. '--force' also
+uninstalls Tiller while releases are still deployed.
 `
 
 type resetCmd struct {
@@ -74,7 +79,7 @@ func newResetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	f := cmd.Flags()
-	f.BoolVarP(&d.force, "force", "f", false, "forces Tiller uninstall even if there are releases installed")
+	f.BoolVarP(&d.force, "force", "f", false, "forces Tiller uninstall even if there are releases installed, and deletes the release history")
 	f.BoolVar(&d.removeHelmHome, "remove-helm-home", false, "if set deletes $HELM_HOME")
 
 	return cmd
@@ -104,7 +109,7 @@ func (d *resetCmd) run() error {
 		return fmt.Errorf("There are still %d deployed releases (Tip: use --force).", len(res.Releases))
 	}
 
-	if err := installer.Uninstall(d.kubeClient, d.kubeCmd, &installer.Options{Namespace: d.namespace}); err != nil {
+	if err := installer.Uninstall(d.kubeClient, d.kubeCmd, &installer.Options{Namespace: d.namespace, PurgeReleases: d.force}); err != nil {
 		return fmt.Errorf("error unstalling Tiller: %s", err)
 	}
 
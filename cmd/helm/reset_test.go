@@ -51,8 +51,8 @@ func TestResetCmd(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 	actions := fc.Actions()
-	if len(actions) != 2 {
-		t.Errorf("Expected 2 actions, got %d", len(actions))
+	if len(actions) != 4 {
+		t.Fatalf("Expected 4 actions, got %d", len(actions))
 	}
 	if !actions[0].Matches("get", "services") {
 		t.Errorf("unexpected action: %v, expected get service", actions[1])
@@ -60,6 +60,12 @@ func TestResetCmd(t *testing.T) {
 	if !actions[1].Matches("get", "deployments") {
 		t.Errorf("unexpected action: %v, expected get deployment", actions[0])
 	}
+	if !actions[2].Matches("delete", "secrets") {
+		t.Errorf("unexpected action: %v, expected delete secret", actions[2])
+	}
+	if !actions[3].Matches("delete", "endpoints") {
+		t.Errorf("unexpected action: %v, expected delete endpoints", actions[3])
+	}
 	expected := "Tiller (the helm server side component) has been uninstalled from your Kubernetes Cluster."
 	if !strings.Contains(buf.String(), expected) {
 		t.Errorf("expected %q, got %q", expected, buf.String())
@@ -91,8 +97,8 @@ func TestResetCmd_removeHelmHome(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 	actions := fc.Actions()
-	if len(actions) != 2 {
-		t.Errorf("Expected 2 actions, got %d", len(actions))
+	if len(actions) != 4 {
+		t.Fatalf("Expected 4 actions, got %d", len(actions))
 	}
 	if !actions[0].Matches("get", "services") {
 		t.Errorf("unexpected action: %v, expected get service", actions[1])
@@ -100,6 +106,12 @@ func TestResetCmd_removeHelmHome(t *testing.T) {
 	if !actions[1].Matches("get", "deployments") {
 		t.Errorf("unexpected action: %v, expected get deployment", actions[0])
 	}
+	if !actions[2].Matches("delete", "secrets") {
+		t.Errorf("unexpected action: %v, expected delete secret", actions[2])
+	}
+	if !actions[3].Matches("delete", "endpoints") {
+		t.Errorf("unexpected action: %v, expected delete endpoints", actions[3])
+	}
 	expected := "Tiller (the helm server side component) has been uninstalled from your Kubernetes Cluster."
 	if !strings.Contains(buf.String(), expected) {
 		t.Errorf("expected %q, got %q", expected, buf.String())
@@ -168,8 +180,8 @@ func TestReset_forceFlag(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 	actions := fc.Actions()
-	if len(actions) != 2 {
-		t.Errorf("Expected 2 actions, got %d", len(actions))
+	if len(actions) != 6 {
+		t.Fatalf("Expected 6 actions, got %d", len(actions))
 	}
 	if !actions[0].Matches("get", "services") {
 		t.Errorf("unexpected action: %v, expected get service", actions[1])
@@ -177,6 +189,15 @@ func TestReset_forceFlag(t *testing.T) {
 	if !actions[1].Matches("get", "deployments") {
 		t.Errorf("unexpected action: %v, expected get deployment", actions[0])
 	}
+	if !actions[2].Matches("delete", "secrets") {
+		t.Errorf("unexpected action: %v, expected delete secret", actions[2])
+	}
+	if !actions[3].Matches("delete", "endpoints") {
+		t.Errorf("unexpected action: %v, expected delete endpoints", actions[3])
+	}
+	if !actions[4].Matches("list", "configmaps") || !actions[5].Matches("list", "secrets") {
+		t.Errorf("unexpected actions: %v, expected the release history to be listed", actions[4:])
+	}
 	expected := "Tiller (the helm server side component) has been uninstalled from your Kubernetes Cluster."
 	if !strings.Contains(buf.String(), expected) {
 		t.Errorf("expected %q, got %q", expected, buf.String())
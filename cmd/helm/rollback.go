@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -30,8 +31,15 @@ const rollbackDesc = `
 This command rolls back a release to a previous revision.
 
 The first argument of the rollback command is the name of a release, and the
-second is a revision (version) number. To see revision numbers, run 
-'helm history RELEASE'.
+second is a revision (version) number. To see revision numbers, run
+'helm history RELEASE'. If the revision is omitted or 0, the release is rolled
+back to the most recent earlier revision that was deployed successfully,
+skipping failed ones.
+
+The '--wait' flag waits until the resources of the restored revision are ready,
+like 'helm install --wait'. Resources that cannot be patched back to their
+earlier state, for example because an immutable field changed, make the
+rollback fail. Use '--force' to delete and recreate such resources instead.
 `
 
 type rollbackCmd struct {
@@ -44,6 +52,7 @@ type rollbackCmd struct {
 	client       helm.Interface
 	timeout      int64
 	wait         bool
+	force        bool
 }
 
 func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -53,23 +62,25 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:               "rollback [flags] [RELEASE] [REVISION]",
+		Use:               "rollback [flags] RELEASE [REVISION]",
 		Short:             "roll back a release to a previous revision",
 		Long:              rollbackDesc,
 		PersistentPreRunE: setupConnection,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
-				return err
+			if len(args) == 0 || len(args) > 2 {
+				return errors.New("This command needs 1 or 2 arguments: release name, revision number (optional)")
 			}
 
 			rollback.name = args[0]
 
-			v64, err := strconv.ParseInt(args[1], 10, 32)
-			if err != nil {
-				return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+			if len(args) > 1 {
+				v64, err := strconv.ParseInt(args[1], 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+				}
+				rollback.revision = int32(v64)
 			}
 
-			rollback.revision = int32(v64)
 			rollback.client = ensureHelmClient(rollback.client)
 			return rollback.run()
 		},
@@ -77,10 +88,11 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.BoolVar(&rollback.dryRun, "dry-run", false, "simulate a rollback")
+	f.BoolVar(&rollback.force, "force", false, "force resource update through delete/recreate if needed")
 	f.BoolVar(&rollback.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
 	f.BoolVar(&rollback.disableHooks, "no-hooks", false, "prevent hooks from running during rollback")
 	f.Int64Var(&rollback.timeout, "timeout", 300, "time in seconds to wait for any individual kubernetes operation (like Jobs for hooks)")
-	f.BoolVar(&rollback.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&rollback.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state, Jobs have completed and CustomResourceDefinitions are established before marking the release as successful. It will wait for as long as --timeout")
 
 	return cmd
 }
@@ -90,6 +102,7 @@ func (r *rollbackCmd) run() error {
 		r.name,
 		helm.RollbackDryRun(r.dryRun),
 		helm.RollbackRecreate(r.recreate),
+		helm.RollbackForce(r.force),
 		helm.RollbackDisableHooks(r.disableHooks),
 		helm.RollbackVersion(r.revision),
 		helm.RollbackTimeout(r.timeout),
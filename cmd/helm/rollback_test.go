@@ -44,8 +44,28 @@ func TestRollbackCmd(t *testing.T) {
 			expected: "Rollback was a success! Happy Helming!",
 		},
 		{
-			name: "rollback a release without revision",
-			args: []string{"funny-honey"},
+			name:     "rollback a release with force",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--force"},
+			expected: "Rollback was a success! Happy Helming!",
+		},
+		{
+			name:     "rollback a release to the previous revision",
+			args:     []string{"funny-honey", "0"},
+			expected: "Rollback was a success! Happy Helming!",
+		},
+		{
+			name:     "rollback a release without revision",
+			args:     []string{"funny-honey"},
+			expected: "Rollback was a success! Happy Helming!",
+		},
+		{
+			name: "rollback a release with an invalid revision",
+			args: []string{"funny-honey", "latest"},
+			err:  true,
+		},
+		{
+			name: "rollback without a release",
 			err:  true,
 		},
 	}
@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
@@ -34,6 +36,22 @@ Search reads through all of the repositories configured on the system, and
 looks for matches.
 
 Repositories are managed with 'helm repo' commands.
+
+When '--fuzzy' is set, the keyword is matched approximately against chart
+names, so that small misspellings still find the chart. Results are sorted by
+relevance, and the score (the number of edits needed to match) is printed in
+an extra column. Lower scores are better. '--max' limits the number of fuzzy
+matches shown.
+
+Use '--version' to only show chart versions that satisfy a semantic version
+constraint. Without '--versions', the newest matching version of each chart is
+shown:
+
+	$ helm search --version '>=1.2.0 <2.0.0' mysql
+
+Use '--output json' to print the results as a JSON list with the name,
+version, app version, repository and description of each chart. Fuzzy searches
+add the score of each result.
 `
 
 // searchMaxScore suggests that any score higher than this is not considered a match.
@@ -45,6 +63,10 @@ type searchCmd struct {
 
 	versions bool
 	regexp   bool
+	fuzzy    bool
+	max      int
+	version  string
+	output   string
 }
 
 func newSearchCmd(out io.Writer) *cobra.Command {
@@ -62,37 +84,153 @@ func newSearchCmd(out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVarP(&sc.regexp, "regexp", "r", false, "use regular expressions for searching")
 	f.BoolVarP(&sc.versions, "versions", "l", false, "show the long listing, with each version of each chart on its own line")
+	f.BoolVar(&sc.fuzzy, "fuzzy", false, "use approximate matching against chart names, sorted by relevance")
+	f.IntVar(&sc.max, "max", 0, "maximum number of fuzzy matches to show")
+	f.StringVarP(&sc.version, "version", "v", "", "search using semantic versioning constraints")
+	f.StringVarP(&sc.output, "output", "o", "table", "output format. Allowed values: table, json")
 
 	return cmd
 }
 
 func (s *searchCmd) run(args []string) error {
-	index, err := s.buildIndex()
+	if s.output != "table" && s.output != "json" {
+		return fmt.Errorf("unknown output format %q", s.output)
+	}
+
+	var constraint *semver.Constraints
+	if s.version != "" {
+		c, err := semver.NewConstraint(s.version)
+		if err != nil {
+			return fmt.Errorf("invalid version constraint %q: %s", s.version, err)
+		}
+		constraint = c
+	}
+
+	index, err := s.buildIndex(constraint != nil)
 	if err != nil {
 		return err
 	}
 
 	if len(args) == 0 {
-		s.showAllCharts(index)
-		return nil
+		return s.showAllCharts(index, constraint)
 	}
 
 	q := strings.Join(args, " ")
+	if s.fuzzy {
+		res := s.applyConstraint(index.SearchFuzzy(q, fuzzyThreshold(q)), constraint)
+		search.SortScore(res)
+		return s.printResults(s.limit(res))
+	}
+
 	res, err := index.Search(q, searchMaxScore, s.regexp)
 	if err != nil {
-		return nil
+		return err
 	}
+	res = s.applyConstraint(res, constraint)
 	search.SortScore(res)
 
+	return s.printResults(res)
+}
+
+func (s *searchCmd) showAllCharts(i *search.Index, constraint *semver.Constraints) error {
+	res := s.applyConstraint(i.All(), constraint)
+	search.SortScore(res)
+	return s.printResults(res)
+}
+
+// printResults prints the results in the format selected with '--output'.
+func (s *searchCmd) printResults(res []*search.Result) error {
+	if s.output == "json" {
+		return s.writeJSON(res)
+	}
 	fmt.Fprintln(s.out, s.formatSearchResults(res))
+	return nil
+}
+
+// searchResult is the JSON representation of a search result.
+type searchResult struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Repo        string `json:"repo"`
+	Description string `json:"description"`
+	Score       *int   `json:"score,omitempty"`
+}
+
+func (s *searchCmd) writeJSON(res []*search.Result) error {
+	list := make([]*searchResult, 0, len(res))
+	for _, r := range res {
+		sr := &searchResult{
+			Name:        r.Name,
+			Version:     r.Chart.Version,
+			AppVersion:  r.Chart.AppVersion,
+			Description: r.Chart.Description,
+		}
+		if i := strings.Index(r.Name, "/"); i > 0 {
+			sr.Repo = r.Name[:i]
+		}
+		if s.fuzzy {
+			score := r.Score
+			sr.Score = &score
+		}
+		list = append(list, sr)
+	}
 
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(s.out, string(data))
 	return nil
 }
 
-func (s *searchCmd) showAllCharts(i *search.Index) {
-	res := i.All()
-	search.SortScore(res)
-	fmt.Fprintln(s.out, s.formatSearchResults(res))
+// applyConstraint drops the results whose version does not satisfy
+// constraint. Unless '--versions' is set, only the newest satisfying version
+// of each chart is kept.
+func (s *searchCmd) applyConstraint(res []*search.Result, constraint *semver.Constraints) []*search.Result {
+	if constraint == nil {
+		return res
+	}
+
+	var matched []*search.Result
+	newest := map[string]int{}
+	for _, r := range res {
+		v, err := semver.NewVersion(r.Chart.Version)
+		if err != nil || !constraint.Check(v) {
+			continue
+		}
+		if s.versions {
+			matched = append(matched, r)
+			continue
+		}
+		if j, ok := newest[r.Name]; ok {
+			if v.GreaterThan(semver.MustParse(matched[j].Chart.Version)) {
+				matched[j] = r
+			}
+			continue
+		}
+		newest[r.Name] = len(matched)
+		matched = append(matched, r)
+	}
+	return matched
+}
+
+// limit truncates fuzzy matches to the number requested with '--max'.
+func (s *searchCmd) limit(res []*search.Result) []*search.Result {
+	if s.max > 0 && len(res) > s.max {
+		return res[:s.max]
+	}
+	return res
+}
+
+// fuzzyThreshold returns the number of edits tolerated for a fuzzy search term.
+//
+// Longer terms tolerate more mistakes, but at least one is always allowed.
+func fuzzyThreshold(term string) int {
+	if t := len(term) / 3; t > 1 {
+		return t
+	}
+	return 1
 }
 
 func (s *searchCmd) formatSearchResults(res []*search.Result) string {
@@ -101,6 +239,13 @@ func (s *searchCmd) formatSearchResults(res []*search.Result) string {
 	}
 	table := uitable.New()
 	table.MaxColWidth = 50
+	if s.fuzzy {
+		table.AddRow("NAME", "VERSION", "SCORE", "DESCRIPTION")
+		for _, r := range res {
+			table.AddRow(r.Name, r.Chart.Version, r.Score, r.Chart.Description)
+		}
+		return table.String()
+	}
 	table.AddRow("NAME", "VERSION", "DESCRIPTION")
 	for _, r := range res {
 		table.AddRow(r.Name, r.Chart.Version, r.Chart.Description)
@@ -108,7 +253,9 @@ func (s *searchCmd) formatSearchResults(res []*search.Result) string {
 	return table.String()
 }
 
-func (s *searchCmd) buildIndex() (*search.Index, error) {
+// buildIndex builds the search index of all repositories. Every version of
+// each chart is indexed if '--versions' is set or allVersions is true.
+func (s *searchCmd) buildIndex(allVersions bool) (*search.Index, error) {
 	// Load the repositories.yaml
 	rf, err := repo.LoadRepositoriesFile(s.helmhome.RepositoryFile())
 	if err != nil {
@@ -125,7 +272,7 @@ func (s *searchCmd) buildIndex() (*search.Index, error) {
 			continue
 		}
 
-		i.AddRepo(n, ind, s.versions)
+		i.AddRepo(n, ind, s.versions || allVersions)
 	}
 	return i, nil
 }
@@ -176,6 +176,63 @@ func (i *Index) SearchRegexp(re string, threshold int) ([]*Result, error) {
 	return buf, nil
 }
 
+// SearchFuzzy does an approximate search against chart names.
+//
+// The score of a result is the smallest number of edits (insertions, deletions
+// or substitutions) needed to make the term appear somewhere in the chart name.
+// Results with a score greater than threshold are considered irrelevant.
+func (i *Index) SearchFuzzy(term string, threshold int) []*Result {
+	term = strings.ToLower(term)
+	buf := []*Result{}
+	for k, ch := range i.charts {
+		score := editDistance(term, strings.ToLower(ch.Name))
+		if score > threshold {
+			continue
+		}
+		parts := strings.Split(k, verSep) // Remove version, if it is there.
+		buf = append(buf, &Result{Name: parts[0], Score: score, Chart: ch})
+	}
+	return buf
+}
+
+// editDistance returns the minimum edit distance between term and any
+// substring of text.
+func editDistance(term, text string) int {
+	t, s := []rune(term), []rune(text)
+
+	// Row 0 is all zeros, since a match may start anywhere in text.
+	prev := make([]int, len(s)+1)
+	cur := make([]int, len(s)+1)
+	for i := 1; i <= len(t); i++ {
+		cur[0] = i
+		for j := 1; j <= len(s); j++ {
+			cost := 1
+			if t[i-1] == s[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j-1]+cost, prev[j]+1, cur[j-1]+1)
+		}
+		prev, cur = cur, prev
+	}
+
+	best := prev[0]
+	for _, d := range prev[1:] {
+		if d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func min(a int, rest ...int) int {
+	for _, b := range rest {
+		if b < a {
+			a = b
+		}
+	}
+	return a
+}
+
 // Chart returns the ChartVersion for a particular name.
 func (i *Index) Chart(name string) (*repo.ChartVersion, error) {
 	c, ok := i.charts[name]
@@ -291,3 +291,84 @@ func TestCalcScore(t *testing.T) {
 		t.Errorf("Expected 3, got %d", r)
 	}
 }
+
+func TestSearchFuzzy(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		threshold int
+		expect    []*Result
+	}{
+		{
+			name:      "exact name",
+			query:     "pinta",
+			threshold: 1,
+			expect: []*Result{
+				{Name: "testing/pinta", Score: 0},
+				{Name: "ztesting/pinta", Score: 0},
+			},
+		},
+		{
+			name:      "one wrong letter",
+			query:     "pimta",
+			threshold: 1,
+			expect: []*Result{
+				{Name: "testing/pinta", Score: 1},
+				{Name: "ztesting/pinta", Score: 1},
+			},
+		},
+		{
+			name:      "misspelled partial name",
+			query:     "santa-mraia",
+			threshold: 2,
+			expect: []*Result{
+				{Name: "testing/santa-maria", Score: 2},
+			},
+		},
+		{
+			name:      "too far off",
+			query:     "mayflower",
+			threshold: 2,
+			expect:    []*Result{},
+		},
+	}
+
+	i := loadTestIndex(t, false)
+	for _, tt := range tests {
+		charts := i.SearchFuzzy(tt.query, tt.threshold)
+		SortScore(charts)
+
+		if len(charts) != len(tt.expect) {
+			t.Fatalf("%s: Expected %d result, got %d", tt.name, len(tt.expect), len(charts))
+		}
+		for i, got := range charts {
+			ex := tt.expect[i]
+			if got.Name != ex.Name {
+				t.Errorf("%s[%d]: Expected name %q, got %q", tt.name, i, ex.Name, got.Name)
+			}
+			if got.Score != ex.Score {
+				t.Errorf("%s[%d]: Expected score %d, got %d", tt.name, i, ex.Score, got.Score)
+			}
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		term, text string
+		expect     int
+	}{
+		{"", "alpine", 0},
+		{"alpine", "alpine", 0},
+		{"alp", "alpine", 0},
+		{"pine", "alpine", 0},
+		{"alpnie", "alpine", 2},
+		{"alpinex", "alpine", 1},
+		{"xyz", "alpine", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.term, tt.text); got != tt.expect {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", tt.term, tt.text, tt.expect, got)
+		}
+	}
+}
@@ -47,6 +47,66 @@ func TestSearchCmd(t *testing.T) {
 			flags:  []string{"--versions"},
 			expect: "NAME          \tVERSION\tDESCRIPTION                    \ntesting/alpine\t0.2.0  \tDeploy a basic Alpine Linux pod\ntesting/alpine\t0.1.0  \tDeploy a basic Alpine Linux pod",
 		},
+		{
+			name:   "fuzzy search for 'alpne' with versions and max, expect one match",
+			args:   []string{"alpne"},
+			flags:  []string{"--fuzzy", "--versions", "--max", "1"},
+			expect: "NAME          \tVERSION\tSCORE\tDESCRIPTION                    \ntesting/alpine\t0.2.0  \t1    \tDeploy a basic Alpine Linux pod",
+		},
+		{
+			name:   "search for 'alpine' with version constraint, expect one match",
+			args:   []string{"alpine"},
+			flags:  []string{"--version", "<0.2.0"},
+			expect: "NAME          \tVERSION\tDESCRIPTION                    \ntesting/alpine\t0.1.0  \tDeploy a basic Alpine Linux pod",
+		},
+		{
+			name:   "search for 'alpine' with version constraint and versions, expect two matches",
+			args:   []string{"alpine"},
+			flags:  []string{"--versions", "--version", ">=0.1.0"},
+			expect: "NAME          \tVERSION\tDESCRIPTION                    \ntesting/alpine\t0.2.0  \tDeploy a basic Alpine Linux pod\ntesting/alpine\t0.1.0  \tDeploy a basic Alpine Linux pod",
+		},
+		{
+			name:   "search for 'alpine' with unsatisfiable version constraint, expect no matches",
+			args:   []string{"alpine"},
+			flags:  []string{"--version", ">=1.0.0"},
+			expect: "No results found",
+		},
+		{
+			name:  "search with invalid version constraint, expect failure",
+			args:  []string{"alpine"},
+			flags: []string{"--version", "not-a-version"},
+			fail:  true,
+		},
+		{
+			name:   "fuzzy search for 'alpne', expect one match with score",
+			args:   []string{"alpne"},
+			flags:  []string{"--fuzzy"},
+			expect: "NAME          \tVERSION\tSCORE\tDESCRIPTION                    \ntesting/alpine\t0.2.0  \t1    \tDeploy a basic Alpine Linux pod",
+		},
+		{
+			name:   "search for 'maria' with json output",
+			args:   []string{"maria"},
+			flags:  []string{"--output", "json"},
+			expect: "[\n  {\n    \"name\": \"testing/mariadb\",\n    \"version\": \"0.3.0\",\n    \"appVersion\": \"\",\n    \"repo\": \"testing\",\n    \"description\": \"Chart for MariaDB\"\n  }\n]",
+		},
+		{
+			name:   "fuzzy search for 'alpne' with json output, expect score",
+			args:   []string{"alpne"},
+			flags:  []string{"--fuzzy", "--output", "json"},
+			expect: "[\n  {\n    \"name\": \"testing/alpine\",\n    \"version\": \"0.2.0\",\n    \"appVersion\": \"\",\n    \"repo\": \"testing\",\n    \"description\": \"Deploy a basic Alpine Linux pod\",\n    \"score\": 1\n  }\n]",
+		},
+		{
+			name:   "search for 'syzygy' with json output, expect empty list",
+			args:   []string{"syzygy"},
+			flags:  []string{"--output", "json"},
+			expect: "[]",
+		},
+		{
+			name:  "search with unknown output format, expect failure",
+			args:  []string{"alpine"},
+			flags: []string{"--output", "xml"},
+			fail:  true,
+		},
 		{
 			name:   "search for 'syzygy', expect no matches",
 			args:   []string{"syzygy"},
@@ -82,6 +142,10 @@ func TestSearchCmd(t *testing.T) {
 			}
 			t.Fatalf("%s: unexpected error %s", tt.name, err)
 		}
+		if tt.fail {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
 		got := strings.TrimSpace(buf.String())
 		if got != tt.expect {
 			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, got)
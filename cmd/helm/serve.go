@@ -19,8 +19,10 @@ package main
 import (
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -34,6 +36,19 @@ This command starts a local chart repository server that serves charts from a lo
 The new server will provide HTTP access to a repository. By default, it will
 scan all of the charts in '$HELM_HOME/repository/local' and serve those over
 the local IPv4 TCP port (default '127.0.0.1:8879').
+
+To serve the repository over HTTPS, pass a certificate and its key with
+'--tls-cert' and '--tls-key'.
+
+To only let authenticated clients access the repository, set the credentials
+that clients must present with HTTP basic authentication with '--auth'. The
+repository can then be added with 'helm repo add --username --password':
+
+	$ helm serve --auth admin:s3cr3t
+
+Packaged charts can be added to the repository with 'helm push', which uploads
+them to the '/api/charts' endpoint of the server. The index is updated right
+away. Uploads are only accepted when the server is started with '--auth'.
 `
 
 type serveCmd struct {
@@ -41,6 +56,9 @@ type serveCmd struct {
 	url      string
 	address  string
 	repoPath string
+	certFile string
+	keyFile  string
+	auth     string
 }
 
 func newServeCmd(out io.Writer) *cobra.Command {
@@ -58,11 +76,28 @@ func newServeCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&srv.repoPath, "repo-path", helmpath.Home(homePath()).LocalRepository(), "local directory path from which to serve charts")
 	f.StringVar(&srv.address, "address", "127.0.0.1:8879", "address to listen on")
 	f.StringVar(&srv.url, "url", "", "external URL of chart repository")
+	f.StringVar(&srv.certFile, "tls-cert", "", "path to TLS certificate file, to serve over HTTPS")
+	f.StringVar(&srv.keyFile, "tls-key", "", "path to TLS private key file, to serve over HTTPS")
+	f.StringVar(&srv.auth, "auth", "", "require HTTP basic authentication with these credentials, in the form USER:PASSWORD")
 
 	return cmd
 }
 
 func (s *serveCmd) run() error {
+	if (s.certFile == "") != (s.keyFile == "") {
+		return fmt.Errorf("both --tls-cert and --tls-key are required to serve over HTTPS")
+	}
+	useTLS := s.certFile != ""
+
+	rs := &repo.RepositoryServer{}
+	if s.auth != "" {
+		creds := strings.SplitN(s.auth, ":", 2)
+		if len(creds) != 2 || creds[0] == "" {
+			return fmt.Errorf("--auth must be in the form USER:PASSWORD")
+		}
+		rs.Username, rs.Password = creds[0], creds[1]
+	}
+
 	repoPath, err := filepath.Abs(s.repoPath)
 	if err != nil {
 		return err
@@ -70,17 +105,25 @@ func (s *serveCmd) run() error {
 	if _, err := os.Stat(repoPath); os.IsNotExist(err) {
 		return err
 	}
+	rs.RepoPath = repoPath
+
+	rs.URL = s.url
+	if rs.URL == "" {
+		scheme := "http://"
+		if useTLS {
+			scheme = "https://"
+		}
+		rs.URL = scheme + s.address
+	}
 
 	fmt.Fprintln(s.out, "Regenerating index. This may take a moment.")
-	if len(s.url) > 0 {
-		err = index(repoPath, s.url, "")
-	} else {
-		err = index(repoPath, "http://"+s.address, "")
-	}
-	if err != nil {
+	if err := index(repoPath, rs.URL, ""); err != nil {
 		return err
 	}
 
 	fmt.Fprintf(s.out, "Now serving you on %s\n", s.address)
-	return repo.StartLocalRepo(repoPath, s.address)
+	if useTLS {
+		return http.ListenAndServeTLS(s.address, s.certFile, s.keyFile, rs)
+	}
+	return http.ListenAndServe(s.address, rs)
 }
@@ -17,11 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/gosuri/uitable/util/strutil"
 	"github.com/spf13/cobra"
@@ -37,10 +40,17 @@ This command shows the status of a named release.
 The status consists of:
 - last deployment time
 - k8s namespace in which the release lives
-- state of the release (can be: UNKNOWN, DEPLOYED, DELETED, SUPERSEDED, FAILED or DELETING)
-- list of resources that this release consists of, sorted by kind
+- state of the release (can be: UNKNOWN, DEPLOYED, DELETED, SUPERSEDED, FAILED, DELETING,
+  PENDING_INSTALL, PENDING_UPGRADE or PENDING_ROLLBACK)
+- list of resources that this release consists of, sorted by kind, with their
+  current state in the cluster, and the pods of workloads like Deployments
 - details on last test suite run, if applicable
 - additional notes provided by the chart
+
+Use '--output json' or '--output yaml' to get the status in a machine-readable
+format. In these formats, each resource of the release is listed with its
+API version, kind, name and namespace, and the object as it exists in the
+cluster.
 `
 
 type statusCmd struct {
@@ -48,6 +58,7 @@ type statusCmd struct {
 	out     io.Writer
 	client  helm.Interface
 	version int32
+	output  string
 }
 
 func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -67,27 +78,119 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 			}
 			status.release = args[0]
 			if status.client == nil {
-				status.client = helm.NewClient(helm.Host(tillerHost))
+				status.client = helm.NewClient(helm.Host(tillerHost), helm.ConnectTimeout(tillerConnectionTimeout))
 			}
 			return status.run()
 		},
 	}
 
 	cmd.PersistentFlags().Int32Var(&status.version, "revision", 0, "if set, display the status of the named release with revision")
+	cmd.PersistentFlags().StringVarP(&status.output, "output", "o", "", "output the status in the specified format (json or yaml)")
 
 	return cmd
 }
 
 func (s *statusCmd) run() error {
+	if s.output != "" && s.output != "json" && s.output != "yaml" {
+		return fmt.Errorf("unknown output format %q", s.output)
+	}
+
 	res, err := s.client.ReleaseStatus(s.release, helm.StatusReleaseVersion(s.version))
 	if err != nil {
 		return prettyError(err)
 	}
 
-	PrintStatus(s.out, res)
+	if s.output == "" {
+		PrintStatus(s.out, res)
+		return nil
+	}
+
+	var data []byte
+	if s.output == "json" {
+		data, err = json.MarshalIndent(newReleaseStatus(res), "", "  ")
+	} else {
+		data, err = yaml.Marshal(newReleaseStatus(res))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(s.out, strings.TrimSpace(string(data)))
 	return nil
 }
 
+// releaseStatus is the machine-readable representation of a release status.
+type releaseStatus struct {
+	Name         string            `json:"name"`
+	Namespace    string            `json:"namespace"`
+	LastDeployed string            `json:"lastDeployed,omitempty"`
+	Status       string            `json:"status"`
+	Resources    []*statusResource `json:"resources,omitempty"`
+	TestSuite    *statusTestSuite  `json:"testSuite,omitempty"`
+	Notes        string            `json:"notes,omitempty"`
+}
+
+// statusResource is a resource of the release. Object is the resource as it
+// exists in the cluster, and is left out if it does not exist.
+type statusResource struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Name       string          `json:"name"`
+	Namespace  string          `json:"namespace,omitempty"`
+	Object     json.RawMessage `json:"object,omitempty"`
+}
+
+type statusTestSuite struct {
+	StartedAt   string           `json:"startedAt"`
+	CompletedAt string           `json:"completedAt"`
+	Results     []*statusTestRun `json:"results"`
+}
+
+type statusTestRun struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Info        string `json:"info,omitempty"`
+	StartedAt   string `json:"startedAt"`
+	CompletedAt string `json:"completedAt"`
+}
+
+func newReleaseStatus(res *services.GetReleaseStatusResponse) *releaseStatus {
+	st := &releaseStatus{
+		Name:      res.Name,
+		Namespace: res.Namespace,
+		Status:    res.Info.Status.Code.String(),
+		Notes:     res.Info.Status.Notes,
+	}
+	if res.Info.LastDeployed != nil {
+		st.LastDeployed = timeconv.String(res.Info.LastDeployed)
+	}
+	for _, r := range res.Info.Resources {
+		st.Resources = append(st.Resources, &statusResource{
+			APIVersion: r.ApiVersion,
+			Kind:       r.Kind,
+			Name:       r.Name,
+			Namespace:  r.Namespace,
+			Object:     json.RawMessage(r.Object),
+		})
+	}
+	if lastRun := res.Info.Status.LastTestSuiteRun; lastRun != nil {
+		st.TestSuite = &statusTestSuite{
+			StartedAt:   timeconv.String(lastRun.StartedAt),
+			CompletedAt: timeconv.String(lastRun.CompletedAt),
+			Results:     []*statusTestRun{},
+		}
+		for _, r := range lastRun.Results {
+			st.TestSuite.Results = append(st.TestSuite.Results, &statusTestRun{
+				Name:        r.Name,
+				Status:      r.Status.String(),
+				Info:        r.Info,
+				StartedAt:   timeconv.String(r.StartedAt),
+				CompletedAt: timeconv.String(r.CompletedAt),
+			})
+		}
+	}
+	return st
+}
+
 // PrintStatus prints out the status of a release. Shared because also used by
 // install / upgrade
 func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
@@ -104,6 +104,65 @@ func TestStatusCmd(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name:  "get status of a deployed release as json",
+			args:  []string{"flummoxed-chickadee"},
+			flags: []string{"--output", "json"},
+			expected: fmt.Sprintf(`{
+  "name": "flummoxed-chickadee",
+  "namespace": "",
+  "lastDeployed": "%s",
+  "status": "DEPLOYED",
+  "resources": [
+    {
+      "apiVersion": "v1",
+      "kind": "Pod",
+      "name": "web-0",
+      "namespace": "default",
+      "object": {
+        "kind": "Pod",
+        "status": {
+          "phase": "Running"
+        }
+      }
+    },
+    {
+      "apiVersion": "v1",
+      "kind": "Service",
+      "name": "web",
+      "namespace": "default"
+    }
+  ],
+  "notes": "release notes"
+}
+`, dateString),
+			rel: releaseMockWithResources(&release.Status{
+				Code:      release.Status_DEPLOYED,
+				Resources: "==> v1/Pod\nNAME   READY  STATUS\nweb-0  1/1    Running\n\n==> v1/Service\nNAME  CLUSTER-IP\nweb   10.0.0.1\n",
+				Notes:     "release notes",
+			}, []*release.Resource{
+				{ApiVersion: "v1", Kind: "Pod", Name: "web-0", Namespace: "default", Object: []byte(`{"kind":"Pod","status":{"phase":"Running"}}`)},
+				{ApiVersion: "v1", Kind: "Service", Name: "web", Namespace: "default"},
+			}),
+		},
+		{
+			name:     "get status of a deployed release as yaml",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--output", "yaml"},
+			expected: fmt.Sprintf("lastDeployed: %s\nname: flummoxed-chickadee\nnamespace: \"\"\nstatus: DEPLOYED\n", dateString),
+			rel: releaseMockWithStatus(&release.Status{
+				Code: release.Status_DEPLOYED,
+			}),
+		},
+		{
+			name:  "get status with an unknown output format",
+			args:  []string{"flummoxed-chickadee"},
+			flags: []string{"--output", "xml"},
+			err:   true,
+			rel: releaseMockWithStatus(&release.Status{
+				Code: release.Status_DEPLOYED,
+			}),
+		},
 	}
 
 	scmd := func(c *fakeReleaseClient, out io.Writer) *cobra.Command {
@@ -147,3 +206,9 @@ func releaseMockWithStatus(status *release.Status) *release.Release {
 		},
 	}
 }
+
+func releaseMockWithResources(status *release.Status, resources []*release.Resource) *release.Release {
+	rel := releaseMockWithStatus(status)
+	rel.Info.Resources = resources
+	return rel
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/timeconv"
+	"k8s.io/helm/pkg/version"
+)
+
+const templateDesc = `
+This command renders the templates of a chart locally and writes the resulting
+manifests to stdout. It does not need a connection to Tiller or to a Kubernetes
+cluster.
+
+Values are passed in the same way as for 'helm install': use '--values'/'-f' to
+pass a file and '--set', '--set-string' or '--set-file' to pass configuration on
+the command line. The release name and namespace that are made available to the
+templates can be set with '--name' and '--namespace':
+
+	$ helm template --name my-release --namespace prod -f myvalues.yaml ./redis
+
+Since no cluster is queried, the templates only see the default capabilities
+(the core "v1" API version).
+`
+
+type templateCmd struct {
+	chartPath    string
+	name         string
+	namespace    string
+	valueFiles   valueFiles
+	values       []string
+	stringValues []string
+	fileValues   []string
+	out          io.Writer
+}
+
+func newTemplateCmd(out io.Writer) *cobra.Command {
+	t := &templateCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "template [flags] CHART",
+		Short: "locally render templates",
+		Long:  templateDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "chart path"); err != nil {
+				return err
+			}
+			t.chartPath = args[0]
+			return t.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&t.name, "name", "n", "RELEASE-NAME", "release name")
+	f.StringVar(&t.namespace, "namespace", "default", "namespace of the release")
+	f.VarP(&t.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
+	f.StringArrayVar(&t.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+
+	return cmd
+}
+
+func (t *templateCmd) run() error {
+	c, err := chartutil.Load(t.chartPath)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	if req, err := chartutil.LoadRequirements(c); err == nil {
+		checkDependencies(c, req, t.out)
+	}
+
+	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues)
+	if err != nil {
+		return err
+	}
+	config := &chart.Config{Raw: string(rawVals)}
+
+	if err := chartutil.ProcessRequirementsEnabled(c, config); err != nil {
+		return err
+	}
+	if err := chartutil.ProcessRequirementsImportValues(c, config); err != nil {
+		return err
+	}
+
+	options := chartutil.ReleaseOptions{
+		Name:      t.name,
+		Time:      timeconv.Now(),
+		Namespace: t.namespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	caps := &chartutil.Capabilities{
+		APIVersions:   chartutil.DefaultVersionSet,
+		TillerVersion: version.GetVersionProto(),
+	}
+	renderVals, err := chartutil.ToRenderValuesCaps(c, config, options, caps)
+	if err != nil {
+		return err
+	}
+
+	files, err := engine.New().Render(c, renderVals)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(t.out, formatTemplates(files))
+	return nil
+}
+
+// formatTemplates joins the rendered files into a YAML stream, ordered by file
+// name. Partials, notes and files that rendered to nothing are left out.
+func formatTemplates(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name, content := range files {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" || strings.TrimSpace(content) == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&b, "---\n# Source: %s\n%s\n", name, files[name])
+	}
+	return b.String()
+}
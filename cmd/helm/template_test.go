@@ -0,0 +1,75 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestTemplateCmd(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		flags  []string
+		expect string
+		err    bool
+	}{
+		{
+			name: "template requires a chart",
+			err:  true,
+		},
+		{
+			name:   "template with default name",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  []string{"--set", "test.Name=baz"},
+			expect: `^---\n# Source: alpine/templates/alpine-pod.yaml\n(.|\n)*name: "RELEASE-NAME-my-alpine"(.|\n)*release: "RELEASE-NAME"`,
+		},
+		{
+			name:   "template with name and values",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  []string{"--name", "foo", "--set", "Name=bar", "-f", "testdata/testcharts/alpine/extra_values.yaml"},
+			expect: `name: "foo-bar"(.|\n)*values: extra-values`,
+		},
+		{
+			name:   "template with string values",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  []string{"--name", "foo", "--set", "Name=0777,test.Name=baz", "--set-string", "Name=0777"},
+			expect: `name: "foo-0777"`,
+		},
+		{
+			name: "template with a missing chart",
+			args: []string{"testdata/testcharts/nope"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newTemplateCmd(&buf)
+		cmd.ParseFlags(tt.flags)
+		err := cmd.RunE(cmd, tt.args)
+		if (err != nil) != tt.err {
+			t.Errorf("%q. expected error: %v, got %v", tt.name, tt.err, err)
+			continue
+		}
+		if !regexp.MustCompile(tt.expect).MatchString(buf.String()) {
+			t.Errorf("%q. expected\n%q\ngot\n%q", tt.name, tt.expect, buf.String())
+		}
+	}
+}
@@ -19,16 +19,13 @@ package main
 import (
 	"fmt"
 	"io"
-	"io/ioutil"
 	"strings"
 
-	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/storage/driver"
-	"k8s.io/helm/pkg/strvals"
 )
 
 const upgradeDesc = `
@@ -48,32 +45,72 @@ contained a key called 'Test', the value set in override.yaml would take precede
 
 	$ helm upgrade -f myvalues.yaml -f override.yaml redis ./redis
 
+Values files can also be given as URLs, which are downloaded in the same way as
+charts.
+
 You can specify the '--set' flag multiple times. The priority will be given to the
 last (right-most) set specified. For example, if both 'bar' and 'newbar' values are
 set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm upgrade --set foo=bar --set foo=newbar redis ./redis
+
+Use '--set-string' instead of '--set' for values that have to stay strings,
+like 'true' or '0777', and '--set-file' to set a value to the contents of a file.
+
+To pick up new default values from an upgraded chart while keeping the values
+you supplied to earlier releases, use '--reset-then-reuse-values'. The values are
+then merged in the following order, from lowest to highest priority:
+
+	1. the values.yaml of the new chart
+	2. the values supplied to the last release ('--values' and '--set')
+	3. the values supplied to this upgrade ('--values' and '--set')
+
+'--reset-values' takes precedence over '--reset-then-reuse-values', which in turn
+takes precedence over '--reuse-values'.
+
+With '--atomic', the upgrade is only successful once all resources are ready,
+as with '--wait'. If the upgrade fails or times out, the release is rolled back
+to the revision that was deployed before. Combined with '--install', a release
+that fails to install is deleted again.
+
+Resources that cannot be patched, for example because an immutable field
+changed, make the upgrade fail. Use '--force' to delete and recreate such
+resources instead.
+
+When an upgrade fails, the resources it created are left in the cluster. With
+'--cleanup-on-fail', they are deleted again.
+
+Use '--render-subchart-notes' to render the NOTES.txt of subcharts along with
+the notes of the chart.
 `
 
 type upgradeCmd struct {
-	release      string
-	chart        string
-	out          io.Writer
-	client       helm.Interface
-	dryRun       bool
-	recreate     bool
-	disableHooks bool
-	valueFiles   valueFiles
-	values       []string
-	verify       bool
-	keyring      string
-	install      bool
-	namespace    string
-	version      string
-	timeout      int64
-	resetValues  bool
-	reuseValues  bool
-	wait         bool
+	release              string
+	chart                string
+	out                  io.Writer
+	client               helm.Interface
+	dryRun               bool
+	recreate             bool
+	force                bool
+	cleanupOnFail        bool
+	disableHooks         bool
+	valueFiles           valueFiles
+	values               []string
+	stringValues         []string
+	fileValues           []string
+	verify               bool
+	keyring              string
+	install              bool
+	namespace            string
+	version              string
+	timeout              int64
+	resetValues          bool
+	reuseValues          bool
+	resetThenReuseValues bool
+	wait                 bool
+	waitConds            []string
+	atomic               bool
+	subNotes             bool
 }
 
 func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -105,7 +142,11 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.VarP(&upgrade.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
 	f.BoolVar(&upgrade.dryRun, "dry-run", false, "simulate an upgrade")
 	f.BoolVar(&upgrade.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
+	f.BoolVar(&upgrade.force, "force", false, "force resource update through delete/recreate if needed")
+	f.BoolVar(&upgrade.cleanupOnFail, "cleanup-on-fail", false, "allow deletion of new resources created in this upgrade when upgrade fails")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.BoolVar(&upgrade.disableHooks, "disable-hooks", false, "disable pre/post upgrade hooks. DEPRECATED. Use no-hooks")
 	f.BoolVar(&upgrade.disableHooks, "no-hooks", false, "disable pre/post upgrade hooks")
 	f.BoolVar(&upgrade.verify, "verify", false, "verify the provenance of the chart before upgrading")
@@ -116,7 +157,11 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.Int64Var(&upgrade.timeout, "timeout", 300, "time in seconds to wait for any individual kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&upgrade.resetValues, "reset-values", false, "when upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&upgrade.reuseValues, "reuse-values", false, "when upgrading, reuse the last release's values, and merge in any new values. If '--reset-values' is specified, this is ignored.")
-	f.BoolVar(&upgrade.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&upgrade.resetThenReuseValues, "reset-then-reuse-values", false, "when upgrading, reset the values to the ones built into the chart, apply the last release's values and merge in any new values. If '--reset-values' is specified, this is ignored.")
+	f.BoolVar(&upgrade.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state, Jobs have completed and CustomResourceDefinitions are established before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&upgrade.atomic, "atomic", false, "if set, the release is rolled back to its previous revision if the upgrade fails. The --wait flag is set automatically")
+	f.StringArrayVar(&upgrade.waitConds, "wait-condition", []string{}, "when waiting, treat resources of a kind as ready once the given status condition is True (can specify multiple): kind=KIND,type=TYPE")
+	f.BoolVar(&upgrade.subNotes, "render-subchart-notes", false, "render subchart notes along with the parent")
 
 	f.MarkDeprecated("disable-hooks", "use --no-hooks instead")
 
@@ -150,15 +195,25 @@ func (u *upgradeCmd) run() error {
 				disableHooks: u.disableHooks,
 				keyring:      u.keyring,
 				values:       u.values,
+				stringValues: u.stringValues,
+				fileValues:   u.fileValues,
 				namespace:    u.namespace,
 				timeout:      u.timeout,
 				wait:         u.wait,
+				waitConds:    u.waitConds,
+				atomic:       u.atomic,
+				subNotes:     u.subNotes,
 			}
 			return ic.run()
 		}
 	}
 
-	rawVals, err := u.vals()
+	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues)
+	if err != nil {
+		return err
+	}
+
+	waitConds, err := parseWaitConditions(u.waitConds)
 	if err != nil {
 		return err
 	}
@@ -176,11 +231,17 @@ func (u *upgradeCmd) run() error {
 		helm.UpdateValueOverrides(rawVals),
 		helm.UpgradeDryRun(u.dryRun),
 		helm.UpgradeRecreate(u.recreate),
+		helm.UpgradeForce(u.force),
+		helm.UpgradeCleanupOnFail(u.cleanupOnFail),
 		helm.UpgradeDisableHooks(u.disableHooks),
 		helm.UpgradeTimeout(u.timeout),
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
-		helm.UpgradeWait(u.wait))
+		helm.ResetThenReuseValues(u.resetThenReuseValues),
+		helm.UpgradeWait(u.wait),
+		helm.UpgradeWaitConditions(waitConds),
+		helm.UpgradeAtomic(u.atomic),
+		helm.UpgradeSubNotes(u.subNotes))
 	if err != nil {
 		return fmt.Errorf("UPGRADE FAILED: %v", prettyError(err))
 	}
@@ -200,31 +261,3 @@ func (u *upgradeCmd) run() error {
 
 	return nil
 }
-
-func (u *upgradeCmd) vals() ([]byte, error) {
-	base := map[string]interface{}{}
-
-	// User specified a values files via -f/--values
-	for _, filePath := range u.valueFiles {
-		currentMap := map[string]interface{}{}
-		bytes, err := ioutil.ReadFile(filePath)
-		if err != nil {
-			return []byte{}, err
-		}
-
-		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
-		}
-		// Merge with the previous map
-		base = mergeValues(base, currentMap)
-	}
-
-	// User specified a value via --set
-	for _, value := range u.values {
-		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
-		}
-	}
-
-	return yaml.Marshal(base)
-}
@@ -137,12 +137,33 @@ func TestUpgradeCmd(t *testing.T) {
 			resp:     releaseMock(&releaseOptions{name: "crazy-bunny", version: 2, chart: ch2}),
 			expected: "Release \"crazy-bunny\" has been upgraded. Happy Helming!\n",
 		},
+		{
+			name:     "upgrade a release with --force",
+			args:     []string{"crazy-bunny", chartPath},
+			flags:    []string{"--force"},
+			resp:     releaseMock(&releaseOptions{name: "crazy-bunny", version: 2, chart: ch2}),
+			expected: "Release \"crazy-bunny\" has been upgraded. Happy Helming!\n",
+		},
 		{
 			name:     "upgrade a release with missing dependencies",
 			args:     []string{"bonkers-bunny", missingDepsPath},
 			resp:     releaseMock(&releaseOptions{name: "bonkers-bunny", version: 1, chart: ch3}),
 			expected: "Warning: reqsubchart2 is in requirements.yaml but not in the charts/ directory!",
 		},
+		{
+			name:     "upgrade a release with a verified chart",
+			args:     []string{"funny-bunny", "testdata/testcharts/signtest-0.1.0.tgz"},
+			flags:    []string{"--verify", "--keyring", "testdata/helm-test-key.pub"},
+			resp:     releaseMock(&releaseOptions{name: "funny-bunny", version: 6, chart: ch2}),
+			expected: "Release \"funny-bunny\" has been upgraded. Happy Helming!\n",
+		},
+		{
+			name:  "upgrade a release with an unsigned chart and --verify",
+			args:  []string{"funny-bunny", "testdata/testcharts/compressedchart-0.1.0.tgz"},
+			flags: []string{"--verify", "--keyring", "testdata/helm-test-key.pub"},
+			resp:  releaseMock(&releaseOptions{name: "funny-bunny", version: 7, chart: ch2}),
+			err:   true,
+		},
 	}
 
 	cmd := func(c *fakeReleaseClient, out io.Writer) *cobra.Command {
@@ -16,12 +16,16 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"sort"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/downloader"
+	"k8s.io/helm/pkg/provenance"
 )
 
 const verifyDesc = `
@@ -33,11 +37,17 @@ tampered with, and was packaged by a trusted provider.
 This command can be used to verify a local chart. Several other commands provide
 '--verify' flags that run the same validation. To generate a signed package, use
 the 'helm package --sign' command.
+
+With '--output json', the result of the verification is written as a JSON
+object containing the chart file, its SHA256 digest, whether the signature
+verified, and the identities and fingerprint of the signing key. The command
+exits with an error if the verification fails, whatever the output format.
 `
 
 type verifyCmd struct {
 	keyring   string
 	chartfile string
+	output    string
 
 	out io.Writer
 }
@@ -60,11 +70,59 @@ func newVerifyCmd(out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.StringVar(&vc.keyring, "keyring", defaultKeyring(), "keyring containing public keys")
+	f.StringVarP(&vc.output, "output", "o", "", "output format. Allowed values: json")
 
 	return cmd
 }
 
 func (v *verifyCmd) run() error {
-	_, err := downloader.VerifyChart(v.chartfile, v.keyring)
+	switch v.output {
+	case "":
+		_, err := downloader.VerifyChart(v.chartfile, v.keyring)
+		return err
+	case "json":
+	default:
+		return fmt.Errorf("unknown output format %q", v.output)
+	}
+
+	ver, err := downloader.VerifyChart(v.chartfile, v.keyring)
+	enc := json.NewEncoder(v.out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if jerr := enc.Encode(newVerifyResult(v.chartfile, ver, err)); jerr != nil {
+		return jerr
+	}
 	return err
 }
+
+// verifyResult is the machine-readable result of a chart verification.
+type verifyResult struct {
+	File        string   `json:"file"`
+	SHA256      string   `json:"sha256,omitempty"`
+	Verified    bool     `json:"verified"`
+	SignedBy    []string `json:"signedBy,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+func newVerifyResult(chartfile string, ver *provenance.Verification, err error) *verifyResult {
+	res := &verifyResult{File: chartfile, Verified: err == nil}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	// The digest is computed separately so that it is reported even if the
+	// signature could not be verified.
+	if sum, err := provenance.DigestFile(chartfile); err == nil {
+		res.SHA256 = sum
+	}
+	if ver != nil && ver.SignedBy != nil {
+		for name := range ver.SignedBy.Identities {
+			res.SignedBy = append(res.SignedBy, name)
+		}
+		sort.Strings(res.SignedBy)
+		if ver.SignedBy.PrimaryKey != nil {
+			res.Fingerprint = fmt.Sprintf("%X", ver.SignedBy.PrimaryKey.Fingerprint)
+		}
+	}
+	return res
+}
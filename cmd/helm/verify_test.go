@@ -70,6 +70,13 @@ func TestVerifyCmd(t *testing.T) {
 			expect: "",
 			err:    false,
 		},
+		{
+			name:   "verify writes a JSON result",
+			args:   []string{"testdata/testcharts/signtest-0.1.0.tgz"},
+			flags:  []string{"--keyring", "testdata/helm-test-key.pub", "--output", "json"},
+			expect: signtestJSON,
+			err:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -93,3 +100,14 @@ func TestVerifyCmd(t *testing.T) {
 		}
 	}
 }
+
+const signtestJSON = `{
+  "file": "testdata/testcharts/signtest-0.1.0.tgz",
+  "sha256": "dee72947753628425b82814516bdaa37aef49f25e8820dd2a6e15a33a007823b",
+  "verified": true,
+  "signedBy": [
+    "Helm Testing (This key should only be used for testing. DO NOT TRUST.) <helm-testing@helm.sh>"
+  ],
+  "fingerprint": "5E615389B53CA37F0EE60BD3843BBF981FC18762"
+}
+`
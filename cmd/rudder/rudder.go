@@ -0,0 +1,89 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main // import "k8s.io/helm/cmd/rudder"
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/rudder"
+	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/version"
+)
+
+const globalUsage = `The Helm release module.
+
+Rudder manages the Kubernetes resources of releases for Tiller, which
+delegates them to Rudder when it is started with '--release-module'.
+
+This Rudder creates, updates and deletes the resources just like Tiller does
+itself. It is a starting point for release modules that roll out resources
+differently.
+
+By default, Rudder listens for gRPC connections on port 10001 of the loopback
+interface only, for use as a sidecar of Tiller in the same pod. Its API is
+neither encrypted nor authenticated, so do not expose it to other pods with
+'--listen'.
+`
+
+var grpcAddr = "127.0.0.1:10001"
+
+var rootCommand = &cobra.Command{
+	Use:   "rudder",
+	Short: "The Helm release module.",
+	Long:  globalUsage,
+	Run:   start,
+}
+
+func init() {
+	log.SetFlags(log.Flags() | log.Lshortfile)
+}
+
+func main() {
+	p := rootCommand.PersistentFlags()
+	p.StringVarP(&grpcAddr, "listen", "l", "127.0.0.1:10001", "address:port to listen on")
+
+	if err := rootCommand.Execute(); err != nil {
+		fmt.Fprint(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func start(c *cobra.Command, args []string) {
+	lstn, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Server died: %s\n", err)
+		os.Exit(1)
+	}
+
+	srv := grpc.NewServer()
+	module := &tiller.LocalReleaseModule{KubeClient: kube.New(nil)}
+	rudder.RegisterReleaseModuleServiceServer(srv, tiller.NewReleaseModuleServer(module))
+
+	fmt.Printf("Starting Rudder %s\n", version.GetVersion())
+	fmt.Printf("GRPC listening on %s\n", grpcAddr)
+	if err := srv.Serve(lstn); err != nil {
+		fmt.Fprintf(os.Stderr, "Server died: %s\n", err)
+		os.Exit(1)
+	}
+}
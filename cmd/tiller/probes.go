@@ -18,6 +18,8 @@ package main
 
 import (
 	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func readinessProbe(w http.ResponseWriter, r *http.Request) {
@@ -32,5 +34,6 @@ func newProbesMux() *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/readiness", readinessProbe)
 	mux.HandleFunc("/liveness", livenessProbe)
+	mux.Handle("/metrics", prometheus.Handler())
 	return mux
 }
@@ -18,15 +18,20 @@ package main // import "k8s.io/helm/cmd/tiller"
 
 import (
 	"crypto/tls"
+	"database/sql"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	// Register the postgres driver for the sql storage backend.
+	_ "github.com/lib/pq"
 	"github.com/spf13/cobra"
 
 	"google.golang.org/grpc"
@@ -51,11 +56,25 @@ const (
 	// tlsCertsEnvVar names the environment variable that points to
 	// the directory where Tiller's TLS certificates are located.
 	tlsCertsEnvVar = "TILLER_TLS_CERTS"
+	// historyMaxEnvVar names the environment variable that limits the number
+	// of revisions kept per release.
+	historyMaxEnvVar = "TILLER_HISTORY_MAX"
+	// auditLogEnvVar names the environment variable that points to the
+	// audit log. "-" means standard output.
+	auditLogEnvVar = "TILLER_AUDIT_LOG"
+	// sqlConnectionEnvVar names the environment variable that holds the
+	// connection string of the database used by the sql storage driver.
+	sqlConnectionEnvVar = "TILLER_SQL_CONNECTION_STRING"
+	// leaderElectionEnvVar names the environment variable that makes the
+	// replicas of Tiller elect a leader.
+	leaderElectionEnvVar = "TILLER_LEADER_ELECTION"
 )
 
 const (
 	storageMemory    = "memory"
 	storageConfigMap = "configmap"
+	storageSecret    = "secret"
+	storageSQL       = "sql"
 )
 
 // rootServer is the root gRPC server.
@@ -74,6 +93,11 @@ var (
 	traceAddr     = ":44136"
 	enableTracing = false
 	store         = storageConfigMap
+	maxHistory    = 0
+	sqlConnection = ""
+	auditLogPath  = ""
+	releaseModule = ""
+	leaderElect   = false
 )
 
 var (
@@ -105,8 +129,13 @@ func init() {
 func main() {
 	p := rootCommand.PersistentFlags()
 	p.StringVarP(&grpcAddr, "listen", "l", ":44134", "address:port to listen on")
-	p.StringVar(&store, "storage", storageConfigMap, "storage driver to use. One of 'configmap' or 'memory'")
+	p.StringVar(&store, "storage", storageConfigMap, "storage driver to use. One of 'configmap', 'secret', 'sql' or 'memory'")
+	p.StringVar(&sqlConnection, "sql-connection-string", os.Getenv(sqlConnectionEnvVar), "connection string of the postgres database used by the 'sql' storage driver")
 	p.BoolVar(&enableTracing, "trace", false, "enable rpc tracing")
+	p.IntVar(&maxHistory, "history-max", historyMaxFromEnv(), "maximum number of revisions kept per release (0 for no limit)")
+	p.StringVar(&releaseModule, "release-module", "", "address:port of a rudder service that manages the resources of releases. Tiller manages them itself if empty")
+	p.BoolVar(&leaderElect, "leader-election", os.Getenv(leaderElectionEnvVar) != "", "elect a leader among the replicas of tiller, which alone serves requests that change releases")
+	p.StringVar(&auditLogPath, "audit-log", os.Getenv(auditLogEnvVar), "file to append the audit log of release operations to, or '-' for stdout")
 
 	p.BoolVar(&tlsEnable, "tls", tlsEnableEnvVarDefault(), "enable TLS")
 	p.BoolVar(&tlsVerify, "tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
@@ -127,12 +156,25 @@ func start(c *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	var d driver.Driver
 	switch store {
 	case storageMemory:
-		env.Releases = storage.Init(driver.NewMemory())
+		d = driver.NewMemory()
 	case storageConfigMap:
-		env.Releases = storage.Init(driver.NewConfigMaps(clientset.Core().ConfigMaps(namespace())))
+		d = driver.NewConfigMaps(clientset.Core().ConfigMaps(namespace()))
+	case storageSecret:
+		d = driver.NewSecrets(clientset.Core().Secrets(namespace()))
+	case storageSQL:
+		if d, err = newSQLDriver(sqlConnection); err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot initialize sql storage: %s\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown storage driver %q\n", store)
+		os.Exit(1)
 	}
+	env.Releases = storage.Init(tiller.InstrumentDriver(d))
+	env.Releases.MaxHistory = maxHistory
 
 	if tlsEnable || tlsVerify {
 		opts := tlsutil.Options{CertFile: certFile, KeyFile: keyFile}
@@ -152,6 +194,39 @@ func start(c *cobra.Command, args []string) {
 		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg)))
 	}
 
+	if auditLogPath != "" {
+		w, err := openAuditLog(auditLogPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot open audit log: %s\n", err)
+			os.Exit(1)
+		}
+		tiller.EnableAuditLog(w)
+	}
+
+	svc := tiller.NewReleaseServer(env, clientset)
+	if releaseModule != "" {
+		if svc.ReleaseModule, err = tiller.NewRemoteReleaseModule(releaseModule); err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot connect to the release module: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if leaderElect {
+		identity, err := os.Hostname()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot determine the identity for leader election: %s\n", err)
+			os.Exit(1)
+		}
+		err = tiller.EnableLeaderElection(clientset, namespace(), identity, func() {
+			fmt.Fprintln(os.Stderr, "Lost the leader lease")
+			os.Exit(1)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot start leader election: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
 	rootServer = tiller.NewServer(opts...)
 
 	lstn, err := net.Listen("tcp", grpcAddr)
@@ -162,8 +237,20 @@ func start(c *cobra.Command, args []string) {
 
 	fmt.Printf("Starting Tiller %s (tls=%t)\n", version.GetVersion(), tlsEnable || tlsVerify)
 	fmt.Printf("GRPC listening on %s\n", grpcAddr)
-	fmt.Printf("Probes listening on %s\n", probeAddr)
+	fmt.Printf("Probes and metrics listening on %s\n", probeAddr)
 	fmt.Printf("Storage driver is %s\n", env.Releases.Name())
+	if maxHistory > 0 {
+		fmt.Printf("Max history per release is %d\n", maxHistory)
+	}
+	if releaseModule != "" {
+		fmt.Printf("Release module is the rudder service at %s\n", releaseModule)
+	}
+	if leaderElect {
+		fmt.Printf("Leader election is enabled with lock %s/%s\n", namespace(), tiller.LeaderLockName)
+	}
+	if auditLogPath != "" {
+		fmt.Printf("Audit log is written to %s\n", auditLogPath)
+	}
 
 	if enableTracing {
 		startTracing(traceAddr)
@@ -172,7 +259,6 @@ func start(c *cobra.Command, args []string) {
 	srvErrCh := make(chan error)
 	probeErrCh := make(chan error)
 	go func() {
-		svc := tiller.NewReleaseServer(env, clientset)
 		services.RegisterReleaseServiceServer(rootServer, svc)
 		if err := rootServer.Serve(lstn); err != nil {
 			srvErrCh <- err
@@ -232,5 +318,47 @@ func tlsDefaultsFromEnv(name string) (value string) {
 	return ""
 }
 
+func historyMaxFromEnv() int {
+	val := os.Getenv(historyMaxEnvVar)
+	if val == "" {
+		return 0
+	}
+	max, err := strconv.Atoi(val)
+	if err != nil {
+		log.Printf("Invalid %s %q, not limiting history: %s", historyMaxEnvVar, val, err)
+		return 0
+	}
+	return max
+}
+
 func tlsEnableEnvVarDefault() bool { return os.Getenv(tlsEnableEnvVar) != "" }
 func tlsVerifyEnvVarDefault() bool { return os.Getenv(tlsVerifyEnvVar) != "" }
+
+// newSQLDriver connects to the postgres database at connection and returns
+// a storage driver backed by it.
+func newSQLDriver(connection string) (driver.Driver, error) {
+	if connection == "" {
+		return nil, fmt.Errorf("--sql-connection-string or $%s is required", sqlConnectionEnvVar)
+	}
+	db, err := sql.Open("postgres", connection)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+	d, err := driver.NewSQL(db)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// openAuditLog opens the audit log at path for appending. The path "-"
+// stands for standard output.
+func openAuditLog(path string) (io.Writer, error) {
+	if path == "-" {
+		return os.Stdout, nil
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+}
@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
@@ -44,6 +45,9 @@ const (
 	NotesName = "NOTES.txt"
 	// HelpersName is the name of the example NOTES.txt file.
 	HelpersName = "_helpers.tpl"
+	// ChartNamePlaceholder is replaced with the name of the new chart in the
+	// templates and values of a starter chart.
+	ChartNamePlaceholder = "<CHARTNAME>"
 )
 
 const defaultValues = `# Default values for %s.
@@ -176,6 +180,9 @@ We truncate at 63 chars because some Kubernetes name fields are limited to this
 `
 
 // CreateFrom creates a new chart, but scaffolds it from the src chart.
+//
+// ChartNamePlaceholder is replaced with the name of the new chart in the
+// templates and values of src.
 func CreateFrom(chartfile *chart.Metadata, dest string, src string) error {
 	schart, err := Load(src)
 	if err != nil {
@@ -183,9 +190,27 @@ func CreateFrom(chartfile *chart.Metadata, dest string, src string) error {
 	}
 
 	schart.Metadata = chartfile
+
+	var updatedTemplates []*chart.Template
+	for _, template := range schart.Templates {
+		newData := transform(string(template.Data), schart.Metadata.Name)
+		updatedTemplates = append(updatedTemplates, &chart.Template{Name: template.Name, Data: newData})
+	}
+	schart.Templates = updatedTemplates
+
+	if schart.Values != nil {
+		schart.Values = &chart.Config{Raw: string(transform(schart.Values.Raw, schart.Metadata.Name))}
+	}
+
 	return SaveDir(schart, dest)
 }
 
+// transform replaces the ChartNamePlaceholder in src with the name of the
+// new chart.
+func transform(src, replacement string) []byte {
+	return []byte(strings.Replace(src, ChartNamePlaceholder, replacement, -1))
+}
+
 // Create creates a new chart in a directory.
 //
 // Inside of dir, this will create a directory based on the name of
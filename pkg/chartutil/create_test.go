@@ -126,3 +126,46 @@ func TestCreateFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateFromChartName(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	srcdir, err := Create(&chart.Metadata{Name: "starter"}, tdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := "name: {{ .Values.<CHARTNAME>.name }}"
+	if err := ioutil.WriteFile(filepath.Join(srcdir, TemplatesDir, "name.yaml"), []byte(tpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(srcdir, ValuesfileName), []byte("<CHARTNAME>:\n  name: <CHARTNAME>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(tdir, "out")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateFrom(&chart.Metadata{Name: "foo"}, dest, srcdir); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dest, "foo", TemplatesDir, "name.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "name: {{ .Values.foo.name }}"; string(b) != expect {
+		t.Errorf("Expected template %q, got %q", expect, b)
+	}
+	b, err = ioutil.ReadFile(filepath.Join(dest, "foo", ValuesfileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "foo:\n  name: foo\n"; string(b) != expect {
+		t.Errorf("Expected values %q, got %q", expect, b)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	oerrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// SchemaFileName is the name of the file in a chart that holds the JSON
+// Schema of its values.
+const SchemaFileName = "values.schema.json"
+
+// ValidateAgainstSchema checks that values conform to the values.schema.json
+// of the chart, and the values of each subchart to the schema of the
+// subchart. Charts without a schema accept any values.
+func ValidateAgainstSchema(chrt *chart.Chart, values map[string]interface{}) error {
+	var sb bytes.Buffer
+	validateSchemas(chrt, values, &sb)
+	if sb.Len() > 0 {
+		return errors.New("values don't meet the specifications of the schema(s) in the following chart(s):\n" + sb.String())
+	}
+	return nil
+}
+
+func validateSchemas(chrt *chart.Chart, values map[string]interface{}, sb *bytes.Buffer) {
+	for _, f := range chrt.Files {
+		if f.TypeUrl != SchemaFileName {
+			continue
+		}
+		if err := ValidateAgainstSingleSchema(values, f.Value); err != nil {
+			fmt.Fprintf(sb, "%s:\n%s\n", chrt.Metadata.Name, err)
+		}
+	}
+	for _, sub := range chrt.Dependencies {
+		subvals, _ := values[sub.Metadata.Name].(map[string]interface{})
+		validateSchemas(sub, subvals, sb)
+	}
+}
+
+// ValidateAgainstSingleSchema checks that values conform to the JSON Schema
+// schemaJSON. The error lists each value that does not conform by its path.
+func ValidateAgainstSingleSchema(values map[string]interface{}, schemaJSON []byte) error {
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(schemaJSON, schema); err != nil {
+		return fmt.Errorf("unable to parse %s: %s", SchemaFileName, err)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	res := validate.NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(values)
+	if !res.HasErrors() {
+		return nil
+	}
+	msgs := make([]string, 0, len(res.Errors))
+	for _, err := range res.Errors {
+		msg := err.Error()
+		if v, ok := err.(*oerrors.Validation); ok {
+			// The validator reports values as parts of a request body.
+			msg = strings.TrimPrefix(strings.Replace(msg, " in "+v.In, "", 1), ".")
+		}
+		msgs = append(msgs, "- "+msg)
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
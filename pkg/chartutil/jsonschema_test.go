@@ -0,0 +1,140 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const testSchema = `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string"},
+    "replicaCount": {"type": "integer", "minimum": 1},
+    "image": {
+      "type": "object",
+      "properties": {
+        "pullPolicy": {"type": "string", "enum": ["Always", "IfNotPresent", "Never"]}
+      }
+    }
+  }
+}`
+
+const testSubchartSchema = `{
+  "type": "object",
+  "properties": {
+    "port": {"type": "integer"}
+  }
+}`
+
+func TestValidateAgainstSingleSchema(t *testing.T) {
+	tests := []struct {
+		values string
+		expect []string
+	}{
+		{
+			values: "name: moby\nreplicaCount: 3\nimage:\n  pullPolicy: Always\n",
+		},
+		{
+			values: "replicaCount: 0\nimage:\n  pullPolicy: Sometimes\n",
+			expect: []string{
+				"- name is required",
+				"- replicaCount should be greater than or equal to 1",
+				"- image.pullPolicy should be one of [Always IfNotPresent Never]",
+			},
+		},
+		{
+			values: "name: 3\n",
+			expect: []string{"- name must be of type string"},
+		},
+	}
+
+	for _, tt := range tests {
+		vals, err := ReadValues([]byte(tt.values))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ValidateAgainstSingleSchema(vals, []byte(testSchema))
+		if len(tt.expect) == 0 {
+			if err != nil {
+				t.Errorf("%q: expected no error, got %s", tt.values, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%q: expected an error", tt.values)
+			continue
+		}
+		for _, e := range tt.expect {
+			if !strings.Contains(err.Error(), e) {
+				t.Errorf("%q: expected %q in %q", tt.values, e, err)
+			}
+		}
+	}
+
+	if err := ValidateAgainstSingleSchema(nil, []byte("{")); err == nil {
+		t.Error("Expected an error for an invalid schema")
+	}
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Files:    []*any.Any{{TypeUrl: SchemaFileName, Value: []byte(testSchema)}},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "pequod"},
+				Files:    []*any.Any{{TypeUrl: SchemaFileName, Value: []byte(testSubchartSchema)}},
+			},
+			{
+				Metadata: &chart.Metadata{Name: "spouter"},
+			},
+		},
+	}
+
+	good := map[string]interface{}{
+		"name":    "moby",
+		"pequod":  map[string]interface{}{"port": 80},
+		"spouter": map[string]interface{}{"anything": "goes"},
+	}
+	if err := ValidateAgainstSchema(c, good); err != nil {
+		t.Errorf("Expected the values to be valid, got %s", err)
+	}
+
+	bad := map[string]interface{}{
+		"name":   "moby",
+		"pequod": map[string]interface{}{"port": "eighty"},
+	}
+	err := ValidateAgainstSchema(c, bad)
+	if err == nil {
+		t.Fatal("Expected the subchart values to be invalid")
+	}
+	expect := "values don't meet the specifications of the schema(s) in the following chart(s):\npequod:\n- port must be of type integer"
+	if !strings.HasPrefix(err.Error(), expect) {
+		t.Errorf("Expected %q, got %q", expect, err)
+	}
+
+	if _, err := ToRenderValuesCaps(c, &chart.Config{Raw: "replicaCount: 2\n"}, ReleaseOptions{}, &Capabilities{}); err == nil || !strings.Contains(err.Error(), "name is required") {
+		t.Errorf("Expected rendering invalid values to fail, got %v", err)
+	}
+}
@@ -65,6 +65,11 @@ type Dependency struct {
 	// ImportValues holds the mapping of source values to parent key to be imported. Each item can be a
 	// string or pair of child/parent sublist items.
 	ImportValues []interface{} `json:"import-values"`
+	// Digest is the SHA256 digest of the chart archive ("sha256:HEX").
+	//
+	// It is recorded in lock files for charts from repositories, so that
+	// rebuilding the dependencies fails if an archive has changed.
+	Digest string `json:"digest,omitempty"`
 }
 
 // ErrNoRequirementsFile to detect error condition
@@ -359,7 +364,7 @@ func processImportValues(c *chart.Chart, v *chart.Config) error {
 					}
 					// create value map from child to be merged into parent
 					vm := pathToMap(nm["parent"], vv.AsMap())
-					b = coalesceTables(cvals, vm)
+					b = CoalesceTables(cvals, vm)
 				case string:
 					nm := map[string]string{
 						"child":  "exports." + iv,
@@ -372,14 +377,14 @@ func processImportValues(c *chart.Chart, v *chart.Config) error {
 						log.Printf("Warning: ImportValues missing table: %v", err)
 						continue
 					}
-					b = coalesceTables(b, vm.AsMap())
+					b = CoalesceTables(b, vm.AsMap())
 				}
 			}
 			// set our formatted import values
 			r.ImportValues = outiv
 		}
 	}
-	b = coalesceTables(b, cvals)
+	b = CoalesceTables(b, cvals)
 	y, err := yaml.Marshal(b)
 	if err != nil {
 		return err
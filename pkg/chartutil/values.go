@@ -157,7 +157,21 @@ func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 
 	var err error
 	cvals, err = coalesceDeps(chrt, cvals)
-	return cvals, err
+	return removeNulls(cvals), err
+}
+
+// removeNulls deletes the keys with null values from v and its tables.
+//
+// Setting a value to null deletes the default value of the key.
+func removeNulls(v map[string]interface{}) map[string]interface{} {
+	for key, val := range v {
+		if val == nil {
+			delete(v, key)
+		} else if table, ok := val.(map[string]interface{}); ok {
+			removeNulls(table)
+		}
+	}
+	return v
 }
 
 // coalesce coalesces the dest values and the chart values, giving priority to the dest values.
@@ -230,7 +244,7 @@ func coalesceGlobals(dest, src map[string]interface{}) map[string]interface{} {
 				if destvmap, ok := destv.(map[string]interface{}); ok {
 					// Basically, we reverse order of coalesce here to merge
 					// top-down.
-					coalesceTables(vv, destvmap)
+					CoalesceTables(vv, destvmap)
 					dg[key] = vv
 					continue
 				} else {
@@ -278,10 +292,14 @@ func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interf
 	}
 
 	for key, val := range nv {
-		if _, ok := v[key]; !ok {
+		if value, ok := v[key]; !ok {
 			// If the key is not in v, copy it from nv.
 			v[key] = val
-		} else if dest, ok := v[key].(map[string]interface{}); ok {
+		} else if value == nil {
+			// A null value overrides the default. It is removed by
+			// CoalesceValues once all of the values are coalesced.
+			continue
+		} else if dest, ok := value.(map[string]interface{}); ok {
 			// if v[key] is a table, merge nv's val table into v[key].
 			src, ok := val.(map[string]interface{})
 			if !ok {
@@ -290,24 +308,28 @@ func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interf
 			}
 			// Because v has higher precedence than nv, dest values override src
 			// values.
-			coalesceTables(dest, src)
+			CoalesceTables(dest, src)
 		}
 	}
 	return v, nil
 }
 
-// coalesceTables merges a source map into a destination map.
+// CoalesceTables merges a source map into a destination map.
 //
-// dest is considered authoritative.
-func coalesceTables(dst, src map[string]interface{}) map[string]interface{} {
+// dest is considered authoritative. A key that is null in dest overrides the
+// value in src, and is kept so that it overrides values coalesced later.
+func CoalesceTables(dst, src map[string]interface{}) map[string]interface{} {
 	// Because dest has higher precedence than src, dest values override src
 	// values.
 	for key, val := range src {
+		if dv, ok := dst[key]; ok && dv == nil {
+			continue
+		}
 		if istable(val) {
 			if innerdst, ok := dst[key]; !ok {
 				dst[key] = val
 			} else if istable(innerdst) {
-				coalesceTables(innerdst.(map[string]interface{}), val.(map[string]interface{}))
+				CoalesceTables(innerdst.(map[string]interface{}), val.(map[string]interface{}))
 			} else {
 				log.Printf("warning: cannot overwrite table with non table for %s (%v)", key, val)
 			}
@@ -370,6 +392,10 @@ func ToRenderValuesCaps(chrt *chart.Chart, chrtVals *chart.Config, options Relea
 		return top, err
 	}
 
+	if err := ValidateAgainstSchema(chrt, vals); err != nil {
+		return top, err
+	}
+
 	top["Values"] = vals
 	return top, nil
 }
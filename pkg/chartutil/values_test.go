@@ -277,6 +277,7 @@ func ttpl(tpl string, v map[string]interface{}) (string, error) {
 
 var testCoalesceValuesYaml = `
 top: yup
+override: null
 
 global:
   name: Ishmael
@@ -293,6 +294,7 @@ pequod:
       sail: true
   ahab:
     scope: whale
+    name: null
 `
 
 func TestCoalesceValues(t *testing.T) {
@@ -321,7 +323,7 @@ func TestCoalesceValues(t *testing.T) {
 		{"{{.global.subject}}", "Queequeg"},
 		{"{{.global.harpooner}}", "<no value>"},
 		{"{{.pequod.name}}", "pequod"},
-		{"{{.pequod.ahab.name}}", "ahab"},
+		{"{{.pequod.ahab.name}}", "<no value>"},
 		{"{{.pequod.ahab.scope}}", "whale"},
 		{"{{.pequod.ahab.global.name}}", "Ishmael"},
 		{"{{.pequod.ahab.global.subject}}", "Queequeg"},
@@ -343,6 +345,13 @@ func TestCoalesceValues(t *testing.T) {
 			t.Errorf("Expected %q to expand to %q, got %q", tt.tpl, tt.expect, o)
 		}
 	}
+
+	if _, ok := v["override"]; ok {
+		t.Error("Expected a null value to delete the default")
+	}
+	if _, ok := v["pequod"].(map[string]interface{})["ahab"].(map[string]interface{})["name"]; ok {
+		t.Error("Expected a null value to delete the default of a subchart")
+	}
 }
 
 func TestCoalesceTables(t *testing.T) {
@@ -355,7 +364,8 @@ func TestCoalesceTables(t *testing.T) {
 		"details": map[string]interface{}{
 			"friends": []string{"Tashtego"},
 		},
-		"boat": "pequod",
+		"boat":  "pequod",
+		"hooks": nil,
 	}
 	src := map[string]interface{}{
 		"occupation": "whaler",
@@ -367,11 +377,14 @@ func TestCoalesceTables(t *testing.T) {
 		"boat": map[string]interface{}{
 			"mast": true,
 		},
+		"hooks": map[string]interface{}{
+			"harpoon": true,
+		},
 	}
 
 	// What we expect is that anything in dst overrides anything in src, but that
 	// otherwise the values are coalesced.
-	coalesceTables(dst, src)
+	CoalesceTables(dst, src)
 
 	if dst["name"] != "Ishmael" {
 		t.Errorf("Unexpected name: %s", dst["name"])
@@ -406,6 +419,10 @@ func TestCoalesceTables(t *testing.T) {
 	if dst["boat"].(string) != "pequod" {
 		t.Errorf("Expected boat string, got %v", dst["boat"])
 	}
+
+	if hooks, ok := dst["hooks"]; !ok || hooks != nil {
+		t.Errorf("Expected the null hooks to override the table, got %v", dst["hooks"])
+	}
 }
 func TestPathValue(t *testing.T) {
 	doc := `
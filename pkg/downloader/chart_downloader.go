@@ -28,7 +28,9 @@ import (
 	"strings"
 
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/provenance"
+	"k8s.io/helm/pkg/registry"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/urlutil"
 )
@@ -66,6 +68,16 @@ type ChartDownloader struct {
 	Keyring string
 	// HelmHome is the $HELM_HOME.
 	HelmHome helmpath.Home
+	// Registry is the client for charts in OCI registries. If it is nil, a
+	// client using the Docker credentials is used.
+	Registry *registry.Client
+	// Plugins are the plugins that download URLs of other protocols than
+	// HTTP and HTTPS.
+	Plugins []*plugin.Plugin
+	// Digest is the expected SHA256 digest of the chart archive, either as
+	// 'sha256:HEX' or as HEX. If it is set, a chart that does not match it is
+	// not saved.
+	Digest string
 }
 
 // DownloadTo retrieves a chart. Depending on the settings, it may also download a provenance file.
@@ -79,7 +91,14 @@ type ChartDownloader struct {
 //
 // Returns a string path to the location where the file was downloaded and a verification
 // (if provenance was verified), or an error if something bad happened.
+//
+// A reference of the form oci://HOST/REPOSITORY[:TAG] is pulled from an OCI
+// registry. Charts in registries have no provenance.
 func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *provenance.Verification, error) {
+	if registry.IsReference(ref) {
+		return c.downloadFromRegistry(ref, version, dest)
+	}
+
 	var r repo.Getter
 	u, r, err := c.ResolveChartVersion(ref, version)
 	if err != nil {
@@ -90,6 +109,9 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 	if err != nil {
 		return "", nil, err
 	}
+	if err := checkDigest(data.Bytes(), c.Digest); err != nil {
+		return "", nil, fmt.Errorf("%s: %s", ref, err)
+	}
 
 	name := filepath.Base(u.Path)
 	destfile := filepath.Join(dest, name)
@@ -125,6 +147,51 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 	return destfile, ver, nil
 }
 
+// downloadFromRegistry pulls a chart from an OCI registry. The version, if
+// given, is used as the tag.
+func (c *ChartDownloader) downloadFromRegistry(ref, version, dest string) (string, *provenance.Verification, error) {
+	r, err := registry.ParseReference(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	if version != "" {
+		if r.Tag != "" && r.Tag != version {
+			return "", nil, fmt.Errorf("chart reference %q conflicts with version %q", ref, version)
+		}
+		r.Tag = version
+	}
+
+	ver := &provenance.Verification{}
+	switch c.Verify {
+	case VerifyAlways:
+		return "", ver, fmt.Errorf("cannot verify %s: charts in OCI registries have no provenance", ref)
+	case VerifyIfPossible:
+		fmt.Fprintf(c.Out, "WARNING: Verification not found for %s: charts in OCI registries have no provenance\n", ref)
+	}
+
+	client := c.Registry
+	if client == nil {
+		client = registry.NewClient()
+	}
+	data, err := client.Pull(r)
+	if err != nil {
+		return "", ver, err
+	}
+	if err := checkDigest(data, c.Digest); err != nil {
+		return "", ver, fmt.Errorf("%s: %s", ref, err)
+	}
+
+	name := r.Name()
+	if r.Tag != "" {
+		name += "-" + r.Tag
+	}
+	destfile := filepath.Join(dest, name+".tgz")
+	if err := ioutil.WriteFile(destfile, data, 0655); err != nil {
+		return destfile, ver, err
+	}
+	return destfile, ver, nil
+}
+
 // ResolveChartVersion resolves a chart reference to a URL.
 //
 // It returns the URL as well as a preconfigured repo.Getter that can fetch
@@ -161,14 +228,26 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, re
 			// If there is no special config, return the default HTTP client and
 			// swallow the error.
 			if err == ErrNoOwnerRepo {
-				return u, http.DefaultClient, nil
+				if u.Scheme == "http" || u.Scheme == "https" {
+					return u, http.DefaultClient, nil
+				}
+				r, err := repo.NewChartRepository(&repo.Entry{URL: ref})
+				if err != nil {
+					return u, nil, err
+				}
+				r.Plugins = c.Plugins
+				return u, r, nil
 			}
 			return u, nil, err
 		}
 		r, err := repo.NewChartRepository(rc)
+		if err != nil {
+			return u, nil, err
+		}
+		r.Plugins = c.Plugins
 		// If we get here, we don't need to go through the next phase of looking
 		// up the URL. We have it already. So we just return.
-		return u, r, err
+		return u, r, nil
 	}
 
 	// See if it's of the form: repo/path_to_chart
@@ -188,6 +267,7 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, re
 	if err != nil {
 		return u, nil, err
 	}
+	r.Plugins = c.Plugins
 
 	// Next, we need to load the index, and actually look up the chart.
 	i, err := repo.LoadIndexFile(c.HelmHome.CacheIndex(r.Config.Name))
@@ -213,6 +293,34 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, re
 	return u, r, nil
 }
 
+// DownloadURL downloads the file at the absolute URL href, like a values file.
+//
+// If href is under the URL of a configured repository, the TLS configuration
+// and credentials of that repository are used. URLs of other protocols than
+// HTTP and HTTPS are downloaded with the downloader plugins.
+func (c *ChartDownloader) DownloadURL(href string) (*bytes.Buffer, error) {
+	entry := &repo.Entry{URL: href}
+	rf, err := repo.LoadRepositoriesFile(c.HelmHome.RepositoryFile())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if rf != nil {
+		for _, rc := range rf.Repositories {
+			if strings.HasPrefix(href, strings.TrimSuffix(rc.URL, "/")+"/") {
+				entry = rc
+				break
+			}
+		}
+	}
+
+	r, err := repo.NewChartRepository(entry)
+	if err != nil {
+		return nil, err
+	}
+	r.Plugins = c.Plugins
+	return download(href, r)
+}
+
 // VerifyChart takes a path to a chart archive and a keyring, and verifies the chart.
 //
 // It assumes that a chart archive file is accompanied by a provenance file whose
@@ -256,6 +364,28 @@ func download(href string, r repo.Getter) (*bytes.Buffer, error) {
 	return buf, err
 }
 
+// checkDigest returns an error if data does not have the expected digest. An
+// empty expected digest matches any data.
+func checkDigest(data []byte, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if i := strings.Index(expected, ":"); i >= 0 {
+		if algo := expected[:i]; !strings.EqualFold(algo, "sha256") {
+			return fmt.Errorf("unsupported digest algorithm %q", algo)
+		}
+		expected = expected[i+1:]
+	}
+	actual, err := provenance.Digest(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("digest sha256:%s does not match the expected digest sha256:%s", actual, expected)
+	}
+	return nil
+}
+
 // isTar tests whether the given file is a tar file.
 //
 // Currently, this simply checks extension, since a subsequent function will
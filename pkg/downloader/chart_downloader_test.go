@@ -16,6 +16,7 @@ limitations under the License.
 package downloader
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -23,9 +24,11 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/repo/repotest"
 )
@@ -116,6 +119,53 @@ func TestDownload(t *testing.T) {
 	}
 }
 
+func TestDownloadURL(t *testing.T) {
+	expect := "replicaCount: 3\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "username" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, expect)
+	}))
+	defer srv.Close()
+
+	tmp, err := ioutil.TempDir("", "helm-downloadurl-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	hh := helmpath.Home(tmp)
+	c := ChartDownloader{HelmHome: hh, Out: ioutil.Discard}
+
+	// Without a repositories file, no credentials are sent.
+	if _, err := c.DownloadURL(srv.URL + "/repo/values.yaml"); err == nil {
+		t.Error("Expected an unauthorized download to fail")
+	}
+
+	if err := os.MkdirAll(hh.Repository(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rf := repo.NewRepoFile()
+	rf.Add(&repo.Entry{Name: "private", URL: srv.URL + "/repo", Username: "username", Password: "password"})
+	if err := rf.WriteFile(hh.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.DownloadURL(srv.URL + "/repo/values.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, got.String())
+	}
+
+	// URLs outside of the repository do not get its credentials.
+	if _, err := c.DownloadURL(srv.URL + "/repository/values.yaml"); err == nil {
+		t.Error("Expected a download outside of the repository to fail")
+	}
+}
+
 func TestIsTar(t *testing.T) {
 	tests := map[string]bool{
 		"foo.tgz":           true,
@@ -258,6 +308,81 @@ func TestDownloadTo_VerifyLater(t *testing.T) {
 	}
 }
 
+func TestDownloadTo_Registry(t *testing.T) {
+	dest, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dest)
+
+	data, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	manifest := fmt.Sprintf(`{"schemaVersion": 2, "config": {"mediaType": %q, "digest": "sha256:0", "size": 2}, "layers": [{"mediaType": %q, "digest": %q, "size": %d}]}`,
+		registry.ConfigMediaType, registry.ChartLayerMediaType, digest, len(data))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/charts/signtest/manifests/0.1.0":
+			w.Write([]byte(manifest))
+		case "/v2/charts/signtest/blobs/" + digest:
+			w.Write(data)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	ref := "oci://" + u.Host + "/charts/signtest"
+
+	c := ChartDownloader{Out: os.Stderr, Registry: registry.NewClient()}
+	where, _, err := c.DownloadTo(ref, "0.1.0", dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := filepath.Join(dest, "signtest-0.1.0.tgz"); where != expect {
+		t.Errorf("Expected download to %s, got %s", expect, where)
+	}
+	if _, err := os.Stat(where); err != nil {
+		t.Error(err)
+	}
+
+	if _, _, err := c.DownloadTo(ref+":0.2.0", "0.1.0", dest); err == nil {
+		t.Error("Expected an error for a tag that conflicts with the version")
+	}
+
+	c.Verify = VerifyAlways
+	if _, _, err := c.DownloadTo(ref+":0.1.0", "", dest); err == nil {
+		t.Error("Expected verification of a chart in a registry to fail")
+	}
+}
+
+func TestCheckDigest(t *testing.T) {
+	data := []byte("hello")
+	sum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	tests := []struct {
+		digest string
+		err    bool
+	}{
+		{digest: ""},
+		{digest: sum},
+		{digest: "sha256:" + sum},
+		{digest: "SHA256:" + strings.ToUpper(sum)},
+		{digest: "sha256:0000", err: true},
+		{digest: "md5:5d41402abc4b2a76b9719d911017c592", err: true},
+	}
+
+	for _, tt := range tests {
+		if err := checkDigest(data, tt.digest); (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.digest, tt.err, err)
+		}
+	}
+}
+
 func TestScanReposForURL(t *testing.T) {
 	hh := helmpath.Home("testdata/helmhome")
 	c := ChartDownloader{
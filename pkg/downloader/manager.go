@@ -24,6 +24,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -32,7 +33,9 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/resolver"
 	"k8s.io/helm/pkg/urlutil"
@@ -54,10 +57,21 @@ type Manager struct {
 	Keyring string
 	// SkipUpdate indicates that the repository should not be updated first.
 	SkipUpdate bool
+	// Plugins are the plugins that download URLs of other protocols than
+	// HTTP and HTTPS.
+	Plugins []*plugin.Plugin
+	// Workers is the number of charts that are downloaded concurrently. If
+	// it is not positive, DefaultWorkers is used.
+	Workers int
 }
 
+// DefaultWorkers is the default number of concurrent chart downloads.
+const DefaultWorkers = 4
+
 // Build rebuilds a local charts directory from a lockfile.
 //
+// Charts with a digest in the lockfile must match it.
+//
 // If the lockfile is not present, this will run a Manager.Update()
 //
 // If SkipUpdate is set, this will not update the repository.
@@ -159,7 +173,7 @@ func (m *Manager) Update() error {
 
 	// If the lock file hasn't changed, don't write a new one.
 	oldLock, err := chartutil.LoadRequirementsLock(c)
-	if err == nil && oldLock.Digest == lock.Digest {
+	if err == nil && oldLock.Digest == lock.Digest && reflect.DeepEqual(oldLock.Dependencies, lock.Dependencies) {
 		return nil
 	}
 
@@ -194,11 +208,14 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 		return err
 	}
 
+	// Downloads write to Out concurrently.
+	out := &syncWriter{w: m.Out}
 	dl := ChartDownloader{
-		Out:      m.Out,
+		Out:      out,
 		Verify:   m.Verify,
 		Keyring:  m.Keyring,
 		HelmHome: m.HelmHome,
+		Plugins:  m.Plugins,
 	}
 
 	destPath := filepath.Join(m.ChartPath, "charts")
@@ -213,6 +230,7 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 	}
 
 	fmt.Fprintf(m.Out, "Saving %d charts\n", len(deps))
+	var remote []*chartutil.Dependency
 	for _, dep := range deps {
 		if err := m.safeDeleteDep(dep.Name, destPath); err != nil {
 			return err
@@ -229,21 +247,78 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 			dep.Version = ver
 			continue
 		}
+		remote = append(remote, dep)
+	}
 
-		fmt.Fprintf(m.Out, "Downloading %s from repo %s\n", dep.Name, dep.Repository)
+	workers := m.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	errs := make([]error, len(remote))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				dep := remote[i]
+				fmt.Fprintf(out, "Downloading %s from repo %s\n", dep.Name, dep.Repository)
+
+				churl, err := findChartURL(dep.Name, dep.Version, dep.Repository, repos)
+				if err != nil {
+					errs[i] = fmt.Errorf("could not find %s: %s", churl, err)
+					continue
+				}
+				dl := dl
+				dl.Digest = dep.Digest
+				saved, _, err := dl.DownloadTo(churl, "", destPath)
+				if err != nil {
+					errs[i] = fmt.Errorf("could not download %s: %s", churl, err)
+					continue
+				}
+				sum, err := provenance.DigestFile(saved)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				dep.Digest = "sha256:" + sum
+			}
+		}()
+	}
+	for i := range remote {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-		// Any failure to resolve/download a chart should fail:
-		// https://github.com/kubernetes/helm/issues/1439
-		churl, err := findChartURL(dep.Name, dep.Version, dep.Repository, repos)
+	// Any failure to resolve/download a chart should fail:
+	// https://github.com/kubernetes/helm/issues/1439
+	var msgs []string
+	for _, err := range errs {
 		if err != nil {
-			return fmt.Errorf("could not find %s: %s", churl, err)
-		}
-
-		if _, _, err := dl.DownloadTo(churl, "", destPath); err != nil {
-			return fmt.Errorf("could not download %s: %s", churl, err)
+			msgs = append(msgs, err.Error())
 		}
 	}
-	return nil
+	switch len(msgs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(msgs[0])
+	}
+	return fmt.Errorf("%d dependencies failed to download:\n\t%s", len(msgs), strings.Join(msgs, "\n\t"))
+}
+
+// syncWriter serializes writes to w.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
 }
 
 // safeDeleteDep deletes any versions of the given dependency in the given directory.
@@ -393,6 +468,7 @@ func (m *Manager) parallelRepoUpdate(repos []*repo.Entry) error {
 		if err != nil {
 			return err
 		}
+		r.Plugins = m.Plugins
 		wg.Add(1)
 		go func(r *repo.ChartRepository) {
 			if err := r.DownloadIndexFile(m.HelmHome.Cache()); err != nil {
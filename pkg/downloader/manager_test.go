@@ -17,7 +17,11 @@ package downloader
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/chartutil"
@@ -156,3 +160,71 @@ func TestGetRepoNames(t *testing.T) {
 		}
 	}
 }
+
+func TestDownloadAllErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-downloadall-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := &Manager{
+		Out:       new(bytes.Buffer),
+		ChartPath: dir,
+		HelmHome:  helmpath.Home("testdata/helmhome"),
+		Workers:   2,
+	}
+	deps := []*chartutil.Dependency{
+		{Name: "one", Version: "0.1.0", Repository: "http://example.com/one"},
+		{Name: "two", Version: "0.1.0", Repository: "http://example.com/two"},
+		{Name: "three", Version: "0.1.0", Repository: "http://example.com/three"},
+	}
+
+	err = m.downloadAll(deps)
+	if err == nil {
+		t.Fatal("Expected downloads from unknown repositories to fail")
+	}
+	if !strings.HasPrefix(err.Error(), "3 dependencies failed to download") {
+		t.Errorf("Expected all failures to be reported, got %q", err)
+	}
+	for _, name := range []string{"one", "two", "three"} {
+		if !strings.Contains(m.Out.(*bytes.Buffer).String(), "Downloading "+name) {
+			t.Errorf("Expected %s to be downloaded", name)
+		}
+	}
+}
+
+func TestDownloadAllLocalDependency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-downloadall-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	local, err := filepath.Abs("testdata/signtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{
+		Out:       new(bytes.Buffer),
+		ChartPath: dir,
+		HelmHome:  helmpath.Home("testdata/helmhome"),
+	}
+	deps := []*chartutil.Dependency{
+		{Name: "signtest", Version: "^0.1.0", Repository: "file://" + local},
+	}
+	if err := m.downloadAll(deps); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "charts", "signtest-0.1.0.tgz")); err != nil {
+		t.Errorf("Expected the local chart to be packaged: %s", err)
+	}
+	if deps[0].Version != "0.1.0" {
+		t.Errorf("Expected the dependency to be locked to 0.1.0, got %q", deps[0].Version)
+	}
+
+	deps[0].Version = "0.2.0"
+	if err := m.downloadAll(deps); err == nil {
+		t.Error("Expected an error for a local chart that does not match the version")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package helm // import "k8s.io/helm/pkg/helm"
 
 import (
+	"fmt"
 	"io"
 	"time"
 
@@ -161,6 +162,7 @@ func (h *Client) UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts
 	req.Recreate = h.opts.recreate
 	req.ResetValues = h.opts.resetValues
 	req.ReuseValues = h.opts.reuseValues
+	req.ResetThenReuseValues = h.opts.resetThenReuseValues
 	ctx := NewContext()
 
 	if h.opts.before != nil {
@@ -283,8 +285,12 @@ func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-ch
 // connect returns a grpc connection to tiller or error. The grpc dial options
 // are constructed here.
 func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error) {
+	timeout := h.opts.connectTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
 	opts := []grpc.DialOption{
-		grpc.WithTimeout(5 * time.Second),
+		grpc.WithTimeout(timeout),
 		grpc.WithBlock(),
 	}
 	switch {
@@ -294,6 +300,9 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 		opts = append(opts, grpc.WithInsecure())
 	}
 	if conn, err = grpc.Dial(h.opts.host, opts...); err != nil {
+		if err == grpc.ErrClientConnTimeout {
+			return nil, fmt.Errorf("timed out after %v connecting to tiller at %q", timeout, h.opts.host)
+		}
 		return nil, err
 	}
 	return conn, nil
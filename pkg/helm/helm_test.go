@@ -20,6 +20,7 @@ import (
 	"errors"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -332,3 +333,16 @@ func loadChart(t *testing.T, name string) *cpb.Chart {
 	}
 	return c
 }
+
+// Verify ConnectTimeout bounds the time spent dialing tiller.
+func TestConnect_Timeout(t *testing.T) {
+	// Nothing listens on port 1, so the dial can only ever time out.
+	client := NewClient(Host("127.0.0.1:1"), ConnectTimeout(1))
+	_, err := client.GetVersion()
+	if err == nil {
+		t.Fatal("expected a connection timeout error")
+	}
+	if !strings.Contains(err.Error(), "timed out after 1s connecting to tiller") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
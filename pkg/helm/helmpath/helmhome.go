@@ -80,3 +80,23 @@ func (h Home) LocalRepository(elem ...string) string {
 func (h Home) Plugins() string {
 	return h.Path("plugins")
 }
+
+// Keyring returns the path to the keyring managed by 'helm keys'.
+func (h Home) Keyring() string {
+	return h.Path("keyring.gpg")
+}
+
+// TLSCaCert returns the path to the CA certificate that helm verifies Tiller with.
+func (h Home) TLSCaCert() string {
+	return h.Path("ca.pem")
+}
+
+// TLSCert returns the path to the client certificate of helm.
+func (h Home) TLSCert() string {
+	return h.Path("cert.pem")
+}
+
+// TLSKey returns the path to the client key of helm.
+func (h Home) TLSKey() string {
+	return h.Path("key.pem")
+}
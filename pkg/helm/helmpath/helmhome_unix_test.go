@@ -37,4 +37,8 @@ func TestHelmHome(t *testing.T) {
 	isEq(t, hh.Cache(), "/r/repository/cache")
 	isEq(t, hh.CacheIndex("t"), "/r/repository/cache/t-index.yaml")
 	isEq(t, hh.Starters(), "/r/starters")
+	isEq(t, hh.Keyring(), "/r/keyring.gpg")
+	isEq(t, hh.TLSCaCert(), "/r/ca.pem")
+	isEq(t, hh.TLSCert(), "/r/cert.pem")
+	isEq(t, hh.TLSKey(), "/r/key.pem")
 }
@@ -18,6 +18,7 @@ package helm
 
 import (
 	"crypto/tls"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
@@ -74,8 +75,13 @@ type options struct {
 	resetValues bool
 	// reuseValues instructs Tiller to reuse the values from the last release.
 	reuseValues bool
+	// resetThenReuseValues instructs Tiller to reset to the chart's default values
+	// and then reapply the user-supplied values from the last release.
+	resetThenReuseValues bool
 	// release test options are applied directly to the test release history request
 	testReq rls.TestReleaseRequest
+	// connectTimeout bounds how long to wait for a connection to tiller
+	connectTimeout time.Duration
 }
 
 // Host specifies the host address of the Tiller release server, (default = ":44134").
@@ -93,6 +99,14 @@ func WithTLS(cfg *tls.Config) Option {
 	}
 }
 
+// ConnectTimeout specifies the time in seconds to wait for a connection to
+// the Tiller release server to be established, (default = 5).
+func ConnectTimeout(timeout int64) Option {
+	return func(opts *options) {
+		opts.connectTimeout = time.Duration(timeout) * time.Second
+	}
+}
+
 // BeforeCall returns an option that allows intercepting a helm client rpc
 // before being sent OTA to tiller. The intercepting function should return
 // an error to indicate that the call should not proceed or nil otherwise.
@@ -213,6 +227,27 @@ func ReleaseTestCleanup(cleanup bool) ReleaseTestOption {
 	}
 }
 
+// ReleaseTestParallel is a boolean value representing whether to run test pods in parallel
+func ReleaseTestParallel(parallel bool) ReleaseTestOption {
+	return func(opts *options) {
+		opts.testReq.Parallel = parallel
+	}
+}
+
+// ReleaseTestMaxParallel specifies the maximum number of test pods run in parallel
+func ReleaseTestMaxParallel(max uint32) ReleaseTestOption {
+	return func(opts *options) {
+		opts.testReq.MaxParallel = max
+	}
+}
+
+// ReleaseTestLogs is a boolean value representing whether to stream the logs of test pods
+func ReleaseTestLogs(logs bool) ReleaseTestOption {
+	return func(opts *options) {
+		opts.testReq.Logs = logs
+	}
+}
+
 // RollbackTimeout specifies the number of seconds before kubernetes calls timeout
 func RollbackTimeout(timeout int64) RollbackOption {
 	return func(opts *options) {
@@ -227,6 +262,22 @@ func InstallWait(wait bool) InstallOption {
 	}
 }
 
+// InstallWaitConditions specifies, per resource kind, the status condition type
+// that marks a resource as ready when waiting.
+func InstallWaitConditions(conditions map[string]string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.WaitConditions = conditions
+	}
+}
+
+// UpgradeWaitConditions specifies, per resource kind, the status condition type
+// that marks a resource as ready when waiting.
+func UpgradeWaitConditions(conditions map[string]string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.WaitConditions = conditions
+	}
+}
+
 // UpgradeWait specifies whether or not to wait for all resources to be ready
 func UpgradeWait(wait bool) UpdateOption {
 	return func(opts *options) {
@@ -234,6 +285,55 @@ func UpgradeWait(wait bool) UpdateOption {
 	}
 }
 
+// InstallAtomic specifies whether or not to delete the release if the install fails
+func InstallAtomic(atomic bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Atomic = atomic
+	}
+}
+
+// UpgradeAtomic specifies whether or not to roll the release back if the upgrade fails
+func UpgradeAtomic(atomic bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Atomic = atomic
+	}
+}
+
+// InstallSubNotes specifies whether or not to render the NOTES.txt of subcharts
+func InstallSubNotes(subNotes bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.SubNotes = subNotes
+	}
+}
+
+// UpgradeForce will (if true) force resource replacement through delete/recreate if needed
+func UpgradeForce(force bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Force = force
+	}
+}
+
+// UpgradeCleanupOnFail will (if true) delete the resources created by a failed upgrade
+func UpgradeCleanupOnFail(cleanup bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.CleanupOnFail = cleanup
+	}
+}
+
+// UpgradeSubNotes specifies whether or not to render the NOTES.txt of subcharts
+func UpgradeSubNotes(subNotes bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.SubNotes = subNotes
+	}
+}
+
+// RollbackForce will (if true) force resource replacement through delete/recreate if needed
+func RollbackForce(force bool) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.Force = force
+	}
+}
+
 // RollbackWait specifies whether or not to wait for all resources to be ready
 func RollbackWait(wait bool) RollbackOption {
 	return func(opts *options) {
@@ -346,6 +446,14 @@ func ReuseValues(reuse bool) UpdateOption {
 	}
 }
 
+// ResetThenReuseValues will (if true) reset the values to the chart's defaults and
+// then reapply the user-supplied values from the last release.
+func ResetThenReuseValues(reuse bool) UpdateOption {
+	return func(opts *options) {
+		opts.resetThenReuseValues = reuse
+	}
+}
+
 // UpgradeRecreate will (if true) recreate pods after upgrade.
 func UpgradeRecreate(recreate bool) UpdateOption {
 	return func(opts *options) {
@@ -17,11 +17,13 @@ limitations under the License.
 package portforwarder
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/typed/core/internalversion"
+	"k8s.io/kubernetes/pkg/client/leaderelection/resourcelock"
 	"k8s.io/kubernetes/pkg/client/restclient"
 	"k8s.io/kubernetes/pkg/labels"
 
@@ -39,17 +41,37 @@ func New(namespace string, client *internalclientset.Clientset, config *restclie
 	return t, t.ForwardPort()
 }
 
-func getTillerPodName(client internalversion.PodsGetter, namespace string) (string, error) {
+// tillerLeaderLock is the name of the Endpoints object that replicated Tillers
+// elect their leader with. It matches tiller.LeaderLockName.
+const tillerLeaderLock = "tiller-leader"
+
+func getTillerPodName(client internalversion.CoreInterface, namespace string) (string, error) {
 	// TODO use a const for labels
 	selector := labels.Set{"app": "helm", "name": "tiller"}.AsSelector()
-	pod, err := getFirstRunningPod(client, namespace, selector)
+	pod, err := getFirstRunningPod(client, namespace, selector, getTillerLeader(client, namespace))
 	if err != nil {
 		return "", err
 	}
 	return pod.ObjectMeta.GetName(), nil
 }
 
-func getFirstRunningPod(client internalversion.PodsGetter, namespace string, selector labels.Selector) (*api.Pod, error) {
+// getTillerLeader returns the name of the pod of the Tiller that leads its
+// replicas, or "" if the Tillers in namespace do not elect a leader.
+func getTillerLeader(client internalversion.EndpointsGetter, namespace string) string {
+	e, err := client.Endpoints(namespace).Get(tillerLeaderLock)
+	if err != nil {
+		return ""
+	}
+	var record resourcelock.LeaderElectionRecord
+	if err := json.Unmarshal([]byte(e.Annotations[resourcelock.LeaderElectionRecordAnnotationKey]), &record); err != nil {
+		return ""
+	}
+	return record.HolderIdentity
+}
+
+// getFirstRunningPod returns the ready pod named preferred, or else the first
+// ready pod.
+func getFirstRunningPod(client internalversion.PodsGetter, namespace string, selector labels.Selector, preferred string) (*api.Pod, error) {
 	options := api.ListOptions{LabelSelector: selector}
 	pods, err := client.Pods(namespace).List(options)
 	if err != nil {
@@ -58,10 +80,21 @@ func getFirstRunningPod(client internalversion.PodsGetter, namespace string, sel
 	if len(pods.Items) < 1 {
 		return nil, fmt.Errorf("could not find tiller")
 	}
-	for _, p := range pods.Items {
-		if api.IsPodReady(&p) {
-			return &p, nil
+	var first *api.Pod
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if !api.IsPodReady(p) {
+			continue
+		}
+		if p.Name == preferred {
+			return p, nil
 		}
+		if first == nil {
+			first = p
+		}
+	}
+	if first == nil {
+		return nil, fmt.Errorf("could not find a ready tiller pod")
 	}
-	return nil, fmt.Errorf("could not find a ready tiller pod")
+	return first, nil
 }
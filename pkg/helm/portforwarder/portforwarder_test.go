@@ -21,6 +21,8 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+	"k8s.io/kubernetes/pkg/client/leaderelection/resourcelock"
+	"k8s.io/kubernetes/pkg/runtime"
 )
 
 func mockTillerPod() api.Pod {
@@ -84,3 +86,53 @@ func TestGetFirstPod(t *testing.T) {
 		}
 	}
 }
+
+func TestGetLeaderPod(t *testing.T) {
+	leader := func(name string) *api.Endpoints {
+		return &api.Endpoints{
+			ObjectMeta: api.ObjectMeta{
+				Name:        tillerLeaderLock,
+				Namespace:   api.NamespaceDefault,
+				Annotations: map[string]string{resourcelock.LeaderElectionRecordAnnotationKey: `{"holderIdentity":"` + name + `"}`},
+			},
+		}
+	}
+	second := mockTillerPod()
+	second.Name = "narwhal"
+
+	tests := []struct {
+		name     string
+		leader   *api.Endpoints
+		expected string
+	}{
+		{
+			name:     "without leader election",
+			expected: "orca",
+		},
+		{
+			name:     "with a ready leader",
+			leader:   leader("narwhal"),
+			expected: "narwhal",
+		},
+		{
+			name:     "with a pending leader",
+			leader:   leader("blue"),
+			expected: "orca",
+		},
+	}
+
+	for _, tt := range tests {
+		objs := []runtime.Object{&api.PodList{Items: []api.Pod{mockTillerPodPending(), mockTillerPod(), second}}}
+		if tt.leader != nil {
+			objs = append(objs, tt.leader)
+		}
+		client := fake.NewSimpleClientset(objs...)
+		name, err := getTillerPodName(client.Core(), api.NamespaceDefault)
+		if err != nil {
+			t.Errorf("%q. unexpected error: %v", tt.name, err)
+		}
+		if name != tt.expected {
+			t.Errorf("%q. expected %q, got %q", tt.name, tt.expected, name)
+		}
+	}
+}
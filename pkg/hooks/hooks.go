@@ -26,6 +26,9 @@ const HookAnno = "helm.sh/hook"
 // HookWeightAnno is the label name for a hook weight
 const HookWeightAnno = "helm.sh/hook-weight"
 
+// HookDeleteAnno is the label name for the delete policy for a hook
+const HookDeleteAnno = "helm.sh/hook-delete-policy"
+
 // Types of hooks
 const (
 	PreInstall         = "pre-install"
@@ -38,6 +41,14 @@ const (
 	PostRollback       = "post-rollback"
 	ReleaseTestSuccess = "test-success"
 	ReleaseTestFailure = "test-failure"
+	CRDInstall         = "crd-install"
+)
+
+// Types of hook delete policies
+const (
+	HookSucceeded      = "hook-succeeded"
+	HookFailed         = "hook-failed"
+	BeforeHookCreation = "before-hook-creation"
 )
 
 // FilterTestHooks filters the list of hooks are returns only testing hooks.
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -150,6 +151,9 @@ func (c *Client) Build(namespace string, reader io.Reader) (Result, error) {
 
 // Get gets kubernetes resources as pretty printed string
 //
+// The pods selected by workloads like Deployments and StatefulSets are listed
+// as well, under "v1/Pod(related)", so that their readiness shows.
+//
 // Namespace will set the namespace
 func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 	// Since we don't know what order the objects come in, let's group them by the types, so
@@ -160,6 +164,7 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 		return "", err
 	}
 	missing := []string{}
+	seenPods := map[string]bool{}
 	err = perform(c, namespace, infos, func(info *resource.Info) error {
 		log.Printf("Doing get for %s: %q", info.Mapping.GroupVersionKind.Kind, info.Name)
 		obj, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
@@ -179,6 +184,19 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 		// versions per cluster, but this certainly won't hurt anything, so let's be safe.
 		objType := or.APIVersion + "/" + or.Kind
 		objs[objType] = append(objs[objType], obj)
+
+		pods, err := c.relatedPods(info.Namespace, info.Mapping.GroupVersionKind.Kind, obj)
+		if err != nil {
+			log.Printf("WARNING: Failed to list pods of %q: %s", info.Name, err)
+			return nil
+		}
+		for _, pod := range pods {
+			key := pod.Namespace + "/" + pod.Name
+			if !seenPods[key] {
+				seenPods[key] = true
+				objs[relatedPodsType] = append(objs[relatedPodsType], pod)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -186,15 +204,23 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 	}
 
 	// Ok, now we have all the objects grouped by types (say, by v1/Pod, v1/Service, etc.), so
-	// spin through them and print them. Printer is cool since it prints the header only when
-	// an object type changes, so we can just rely on that. Problem is it doesn't seem to keep
+	// spin through them in order and print them. Problem is the printer doesn't seem to keep
 	// track of tab widths
+	types := make([]string, 0, len(objs))
+	for t := range objs {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
 	buf := new(bytes.Buffer)
-	p := kubectl.NewHumanReadablePrinter(kubectl.PrintOptions{})
-	for t, ot := range objs {
+	for _, t := range types {
+		ot := objs[t]
 		if _, err = buf.WriteString("==> " + t + "\n"); err != nil {
 			return "", err
 		}
+		// Use a printer per group, so that groups of the same type, like the
+		// pods of the release and related pods, each get a header.
+		p := kubectl.NewHumanReadablePrinter(kubectl.PrintOptions{})
 		for _, o := range ot {
 			if err := p.PrintObj(o, buf); err != nil {
 				log.Printf("failed to print object type %s, object: %q :\n %v", t, o, err)
@@ -214,24 +240,74 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 	return buf.String(), nil
 }
 
+// Resource is a resource of a manifest as it exists in the cluster.
+type Resource struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	// Object is the resource in the cluster, encoded as JSON. It is nil if
+	// the resource does not exist.
+	Object []byte
+}
+
+// GetResources gets the resources in reader from the cluster.
+//
+// Resources that do not exist are returned without an Object.
+func (c *Client) GetResources(namespace string, reader io.Reader) ([]*Resource, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	err = perform(c, namespace, infos, func(info *resource.Info) error {
+		gvk := info.Mapping.GroupVersionKind
+		r := &Resource{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       info.Name,
+			Namespace:  info.Namespace,
+		}
+		resources = append(resources, r)
+
+		obj, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			log.Printf("WARNING: Failed Get for resource %q: %s", info.Name, err)
+			return nil
+		}
+		r.Object, err = json.Marshal(obj)
+		return err
+	})
+	return resources, err
+}
+
 // Update reads in the current configuration and a target configuration from io.reader
 //  and creates resources that don't already exists, updates resources that have been modified
 //  in the target configuration and deletes resources from the current configuration that are
 //  not present in the target configuration
 //
+// If force is set, a resource that cannot be patched, for example because an
+// immutable field changed, is deleted and created again from the target
+// configuration.
+//
+// Update returns the resources it created, as kind/namespace/name, also when
+// it fails, so that they can be cleaned up. Resources that existed before are
+// never among them.
+//
 // Namespace will set the namespaces
-func (c *Client) Update(namespace string, originalReader, targetReader io.Reader, recreate bool, timeout int64, shouldWait bool) error {
+func (c *Client) Update(namespace string, originalReader, targetReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
 	original, err := c.BuildUnstructured(namespace, originalReader)
 	if err != nil {
-		return fmt.Errorf("failed decoding reader into objects: %s", err)
+		return nil, fmt.Errorf("failed decoding reader into objects: %s", err)
 	}
 
 	target, err := c.BuildUnstructured(namespace, targetReader)
 	if err != nil {
-		return fmt.Errorf("failed decoding reader into objects: %s", err)
+		return nil, fmt.Errorf("failed decoding reader into objects: %s", err)
 	}
 
 	updateErrors := []string{}
+	created := []string{}
 
 	err = target.Visit(func(info *resource.Info, err error) error {
 		if err != nil {
@@ -239,7 +315,8 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		liveObj, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: err: %s", err)
 			}
@@ -251,6 +328,13 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 
 			kind := info.Mapping.GroupVersionKind.Kind
 			log.Printf("Created a new %s called %q\n", kind, info.Name)
+			ns := info.Namespace
+			if ns == "" {
+				// Cluster-scoped resources are keyed by the release
+				// namespace, as tiller keys the manifest.
+				ns = namespace
+			}
+			created = append(created, kind+"/"+ns+"/"+info.Name)
 			return nil
 		}
 
@@ -259,7 +343,7 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 			return fmt.Errorf("no resource with the name %q found", info.Name)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, recreate); err != nil {
+		if err := updateResource(c, info, originalInfo.Object, liveObj, force, recreate); err != nil {
 			log.Printf("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
@@ -269,9 +353,9 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 
 	switch {
 	case err != nil:
-		return err
+		return created, err
 	case len(updateErrors) != 0:
-		return fmt.Errorf(strings.Join(updateErrors, " && "))
+		return created, fmt.Errorf(strings.Join(updateErrors, " && "))
 	}
 
 	for _, info := range original.Difference(target) {
@@ -281,9 +365,9 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		}
 	}
 	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, target)
+		return created, c.waitForResources(time.Duration(timeout)*time.Second, target)
 	}
-	return nil
+	return created, nil
 }
 
 // Delete deletes kubernetes resources from an io.reader
@@ -371,8 +455,16 @@ func deleteResource(c *Client, info *resource.Info) error {
 	return reaper.Stop(info.Namespace, info.Name, 0, nil)
 }
 
-func createPatch(mapping *meta.RESTMapping, target, current runtime.Object) ([]byte, api.PatchType, error) {
-	oldData, err := json.Marshal(current)
+// createPatch returns the patch that updates the live object to target, the
+// configuration that replaces original.
+//
+// Like kubectl apply, it is a three-way strategic merge patch: fields removed
+// from the configuration are deleted, while fields only set on the live object,
+// like defaults and out-of-band changes, are kept unless target sets them.
+// Kinds without strategic merge patches get a JSON merge patch from original
+// to target instead. The patch is nil if there is nothing to change.
+func createPatch(mapping *meta.RESTMapping, target, original, live runtime.Object) ([]byte, api.PatchType, error) {
+	oldData, err := json.Marshal(original)
 	if err != nil {
 		return nil, api.StrategicMergePatchType, fmt.Errorf("serializing current configuration: %s", err)
 	}
@@ -381,28 +473,38 @@ func createPatch(mapping *meta.RESTMapping, target, current runtime.Object) ([]b
 		return nil, api.StrategicMergePatchType, fmt.Errorf("serializing target configuration: %s", err)
 	}
 
-	if api.Semantic.DeepEqual(oldData, newData) {
-		return nil, api.StrategicMergePatchType, nil
-	}
-
 	// Get a versioned object
 	versionedObject, err := api.Scheme.New(mapping.GroupVersionKind)
 	switch {
 	case runtime.IsNotRegisteredError(err):
+		if api.Semantic.DeepEqual(oldData, newData) {
+			return nil, api.MergePatchType, nil
+		}
 		// fall back to generic JSON merge patch
 		patch, err := jsonpatch.CreateMergePatch(oldData, newData)
 		return patch, api.MergePatchType, err
 	case err != nil:
 		return nil, api.StrategicMergePatchType, fmt.Errorf("failed to get versionedObject: %s", err)
 	default:
+		// Typed objects don't carry their kind, which the configurations do.
+		if live.GetObjectKind().GroupVersionKind().Empty() {
+			live.GetObjectKind().SetGroupVersionKind(mapping.GroupVersionKind)
+		}
+		liveData, err := json.Marshal(live)
+		if err != nil {
+			return nil, api.StrategicMergePatchType, fmt.Errorf("serializing live configuration: %s", err)
+		}
 		log.Printf("generating strategic merge patch for %T", target)
-		patch, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, versionedObject)
-		return patch, api.StrategicMergePatchType, err
+		patch, err := strategicpatch.CreateThreeWayMergePatch(oldData, newData, liveData, versionedObject, true)
+		if err != nil || string(patch) == "{}" {
+			return nil, api.StrategicMergePatchType, err
+		}
+		return patch, api.StrategicMergePatchType, nil
 	}
 }
 
-func updateResource(c *Client, target *resource.Info, currentObj runtime.Object, recreate bool) error {
-	patch, patchType, err := createPatch(target.Mapping, target.Object, currentObj)
+func updateResource(c *Client, target *resource.Info, originalObj, liveObj runtime.Object, force bool, recreate bool) error {
+	patch, patchType, err := createPatch(target.Mapping, target.Object, originalObj, liveObj)
 	if err != nil {
 		return fmt.Errorf("failed to create patch: %s", err)
 	}
@@ -420,7 +522,19 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 	helper := resource.NewHelper(target.Client, target.Mapping)
 	obj, err := helper.Patch(target.Namespace, target.Name, patchType, patch)
 	if err != nil {
-		return err
+		if !force {
+			return err
+		}
+		kind := target.Mapping.GroupVersionKind.Kind
+		log.Printf("Cannot patch %s %q (%v), replacing it", kind, target.Name, err)
+		if err := deleteResource(c, target); err != nil {
+			return fmt.Errorf("failed to delete %s %q for replacement: %s", kind, target.Name, err)
+		}
+		if err := createResource(target); err != nil {
+			return fmt.Errorf("failed to recreate %s %q: %s", kind, target.Name, err)
+		}
+		log.Printf("Replaced %s %q", kind, target.Name)
+		return nil
 	}
 
 	target.Refresh(obj, true)
@@ -464,6 +578,78 @@ func getSelectorFromObject(obj runtime.Object) (map[string]string, error) {
 	}
 }
 
+// relatedPodsType is the group of the pods that are selected by the workloads
+// of a release in the output of Get.
+const relatedPodsType = "v1/Pod(related)"
+
+// relatedPods returns the pods that the workload obj of the given kind
+// selects, or no pods if obj is not a workload.
+func (c *Client) relatedPods(namespace, kind string, obj runtime.Object) ([]*api.Pod, error) {
+	selector, err := podSelector(kind, obj)
+	if err != nil || len(selector) == 0 {
+		return nil, err
+	}
+
+	mapper, _ := c.Object()
+	mapping, err := mapper.RESTMapping(api.Kind("Pod"), "v1")
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.ClientForMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+	list, err := resource.NewHelper(client, mapping).List(namespace, "v1", labels.Set(selector).AsSelector(), false)
+	if err != nil {
+		return nil, err
+	}
+	podList, ok := list.(*api.PodList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pod list type %T", list)
+	}
+	pods := make([]*api.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	return pods, nil
+}
+
+// podSelector returns the labels that the workload obj selects its pods by.
+// Unlike getSelectorFromObject, it works on any version of the workload
+// kinds, including unstructured objects.
+func podSelector(kind string, obj runtime.Object) (map[string]string, error) {
+	switch kind {
+	case "ReplicationController", "ReplicaSet", "Deployment", "DaemonSet", "StatefulSet", "Job":
+	default:
+		return nil, nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var workload struct {
+		Spec struct {
+			Selector json.RawMessage `json:"selector"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &workload); err != nil || len(workload.Spec.Selector) == 0 {
+		return nil, err
+	}
+	// ReplicationControllers select by a plain map of labels, the other
+	// kinds by a label selector.
+	if kind == "ReplicationController" {
+		var selector map[string]string
+		err := json.Unmarshal(workload.Spec.Selector, &selector)
+		return selector, err
+	}
+	var selector struct {
+		MatchLabels map[string]string `json:"matchLabels"`
+	}
+	err = json.Unmarshal(workload.Spec.Selector, &selector)
+	return selector.MatchLabels, err
+}
+
 func recreatePods(client *internalclientset.Clientset, namespace string, selector map[string]string) error {
 	pods, err := client.Pods(namespace).List(api.ListOptions{
 		FieldSelector: fields.Everything(),
@@ -586,8 +772,10 @@ func (c *Client) AsVersionedObject(obj runtime.Object) (runtime.Object, error) {
 	return versions.First(), err
 }
 
-// waitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
+// waitForResources polls to get the current status of all pods, PVCs, Services,
+// Jobs and CustomResourceDefinitions until all are ready or a timeout is reached.
+// Jobs are ready once they complete, and CustomResourceDefinitions once they
+// are established.
 func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 	log.Printf("beginning wait for resources with timeout of %v", timeout)
 	client, _ := c.ClientSet()
@@ -597,7 +785,15 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 		pvc := []api.PersistentVolumeClaim{}
 		replicaSets := []*ext.ReplicaSet{}
 		deployments := []deployment{}
+		jobsCompleted, crdsEstablished := true, true
 		for _, v := range created {
+			if v.Mapping.GroupVersionKind.Kind == "CustomResourceDefinition" {
+				if err := v.Get(); err != nil {
+					return false, err
+				}
+				crdsEstablished = crdsEstablished && conditionTrue(v.Object, "Established")
+				continue
+			}
 			obj, err := c.AsVersionedObject(v.Object)
 			if err != nil && !runtime.IsNotRegisteredError(err) {
 				return false, err
@@ -673,10 +869,92 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 					return false, err
 				}
 				services = append(services, *svc)
+			case (*batch.Job):
+				job, err := client.Batch().Jobs(value.Namespace).Get(value.Name)
+				if err != nil {
+					return false, err
+				}
+				completed, err := jobCompleted(job)
+				if err != nil {
+					return false, fmt.Errorf("%s: %s", value.Name, err)
+				}
+				jobsCompleted = jobsCompleted && completed
+			}
+		}
+		return podsReady(pods) && servicesReady(services) && volumesReady(pvc) && deploymentsReady(deployments) && jobsCompleted && crdsEstablished, nil
+	})
+}
+
+// WaitForConditions polls the resources in reader until every resource whose
+// kind is a key of conditions reports the mapped status condition type as
+// "True", or the timeout is reached.
+//
+// Resources of other kinds are ignored.
+func (c *Client) WaitForConditions(namespace string, reader io.Reader, timeout int64, conditions map[string]string) error {
+	if len(conditions) == 0 {
+		return nil
+	}
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return err
+	}
+	infos = infos.Filter(func(info *resource.Info) bool {
+		_, ok := conditions[info.Mapping.GroupVersionKind.Kind]
+		return ok
+	})
+	if len(infos) == 0 {
+		return nil
+	}
+
+	log.Printf("beginning wait for status conditions with timeout of %ds", timeout)
+	// Failures to get a resource are retried until the timeout, as they may
+	// be transient.
+	var lastErr error
+	err = wait.Poll(2*time.Second, time.Duration(timeout)*time.Second, func() (bool, error) {
+		for _, info := range infos {
+			if lastErr = info.Get(); lastErr != nil {
+				log.Printf("Failed to get %q: %s", info.Name, lastErr)
+				return false, nil
+			}
+			kind := info.Mapping.GroupVersionKind.Kind
+			if !conditionTrue(info.Object, conditions[kind]) {
+				log.Printf("%s %q is not %s yet", kind, info.Name, conditions[kind])
+				return false, nil
 			}
 		}
-		return podsReady(pods) && servicesReady(services) && volumesReady(pvc) && deploymentsReady(deployments), nil
+		return true, nil
 	})
+	if err == wait.ErrWaitTimeout && lastErr != nil {
+		return fmt.Errorf("%s: %s", err, lastErr)
+	}
+	return err
+}
+
+// conditionTrue reports whether obj has a status condition of the given type
+// with the status "True".
+func conditionTrue(obj runtime.Object, conditionType string) bool {
+	u, ok := obj.(*runtime.Unstructured)
+	if !ok {
+		return false
+	}
+	status, ok := u.Object["status"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	conds, ok := status["conditions"].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, c := range conds {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == conditionType && cond["status"] == string(api.ConditionTrue) {
+			return true
+		}
+	}
+	return false
 }
 
 // waitForJob is a helper that waits for a job to complete.
@@ -688,6 +966,16 @@ func waitForJob(e watch.Event, name string) (bool, error) {
 		return true, fmt.Errorf("Expected %s to be a *batch.Job, got %T", name, e.Object)
 	}
 
+	if done, err := jobCompleted(o); done {
+		return true, err
+	}
+
+	log.Printf("%s: Jobs active: %d, jobs failed: %d, jobs succeeded: %d", name, o.Status.Active, o.Status.Failed, o.Status.Succeeded)
+	return false, nil
+}
+
+// jobCompleted reports whether the job o is done. It fails if the job failed.
+func jobCompleted(o *batchinternal.Job) (bool, error) {
 	for _, c := range o.Status.Conditions {
 		if c.Type == batchinternal.JobComplete && c.Status == api.ConditionTrue {
 			return true, nil
@@ -695,8 +983,6 @@ func waitForJob(e watch.Event, name string) (bool, error) {
 			return true, fmt.Errorf("Job failed: %s", c.Reason)
 		}
 	}
-
-	log.Printf("%s: Jobs active: %d, jobs failed: %d, jobs succeeded: %d", name, o.Status.Active, o.Status.Failed, o.Status.Succeeded)
 	return false, nil
 }
 
@@ -739,6 +1025,35 @@ func (c *Client) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader,
 	return status, nil
 }
 
+// GetPodLogs returns the logs of every container of the pods in reader. The
+// logs of each container follow a line naming the container.
+func (c *Client) GetPodLogs(namespace string, reader io.Reader) (string, error) {
+	infos, err := c.Build(namespace, reader)
+	if err != nil {
+		return "", err
+	}
+	client, err := c.ClientSet()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	for _, info := range infos {
+		pod, ok := info.Object.(*api.Pod)
+		if !ok {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			logs, err := client.Core().Pods(info.Namespace).GetLogs(info.Name, &api.PodLogOptions{Container: container.Name}).Do().Raw()
+			if err != nil {
+				return buf.String(), fmt.Errorf("getting logs of container %s of pod %s: %s", container.Name, info.Name, err)
+			}
+			fmt.Fprintf(&buf, "CONTAINER: %s\n%s", container.Name, logs)
+		}
+	}
+	return buf.String(), nil
+}
+
 func watchPodUntilComplete(timeout time.Duration, info *resource.Info) error {
 	w, err := resource.NewHelper(info.Client, info.Mapping).WatchSingle(info.Namespace, info.Name, info.ResourceVersion)
 	if err != nil {
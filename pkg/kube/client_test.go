@@ -22,6 +22,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/testapi"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/apis/batch"
 	"k8s.io/kubernetes/pkg/client/restclient/fake"
 	"k8s.io/kubernetes/pkg/kubectl"
 	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
@@ -170,7 +172,7 @@ func TestUpdate(t *testing.T) {
 					t.Fatalf("could not dump request: %s", err)
 				}
 				req.Body.Close()
-				expected := `{"spec":{"containers":[{"name":"app:v4","ports":[{"containerPort":443,"name":"https"},{"$patch":"delete","containerPort":80}]}]}}`
+				expected := `{"spec":{"containers":[{"name":"app:v4","ports":[{"$patch":"delete","containerPort":80},{"containerPort":443,"name":"https"}]}]}}`
 				if string(data) != expected {
 					t.Errorf("expected patch\n%s\ngot\n%s", expected, string(data))
 				}
@@ -189,7 +191,7 @@ func TestUpdate(t *testing.T) {
 	reaper := &fakeReaper{}
 	rf := &fakeReaperFactory{Factory: f, reaper: reaper}
 	c := &Client{Factory: rf}
-	if err := c.Update(api.NamespaceDefault, objBody(codec, &listA), objBody(codec, &listB), false, 0, false); err != nil {
+	if _, err := c.Update(api.NamespaceDefault, objBody(codec, &listA), objBody(codec, &listB), false, false, 0, false); err != nil {
 		t.Fatal(err)
 	}
 	// TODO: Find a way to test methods that use Client Set
@@ -226,6 +228,62 @@ func TestUpdate(t *testing.T) {
 
 }
 
+func TestCreatePatch(t *testing.T) {
+	mapping := &meta.RESTMapping{GroupVersionKind: unversioned.GroupVersionKind{Version: "v1", Kind: "Pod"}}
+	injectLabel := func(p *api.Pod) { p.Labels = map[string]string{"injected": "true"} }
+	labelTier := func(p *api.Pod) { p.Labels = map[string]string{"tier": "web"} }
+
+	tests := []struct {
+		name                   string
+		original, target, live func(*api.Pod)
+		expect                 string
+	}{
+		{
+			name:   "unchanged configuration keeps live fields",
+			live:   injectLabel,
+			expect: "",
+		},
+		{
+			name:   "changed configuration keeps live fields",
+			target: func(p *api.Pod) { p.Spec.Containers[0].Image = "abc/app:v5" },
+			live:   injectLabel,
+			expect: `{"spec":{"containers":[{"image":"abc/app:v5","name":"app:v4"}]}}`,
+		},
+		{
+			name:   "out-of-band change of configured field is reverted",
+			live:   func(p *api.Pod) { p.Spec.Containers[0].Image = "abc/app:hotfix" },
+			expect: `{"spec":{"containers":[{"image":"abc/app:v4","name":"app:v4"}]}}`,
+		},
+		{
+			name:     "field removed from configuration is deleted",
+			original: labelTier,
+			live:     labelTier,
+			expect:   `{"metadata":{"labels":null}}`,
+		},
+	}
+
+	pod := func(f func(*api.Pod)) *api.Pod {
+		p := newPod("starfish")
+		if f != nil {
+			f(&p)
+		}
+		return &p
+	}
+	for _, tt := range tests {
+		patch, patchType, err := createPatch(mapping, pod(tt.target), pod(tt.original), pod(tt.live))
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if patchType != api.StrategicMergePatchType {
+			t.Errorf("%s: expected a strategic merge patch, got %s", tt.name, patchType)
+		}
+		if string(patch) != tt.expect {
+			t.Errorf("%s: expected patch %s, got %s", tt.name, tt.expect, patch)
+		}
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -323,6 +381,124 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetResources(t *testing.T) {
+	list := newPodList("starfish", "otter")
+	f, tf, _, ns := cmdtesting.NewAPIFactory()
+	tf.Client = &fake.RESTClient{
+		NegotiatedSerializer: ns,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &list.Items[1])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+	c := &Client{Factory: f}
+
+	data := strings.NewReader("kind: Pod\napiVersion: v1\nmetadata:\n  name: otter\n---\nkind: Pod\napiVersion: v1\nmetadata:\n  name: starfish")
+	resources, err := c.GetResources("default", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("Expected 2 resources, got %d", len(resources))
+	}
+	otter, starfish := resources[0], resources[1]
+	if otter.APIVersion != "v1" || otter.Kind != "Pod" || otter.Name != "otter" || otter.Namespace != "default" {
+		t.Errorf("Expected v1/Pod default/otter, got %s/%s %s/%s", otter.APIVersion, otter.Kind, otter.Namespace, otter.Name)
+	}
+	if !strings.Contains(string(otter.Object), `"name":"otter"`) {
+		t.Errorf("Expected the object of otter, got %s", otter.Object)
+	}
+	if starfish.Name != "starfish" || starfish.Object != nil {
+		t.Errorf("Expected starfish without an object, got %s %s", starfish.Name, starfish.Object)
+	}
+}
+
+func TestGetRelatedPods(t *testing.T) {
+	list := newPodList("starfish", "otter")
+	rc := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{
+			Name:      "aquarium",
+			Namespace: api.NamespaceDefault,
+			SelfLink:  "/api/v1/namespaces/default/replicationcontrollers/aquarium",
+		},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: 2,
+			Selector: map[string]string{"app": "aquarium"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{Labels: map[string]string{"app": "aquarium"}},
+				Spec:       newPod("aquarium").Spec,
+			},
+		},
+	}
+	f, tf, _, ns := cmdtesting.NewAPIFactory()
+	tf.Client = &fake.RESTClient{
+		NegotiatedSerializer: ns,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			t.Logf("got request %s %s", p, m)
+			switch {
+			case p == "/namespaces/default/replicationcontrollers/aquarium" && m == "GET":
+				return newResponse(200, rc)
+			case p == "/namespaces/default/pods" && m == "GET":
+				if s := req.URL.Query().Get("labelSelector"); s != "app=aquarium" {
+					t.Errorf("Expected pods to be selected by app=aquarium, got %q", s)
+				}
+				return newResponse(200, &list)
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+	c := &Client{Factory: f}
+
+	data := strings.NewReader("kind: ReplicationController\napiVersion: v1\nmetadata:\n  name: aquarium\nspec:\n  selector:\n    app: aquarium")
+	o, err := c.Get("default", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{"==> v1/ReplicationController", "aquarium", "==> v1/Pod(related)", "starfish", "otter"} {
+		if !strings.Contains(o, expect) {
+			t.Errorf("Expected %q in output, got %s", expect, o)
+		}
+	}
+}
+
+func TestPodSelector(t *testing.T) {
+	tests := []struct {
+		kind   string
+		obj    string
+		expect map[string]string
+	}{
+		{"ReplicationController", `{"spec": {"selector": {"app": "web"}}}`, map[string]string{"app": "web"}},
+		{"Deployment", `{"spec": {"selector": {"matchLabels": {"app": "web", "tier": "front"}}}}`, map[string]string{"app": "web", "tier": "front"}},
+		{"StatefulSet", `{"spec": {}}`, nil},
+		{"Service", `{"spec": {"selector": {"app": "web"}}}`, nil},
+	}
+	for _, tt := range tests {
+		obj := &runtime.Unstructured{}
+		if err := obj.UnmarshalJSON([]byte(`{"kind": "` + tt.kind + `", "apiVersion": "v1", "metadata": {"name": "web"}, ` + tt.obj[1:])); err != nil {
+			t.Fatal(err)
+		}
+		selector, err := podSelector(tt.kind, obj)
+		if err != nil {
+			t.Errorf("%s: %s", tt.kind, err)
+			continue
+		}
+		if !reflect.DeepEqual(selector, tt.expect) {
+			t.Errorf("%s: expected selector %v, got %v", tt.kind, tt.expect, selector)
+		}
+	}
+}
+
 func TestPerform(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -458,6 +634,108 @@ func TestWaitAndGetCompletedPodPhase(t *testing.T) {
 	}
 }
 
+func TestConditionTrue(t *testing.T) {
+	withConditions := func(conds ...interface{}) runtime.Object {
+		return &runtime.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"conditions": conds},
+		}}
+	}
+	tests := []struct {
+		name   string
+		obj    runtime.Object
+		expect bool
+	}{
+		{
+			name:   "condition is true",
+			obj:    withConditions(map[string]interface{}{"type": "Ready", "status": "True"}),
+			expect: true,
+		},
+		{
+			name:   "condition is false",
+			obj:    withConditions(map[string]interface{}{"type": "Ready", "status": "False"}),
+			expect: false,
+		},
+		{
+			name:   "other condition is true",
+			obj:    withConditions(map[string]interface{}{"type": "Synced", "status": "True"}),
+			expect: false,
+		},
+		{
+			name:   "no status",
+			obj:    &runtime.Unstructured{Object: map[string]interface{}{}},
+			expect: false,
+		},
+		{
+			name:   "structured object",
+			obj:    &api.Pod{},
+			expect: false,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := conditionTrue(tt.obj, "Ready"); got != tt.expect {
+			t.Errorf("%q. expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestServicesReady(t *testing.T) {
+	tests := []struct {
+		name   string
+		svc    api.Service
+		expect bool
+	}{
+		{
+			name:   "cluster IP not assigned yet",
+			svc:    api.Service{Spec: api.ServiceSpec{Type: api.ServiceTypeClusterIP}},
+			expect: false,
+		},
+		{
+			name:   "cluster IP assigned",
+			svc:    api.Service{Spec: api.ServiceSpec{Type: api.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"}},
+			expect: true,
+		},
+		{
+			name:   "headless service",
+			svc:    api.Service{Spec: api.ServiceSpec{Type: api.ServiceTypeClusterIP, ClusterIP: api.ClusterIPNone}},
+			expect: true,
+		},
+		{
+			name:   "load balancer not provisioned yet",
+			svc:    api.Service{Spec: api.ServiceSpec{Type: api.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"}},
+			expect: false,
+		},
+		{
+			name: "load balancer provisioned",
+			svc: api.Service{
+				Spec: api.ServiceSpec{Type: api.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+				Status: api.ServiceStatus{LoadBalancer: api.LoadBalancerStatus{
+					Ingress: []api.LoadBalancerIngress{{IP: "1.2.3.4"}},
+				}},
+			},
+			expect: true,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := servicesReady([]api.Service{tt.svc}); got != tt.expect {
+			t.Errorf("%s: expected %t, got %t", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestVolumesReady(t *testing.T) {
+	pending := api.PersistentVolumeClaim{Status: api.PersistentVolumeClaimStatus{Phase: api.ClaimPending}}
+	bound := api.PersistentVolumeClaim{Status: api.PersistentVolumeClaimStatus{Phase: api.ClaimBound}}
+
+	if volumesReady([]api.PersistentVolumeClaim{bound, pending}) {
+		t.Error("expected pending claim not to be ready")
+	}
+	if !volumesReady([]api.PersistentVolumeClaim{bound}) {
+		t.Error("expected bound claim to be ready")
+	}
+}
+
 func TestReal(t *testing.T) {
 	t.Skip("This is a live test, comment this line to run")
 	c := New(nil)
@@ -638,3 +916,32 @@ spec:
         ports:
         - containerPort: 80
 `
+
+func TestJobCompleted(t *testing.T) {
+	withCondition := func(typ batch.JobConditionType, status api.ConditionStatus) *batch.Job {
+		return &batch.Job{Status: batch.JobStatus{
+			Conditions: []batch.JobCondition{{Type: typ, Status: status, Reason: "BackoffLimitExceeded"}},
+		}}
+	}
+	tests := []struct {
+		name   string
+		job    *batch.Job
+		expect bool
+		err    bool
+	}{
+		{name: "running", job: &batch.Job{}, expect: false},
+		{name: "completed", job: withCondition(batch.JobComplete, api.ConditionTrue), expect: true},
+		{name: "not completed yet", job: withCondition(batch.JobComplete, api.ConditionFalse), expect: false},
+		{name: "failed", job: withCondition(batch.JobFailed, api.ConditionTrue), expect: true, err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := jobCompleted(tt.job)
+		if got != tt.expect {
+			t.Errorf("%q. expected %v, got %v", tt.name, tt.expect, got)
+		}
+		if (err != nil) != tt.err {
+			t.Errorf("%q. expected error %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
@@ -24,13 +24,16 @@ import (
 )
 
 // All runs all of the available linters on the given base directory.
-func All(basedir string) support.Linter {
+//
+// values are YAML values that override the chart's defaults when rendering
+// the templates. They may be nil.
+func All(basedir string, values []byte) support.Linter {
 	// Using abs path to get directory context
 	chartDir, _ := filepath.Abs(basedir)
 
 	linter := support.Linter{ChartDir: chartDir}
 	rules.Chartfile(&linter)
 	rules.Values(&linter)
-	rules.Templates(&linter)
+	rules.Templates(&linter, values)
 	return linter
 }
@@ -26,11 +26,13 @@ import (
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/lint/support"
+	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/timeconv"
 )
 
-// Templates lints the templates in the Linter.
-func Templates(linter *support.Linter) {
+// Templates lints the templates in the Linter, rendering them with values
+// merged over the chart's default values.
+func Templates(linter *support.Linter, values []byte) {
 	path := "templates/"
 	templatesPath := filepath.Join(linter.ChartDir, path)
 
@@ -52,7 +54,14 @@ func Templates(linter *support.Linter) {
 
 	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: "testNamespace"}
 	caps := &chartutil.Capabilities{APIVersions: chartutil.DefaultVersionSet}
-	valuesToRender, err := chartutil.ToRenderValuesCaps(chart, chart.Values, options, caps)
+	config := chart.Values
+	if len(values) > 0 {
+		config = &cpb.Config{Raw: string(values)}
+	}
+	if vals, err := chartutil.CoalesceValues(chart, config); err == nil {
+		linter.RunLinterRule(support.ErrorSev, chartutil.SchemaFileName, chartutil.ValidateAgainstSchema(chart, vals))
+	}
+	valuesToRender, err := chartutil.ToRenderValuesCaps(chart, config, options, caps)
 	if err != nil {
 		// FIXME: This seems to generate a duplicate, but I can't find where the first
 		// error is coming from.
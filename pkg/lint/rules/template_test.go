@@ -46,7 +46,7 @@ func TestValidateAllowedExtension(t *testing.T) {
 
 func TestTemplateParsing(t *testing.T) {
 	linter := support.Linter{ChartDir: templateTestBasedir}
-	Templates(&linter)
+	Templates(&linter, nil)
 	res := linter.Messages
 
 	if len(res) != 1 {
@@ -69,10 +69,29 @@ func TestTemplateIntegrationHappyPath(t *testing.T) {
 	defer os.Rename(ignoredTemplatePath, wrongTemplatePath)
 
 	linter := support.Linter{ChartDir: templateTestBasedir}
-	Templates(&linter)
+	Templates(&linter, nil)
 	res := linter.Messages
 
 	if len(res) != 0 {
 		t.Fatalf("Expected no error, got %d, %v", len(res), res)
 	}
 }
+
+func TestTemplateSchema(t *testing.T) {
+	dir := filepath.Join("testdata", "withschema")
+
+	linter := support.Linter{ChartDir: dir}
+	Templates(&linter, nil)
+	if len(linter.Messages) != 0 {
+		t.Fatalf("Expected no error, got %d, %v", len(linter.Messages), linter.Messages)
+	}
+
+	linter = support.Linter{ChartDir: dir}
+	Templates(&linter, []byte("replicaCount: 0\n"))
+	if len(linter.Messages) != 1 {
+		t.Fatalf("Expected one error, got %d, %v", len(linter.Messages), linter.Messages)
+	}
+	if !strings.Contains(linter.Messages[0].Err.Error(), "replicaCount should be greater than or equal to 1") {
+		t.Errorf("Unexpected error: %s", linter.Messages[0].Err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin // import "k8s.io/helm/pkg/plugin"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Downloader is a command that downloads URLs of the given protocols.
+//
+// The command is invoked with the certificate file, key file and CA file of
+// the repository (which may be empty strings) and the URL to download, and
+// writes the downloaded content to stdout:
+//
+//	COMMAND CERT_FILE KEY_FILE CA_FILE URL
+//
+// A relative command is resolved against the plugin directory.
+type Downloader struct {
+	// Command is the command to run, as a single string.
+	Command string `json:"command"`
+	// Protocols are the URL schemes that the command downloads, like "s3".
+	Protocols []string `json:"protocols"`
+}
+
+// FindDownloader returns the plugin and its downloader for the URL scheme
+// protocol, or nil if none of the plugins downloads it.
+func FindDownloader(plugins []*Plugin, protocol string) (*Plugin, *Downloader) {
+	for _, p := range plugins {
+		for i, d := range p.Metadata.Downloaders {
+			for _, proto := range d.Protocols {
+				if strings.EqualFold(proto, protocol) {
+					return p, &p.Metadata.Downloaders[i]
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Download runs the downloader d of the plugin for href and returns what
+// it downloaded.
+func (p *Plugin) Download(d *Downloader, href, certFile, keyFile, caFile string) (*bytes.Buffer, error) {
+	env := map[string]string{
+		"HELM_PLUGIN_NAME": p.Metadata.Name,
+		"HELM_PLUGIN_DIR":  p.Dir,
+	}
+	parts := strings.Fields(os.Expand(d.Command, func(key string) string {
+		if v, ok := env[key]; ok {
+			return v
+		}
+		return os.Getenv(key)
+	}))
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("plugin %q has a downloader without a command", p.Metadata.Name)
+	}
+
+	main := parts[0]
+	if !filepath.IsAbs(main) && strings.ContainsRune(main, filepath.Separator) {
+		main = filepath.Join(p.Dir, main)
+	}
+	args := append(parts[1:], certFile, keyFile, caFile, href)
+
+	buf := bytes.NewBuffer(nil)
+	var stderr bytes.Buffer
+	cmd := exec.Command(main, args...)
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stdout = buf
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %q failed to download %s: %s: %s", p.Metadata.Name, href, err, strings.TrimSpace(stderr.String()))
+	}
+	return buf, nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package installer // import "k8s.io/helm/pkg/plugin/installer"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm/helmpath"
+)
+
+// archiveExtensions are the extensions of the plugin archives that
+// ArchiveInstaller installs.
+var archiveExtensions = []string{".tar.gz", ".tgz"}
+
+// sourceFileExt is the extension of the file next to an extracted archive in
+// the plugin cache that records the path of the archive.
+const sourceFileExt = ".source"
+
+// ArchiveInstaller installs plugins from gzipped tarballs on the filesystem.
+type ArchiveInstaller struct {
+	base
+}
+
+// NewArchiveInstaller creates a new ArchiveInstaller.
+func NewArchiveInstaller(source string, home helmpath.Home) (*ArchiveInstaller, error) {
+	i := &ArchiveInstaller{
+		base: newBase(source, home),
+	}
+	return i, nil
+}
+
+// Install extracts the archive into the plugin cache of $HELM_HOME and creates
+// a symlink to the plugin directory in it. The plugin is either at the root of
+// the archive or in its only top-level directory.
+//
+// Implements Installer.
+func (i *ArchiveInstaller) Install() error {
+	dir, err := i.extract(nil)
+	if err != nil {
+		return err
+	}
+	debug("symlinking %s to %s", dir, i.Path())
+	return os.Symlink(dir, i.Path())
+}
+
+// Update extracts the archive that the plugin was installed from again. The
+// installed plugin is left alone if the archive is not available anymore.
+//
+// Implements Installer.
+func (i *ArchiveInstaller) Update() error {
+	return i.updateChecked(nil)
+}
+
+func (i *ArchiveInstaller) updateChecked(check func(dir string) error) error {
+	if strings.Contains(i.Source, "://") {
+		return fmt.Errorf("cannot update the plugin from %s: only plugins installed from local archives can be updated, reinstall it instead", i.Source)
+	}
+	if _, err := os.Stat(i.Source); err != nil {
+		return fmt.Errorf("cannot update the plugin from %s: %s", i.Source, err)
+	}
+	dir, err := i.extract(check)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(i.Path()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	debug("symlinking %s to %s", dir, i.Path())
+	return os.Symlink(dir, i.Path())
+}
+
+// extract extracts the archive into a staging directory in the plugin cache,
+// checks the plugin in it with check, if given, and only then replaces the
+// previously extracted plugin with it, so that a broken archive leaves the
+// cache alone. It returns the plugin directory in the cache.
+func (i *ArchiveInstaller) extract(check func(dir string) error) (string, error) {
+	cachedpath := i.HelmHome.Path("cache", "plugins", i.name())
+	if err := os.MkdirAll(filepath.Dir(cachedpath), 0755); err != nil {
+		return "", err
+	}
+	staging, err := ioutil.TempDir(filepath.Dir(cachedpath), "."+i.name()+"-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(staging)
+
+	debug("extracting %s to %s", i.Source, staging)
+	if err := chartutil.ExpandFile(staging, i.Source); err != nil {
+		return "", err
+	}
+	dir, err := pluginDir(staging)
+	if err != nil {
+		return "", err
+	}
+	if check != nil {
+		if err := check(dir); err != nil {
+			return "", err
+		}
+	}
+
+	// Move the previously extracted plugin out of the way and back if the new
+	// one cannot take its place.
+	previous := staging + ".previous"
+	if err := os.Rename(cachedpath, previous); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.Rename(staging, cachedpath); err != nil {
+		os.Rename(previous, cachedpath)
+		return "", err
+	}
+	os.RemoveAll(previous)
+
+	// Record the source for updates.
+	src, err := filepath.Abs(i.Source)
+	if err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(cachedpath+sourceFileExt, []byte(src), 0644); err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(staging, dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cachedpath, rel), nil
+}
+
+// existingArchiveInstaller creates an ArchiveInstaller for the archive that
+// the plugin in plugdir was extracted from.
+func existingArchiveInstaller(plugdir string, home helmpath.Home) (*ArchiveInstaller, error) {
+	name := filepath.Base(plugdir)
+	src, err := ioutil.ReadFile(home.Path("cache", "plugins", name+sourceFileExt))
+	if err != nil {
+		return nil, err
+	}
+	return NewArchiveInstaller(string(src), home)
+}
+
+// Path is where the plugin will be symlinked to.
+func (i *ArchiveInstaller) Path() string {
+	if i.Source == "" {
+		return ""
+	}
+	return filepath.Join(i.HelmHome.Plugins(), i.name())
+}
+
+// name is the base name of the archive without its extension.
+func (i *ArchiveInstaller) name() string {
+	name := filepath.Base(i.Source)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext)
+		}
+	}
+	return name
+}
+
+// pluginDir returns dir if it holds a plugin, or else its only subdirectory
+// if that holds a plugin.
+func pluginDir(dir string) (string, error) {
+	if isPlugin(dir) {
+		return dir, nil
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 1 && files[0].IsDir() && isPlugin(filepath.Join(dir, files[0].Name())) {
+		return filepath.Join(dir, files[0].Name()), nil
+	}
+	return "", ErrMissingMetadata
+}
+
+// isArchive checks if the source is named like a plugin archive.
+func isArchive(source string) bool {
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(source, ext) {
+			return true
+		}
+	}
+	return false
+}
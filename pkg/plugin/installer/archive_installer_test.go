@@ -0,0 +1,223 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installer // import "k8s.io/helm/pkg/plugin/installer"
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+)
+
+var _ Installer = new(ArchiveInstaller)
+
+// writeArchive writes a gzipped tarball of files to path.
+func writeArchive(t *testing.T, path string, files map[string]string) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchiveInstaller(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", home.Plugins(), err)
+	}
+
+	tdir, err := ioutil.TempDir("", "helm-installer-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	tests := []struct {
+		archive string
+		files   map[string]string
+		path    string
+		err     error
+	}{
+		{"echo.tgz", map[string]string{"plugin.yaml": "name: echo\n"}, "echo", nil},
+		{"hello-0.1.0.tar.gz", map[string]string{"hello/plugin.yaml": "name: hello\n", "hello/hello.sh": "echo hello\n"}, "hello-0.1.0", nil},
+		{"broken.tgz", map[string]string{"README": "no plugin here\n"}, "broken", ErrMissingMetadata},
+	}
+	for _, tt := range tests {
+		source := filepath.Join(tdir, tt.archive)
+		writeArchive(t, source, tt.files)
+
+		i, err := NewForSource(source, "", home)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, ok := i.(*ArchiveInstaller); !ok {
+			t.Fatalf("expected an ArchiveInstaller for %s, got %T", tt.archive, i)
+		}
+		if err := Install(i); err != tt.err {
+			t.Errorf("%s: expected error %v, got %v", tt.archive, tt.err, err)
+		}
+		if tt.err != nil {
+			continue
+		}
+
+		if i.Path() != home.Path("plugins", tt.path) {
+			t.Errorf("expected path '$HELM_HOME/plugins/%s', got %q", tt.path, i.Path())
+		}
+		if _, err := os.Stat(filepath.Join(i.Path(), "plugin.yaml")); err != nil {
+			t.Errorf("expected the plugin to be installed: %s", err)
+		}
+	}
+}
+
+func TestArchiveInstallerUpdate(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", home.Plugins(), err)
+	}
+
+	source := filepath.Join(hh, "echo.tgz")
+	writeArchive(t, source, map[string]string{"plugin.yaml": "name: echo\nversion: 0.1.0\n"})
+	i, err := NewForSource(source, "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+
+	writeArchive(t, source, map[string]string{"plugin.yaml": "name: echo\nversion: 0.2.0\n"})
+	found, err := FindSource(home.Path("plugins", "echo"), "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := found.(*ArchiveInstaller); !ok {
+		t.Fatalf("expected an ArchiveInstaller, got %T", found)
+	}
+	if err := Update(found); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(found.Path(), "plugin.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "name: echo\nversion: 0.2.0\n" {
+		t.Errorf("expected the plugin to be updated, got %q", b)
+	}
+
+	// A broken archive or a version that fails the check leaves the installed
+	// version alone.
+	tests := []struct {
+		name  string
+		files map[string]string
+		check func(string) error
+	}{
+		{"broken archive", map[string]string{"README": "no plugin here\n"}, nil},
+		{"failed check", map[string]string{"plugin.yaml": "name: echo\nversion: 0.3.0\n"}, func(string) error { return errors.New("incompatible") }},
+	}
+	for _, tt := range tests {
+		writeArchive(t, source, tt.files)
+		if err := UpdateChecked(found, tt.check); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+		b, err := ioutil.ReadFile(filepath.Join(found.Path(), "plugin.yaml"))
+		if err != nil {
+			t.Fatalf("%s: expected the installed plugin to be left alone: %s", tt.name, err)
+		}
+		if string(b) != "name: echo\nversion: 0.2.0\n" {
+			t.Errorf("%s: expected the installed version to be kept, got %q", tt.name, b)
+		}
+	}
+}
+
+func TestArchiveInstallerUpdateUnavailable(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", home.Plugins(), err)
+	}
+
+	source := filepath.Join(hh, "echo.tgz")
+	writeArchive(t, source, map[string]string{"plugin.yaml": "name: echo\n"})
+	i, err := NewForSource(source, "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"removed archive", source},
+		{"archive URL", "https://example.com/echo.tgz"},
+	}
+	if err := os.Remove(source); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		u, err := NewArchiveInstaller(tt.source, home)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Update(u); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+		if _, err := os.Stat(filepath.Join(i.Path(), "plugin.yaml")); err != nil {
+			t.Errorf("%s: expected the installed plugin to be left alone: %s", tt.name, err)
+		}
+	}
+}
@@ -36,6 +36,8 @@ type Installer interface {
 	Install() error
 	// Path is the directory of the installed plugin.
 	Path() string
+	// Update updates an installed plugin to the newest version of its source.
+	Update() error
 }
 
 // Install installs a plugin to $HELM_HOME.
@@ -43,10 +45,58 @@ func Install(i Installer) error {
 	return i.Install()
 }
 
+// Update updates a plugin in $HELM_HOME.
+func Update(i Installer) error {
+	return i.Update()
+}
+
+// checkedUpdater is implemented by the installers that can check the new
+// version of a plugin before it replaces the installed one.
+type checkedUpdater interface {
+	updateChecked(check func(dir string) error) error
+}
+
+// UpdateChecked updates a plugin in $HELM_HOME like Update, but calls check
+// with the directory of the new version of the plugin first and keeps the
+// installed version if check fails.
+func UpdateChecked(i Installer, check func(dir string) error) error {
+	if u, ok := i.(checkedUpdater); ok {
+		return u.updateChecked(check)
+	}
+	if err := i.Update(); err != nil {
+		return err
+	}
+	return check(i.Path())
+}
+
+// FindSource determines the Installer of the plugin installed in the
+// directory plugdir of $HELM_HOME from the source it was installed from.
+// Only plugins cloned to the plugin cache of $HELM_HOME are updated from
+// their repositories; a linked local directory is never pulled, even if it is
+// a checkout.
+func FindSource(plugdir, version string, home helmpath.Home) (Installer, error) {
+	src, err := os.Readlink(plugdir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine the source of %s, it was not installed by 'helm plugin install'", plugdir)
+	}
+	if filepath.Dir(src) == home.Path("cache", "plugins") {
+		if i, err := existingVCSInstaller(src, version, home); err == nil {
+			return i, nil
+		}
+	}
+	if i, err := existingArchiveInstaller(plugdir, home); err == nil {
+		return i, nil
+	}
+	return NewLocalInstaller(src, home)
+}
+
 // NewForSource determines the correct Installer for the given source.
 func NewForSource(source, version string, home helmpath.Home) (Installer, error) {
-	// Check if source is a local directory
+	// Check if source is a local archive or directory
 	if isLocalReference(source) {
+		if isArchive(source) {
+			return NewArchiveInstaller(source, home)
+		}
 		return NewLocalInstaller(source, home)
 	}
 	return NewVCSInstaller(source, version, home)
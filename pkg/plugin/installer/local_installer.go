@@ -47,3 +47,12 @@ func (i *LocalInstaller) Install() error {
 	}
 	return i.link(src)
 }
+
+// Update does nothing, since the plugin directory in $HELM_HOME links to the
+// source, which is always up to date.
+//
+// Implements Installer.
+func (i *LocalInstaller) Update() error {
+	debug("%s is a local directory, nothing to update", i.Source)
+	return nil
+}
@@ -18,6 +18,7 @@ package installer // import "k8s.io/helm/pkg/plugin/installer"
 import (
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -62,3 +63,81 @@ func TestLocalInstaller(t *testing.T) {
 		t.Errorf("expected path '$HELM_HOME/plugins/helm-env', got %q", i.Path())
 	}
 }
+
+func TestLocalInstallerFindSource(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", home.Plugins(), err)
+	}
+	i, err := NewForSource("../testdata/plugdir/echo", "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindSource(i.Path(), "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := found.(*LocalInstaller); !ok {
+		t.Fatalf("expected a LocalInstaller, got %T", found)
+	}
+	if err := Update(found); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := FindSource("../testdata/plugdir/echo", "", home); err == nil {
+		t.Error("expected an error for a plugin that was not installed by helm")
+	}
+}
+
+func TestLocalInstallerFindSourceCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", home.Plugins(), err)
+	}
+
+	// A local plugin directory that is a git checkout is not pulled.
+	source := filepath.Join(hh, "echo")
+	if err := os.Mkdir(source, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(source, "plugin.yaml"), []byte("name: echo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "init", source).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %s: %s", err, out)
+	}
+	i, err := NewForSource(source, "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindSource(i.Path(), "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := found.(*LocalInstaller); !ok {
+		t.Fatalf("expected a LocalInstaller, got %T", found)
+	}
+}
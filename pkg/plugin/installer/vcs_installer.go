@@ -16,7 +16,11 @@ limitations under the License.
 package installer // import "k8s.io/helm/pkg/plugin/installer"
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/Masterminds/semver"
@@ -26,6 +30,10 @@ import (
 	"k8s.io/helm/pkg/plugin/cache"
 )
 
+// versionFileExt is the extension of the file next to a repository in the
+// plugin cache that records the version constraint it was installed with.
+const versionFileExt = ".version"
+
 // VCSInstaller installs plugins from remote a repository.
 type VCSInstaller struct {
 	Repo    vcs.Repo
@@ -72,10 +80,111 @@ func (i *VCSInstaller) Install() error {
 	if !isPlugin(i.Repo.LocalPath()) {
 		return ErrMissingMetadata
 	}
+	if err := i.recordVersion(); err != nil {
+		return err
+	}
 
 	return i.link(i.Repo.LocalPath())
 }
 
+// existingVCSInstaller creates a VCSInstaller for the repository that a plugin
+// was cloned to in the cache of $HELM_HOME. Without a version constraint, the
+// one the plugin was installed with is used.
+func existingVCSInstaller(cachedpath, version string, home helmpath.Home) (*VCSInstaller, error) {
+	repo, err := vcs.NewRepo("", cachedpath)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		if b, err := ioutil.ReadFile(versionFile(home, cachedpath)); err == nil {
+			version = string(b)
+		}
+	}
+	return &VCSInstaller{
+		Repo:    repo,
+		Version: version,
+		base:    newBase(repo.Remote(), home),
+	}, nil
+}
+
+// Update pulls the newest changes of the repository and checks out the
+// newest version that matches the version constraint, if any.
+//
+// Implements Installer.
+func (i *VCSInstaller) Update() error {
+	return i.updateChecked(nil)
+}
+
+func (i *VCSInstaller) updateChecked(check func(dir string) error) error {
+	debug("updating %s", i.Repo.Remote())
+	if i.Repo.IsDirty() {
+		return errors.New("plugin repo was modified")
+	}
+	previous, err := i.Repo.Version()
+	if err != nil {
+		return err
+	}
+	if err := i.update(check); err != nil {
+		debug("restoring %s", previous)
+		if rerr := i.restore(previous); rerr != nil {
+			return fmt.Errorf("%s (failed to restore the installed version: %s)", err, rerr)
+		}
+		return err
+	}
+	return i.recordVersion()
+}
+
+func (i *VCSInstaller) update(check func(dir string) error) error {
+	if err := i.Repo.Update(); err != nil {
+		return err
+	}
+
+	if i.Version != "" {
+		ref, err := i.solveVersion(i.Repo)
+		if err != nil {
+			return err
+		}
+		if err := i.setVersion(i.Repo, ref); err != nil {
+			return err
+		}
+	}
+
+	if !isPlugin(i.Repo.LocalPath()) {
+		return ErrMissingMetadata
+	}
+	if check != nil {
+		return check(i.Repo.LocalPath())
+	}
+	return nil
+}
+
+// restore checks out the revision of the repository before an update again.
+// A git branch is reset to it so that it is still pulled on the next update.
+func (i *VCSInstaller) restore(revision string) error {
+	if g, ok := i.Repo.(*vcs.GitRepo); ok {
+		if out, err := g.RunFromDir("git", "reset", "--hard", revision); err != nil {
+			return fmt.Errorf("%s: %s", err, out)
+		}
+		return nil
+	}
+	return i.Repo.UpdateVersion(revision)
+}
+
+// recordVersion records the version constraint of the installer next to the
+// repository, to be used by later updates.
+func (i *VCSInstaller) recordVersion() error {
+	if i.Version == "" {
+		return nil
+	}
+	return ioutil.WriteFile(versionFile(i.HelmHome, i.Repo.LocalPath()), []byte(i.Version), 0644)
+}
+
+// versionFile is the file that records the version constraint of the
+// repository in cachedpath.
+func versionFile(home helmpath.Home, cachedpath string) string {
+	return home.Path("cache", "plugins", filepath.Base(cachedpath)+versionFileExt)
+}
+
 func (i *VCSInstaller) solveVersion(repo vcs.Repo) (string, error) {
 	if i.Version == "" {
 		return "", nil
@@ -16,6 +16,7 @@ limitations under the License.
 package installer // import "k8s.io/helm/pkg/plugin/installer"
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -30,6 +31,7 @@ var _ Installer = new(VCSInstaller)
 type testRepo struct {
 	local, remote, current string
 	tags, branches         []string
+	dirty                  bool
 	err                    error
 	vcs.Repo
 }
@@ -41,6 +43,8 @@ func (r *testRepo) Get() error                  { return r.err }
 func (r *testRepo) IsReference(string) bool     { return false }
 func (r *testRepo) Tags() ([]string, error)     { return r.tags, r.err }
 func (r *testRepo) Branches() ([]string, error) { return r.branches, r.err }
+func (r *testRepo) IsDirty() bool               { return r.dirty }
+func (r *testRepo) Version() (string, error)    { return r.current, nil }
 func (r *testRepo) UpdateVersion(version string) error {
 	r.current = version
 	return r.err
@@ -51,11 +55,13 @@ func TestVCSInstaller(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer os.Remove(hh)
+	defer os.RemoveAll(hh)
 
 	home := helmpath.Home(hh)
-	if err := os.MkdirAll(home.Plugins(), 0755); err != nil {
-		t.Fatalf("Could not create %s: %s", home.Plugins(), err)
+	for _, dir := range []string{home.Plugins(), home.Path("cache", "plugins")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Could not create %s: %s", dir, err)
+		}
 	}
 
 	source := "https://github.com/adamreese/helm-env"
@@ -87,4 +93,54 @@ func TestVCSInstaller(t *testing.T) {
 	if i.Path() != home.Path("plugins", "helm-env") {
 		t.Errorf("expected path '$HELM_HOME/plugins/helm-env', got %q", i.Path())
 	}
+
+	// The version constraint is recorded for updates.
+	b, err := ioutil.ReadFile(versionFile(home, repo.local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "~0.1.0" {
+		t.Errorf("expected the recorded version '~0.1.0', got %q", b)
+	}
+}
+
+func TestVCSInstallerUpdate(t *testing.T) {
+	hh, err := ioutil.TempDir("", "helm-home-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh)
+
+	home := helmpath.Home(hh)
+	if err := os.MkdirAll(home.Path("cache", "plugins"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	repo := &testRepo{
+		local:   "../testdata/plugdir/echo",
+		remote:  "https://github.com/adamreese/helm-env",
+		current: "0.1.0",
+		tags:    []string{"0.1.0", "0.1.1", "0.2.0"},
+	}
+	i := &VCSInstaller{Repo: repo, Version: "~0.1.0", base: newBase(repo.remote, home)}
+
+	if err := Update(i); err != nil {
+		t.Fatal(err)
+	}
+	if repo.current != "0.1.1" {
+		t.Errorf("expected version '0.1.1', got %q", repo.current)
+	}
+
+	// A version that fails the check is replaced by the installed one again.
+	i.Version = "~0.2.0"
+	if err := UpdateChecked(i, func(string) error { return errors.New("incompatible") }); err == nil {
+		t.Error("expected an error for a failed check")
+	}
+	if repo.current != "0.1.1" {
+		t.Errorf("expected the installed version '0.1.1', got %q", repo.current)
+	}
+
+	repo.dirty = true
+	if err := Update(i); err == nil {
+		t.Error("expected an error for a modified plugin repo")
+	}
 }
@@ -16,11 +16,13 @@ limitations under the License.
 package plugin // import "k8s.io/helm/pkg/plugin"
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/ghodss/yaml"
 )
 
@@ -65,8 +67,23 @@ type Metadata struct {
 	// automatic setting of HELM_HOST.
 	UseTunnel bool `json:"useTunnel"`
 
+	// HelmVersion is a SemVer constraint on the versions of Helm that the
+	// plugin works with (e.g. ">=2.3.0, <3"). If it is empty, the plugin works
+	// with any version of Helm.
+	HelmVersion string `json:"helmVersion,omitempty"`
+
+	// RequiredHelmVersion is the minimum SemVer version of Helm that the plugin
+	// needs. It is the same as a HelmVersion of ">=RequiredHelmVersion".
+	//
+	// Deprecated: use HelmVersion.
+	RequiredHelmVersion string `json:"requiredHelmVersion,omitempty"`
+
 	// Hooks are commands that will run on events.
 	Hooks Hooks
+
+	// Downloaders are the commands that the plugin provides to download
+	// charts and repository indexes from URLs of other protocols than HTTP.
+	Downloaders []Downloader `json:"downloaders,omitempty"`
 }
 
 // Plugin represents a plugin.
@@ -96,6 +113,57 @@ func (p *Plugin) PrepareCommand(extraArgs []string) (string, []string) {
 	return main, baseArgs
 }
 
+// helmVersionConstraint returns the constraint of the plugin on the versions
+// of Helm, which includes the deprecated RequiredHelmVersion.
+func (m *Metadata) helmVersionConstraint() (string, error) {
+	if m.RequiredHelmVersion == "" {
+		return m.HelmVersion, nil
+	}
+	if _, err := semver.NewVersion(m.RequiredHelmVersion); err != nil {
+		return "", fmt.Errorf("plugin %q has an invalid requiredHelmVersion %q: %s", m.Name, m.RequiredHelmVersion, err)
+	}
+	if m.HelmVersion == "" {
+		return ">=" + m.RequiredHelmVersion, nil
+	}
+	return m.HelmVersion + ", >=" + m.RequiredHelmVersion, nil
+}
+
+// CheckHelmVersion returns an error if helmVersion does not satisfy the
+// helmVersion constraint of the plugin.
+//
+// A pre-release of Helm is checked as the release it precedes, so that a
+// release candidate of 2.4.0 loads the plugins that work with 2.4.0. If
+// helmVersion is not a valid SemVer version (e.g. a development build), the
+// check is skipped.
+func (p *Plugin) CheckHelmVersion(helmVersion string) error {
+	constraint, err := p.Metadata.helmVersionConstraint()
+	if err != nil {
+		return err
+	}
+	if constraint == "" {
+		return nil
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("plugin %q has an invalid helmVersion %q: %s", p.Metadata.Name, constraint, err)
+	}
+
+	current, err := semver.NewVersion(helmVersion)
+	if err != nil {
+		return nil
+	}
+	// Constraints never match pre-releases, unless they name one.
+	if current.Prerelease() != "" {
+		if current, err = semver.NewVersion(fmt.Sprintf("%d.%d.%d", current.Major(), current.Minor(), current.Patch())); err != nil {
+			return nil
+		}
+	}
+	if !c.Check(current) {
+		return fmt.Errorf("plugin %q requires Helm %s, but this is Helm %s", p.Metadata.Name, constraint, helmVersion)
+	}
+	return nil
+}
+
 // LoadDir loads a plugin from the given directory.
 func LoadDir(dirname string) (*Plugin, error) {
 	data, err := ioutil.ReadFile(filepath.Join(dirname, PluginFileName))
@@ -136,3 +204,18 @@ func LoadAll(basedir string) ([]*Plugin, error) {
 	}
 	return plugins, nil
 }
+
+// FindPlugins loads all plugins found beneath the directories in plugdirs,
+// a list separated by the OS-specific path list separator.
+func FindPlugins(plugdirs string) ([]*Plugin, error) {
+	found := []*Plugin{}
+	// Let's get all UNIXy and allow path separators
+	for _, p := range filepath.SplitList(plugdirs) {
+		matches, err := LoadAll(p)
+		if err != nil {
+			return matches, err
+		}
+		found = append(found, matches...)
+	}
+	return found, nil
+}
@@ -118,3 +118,72 @@ func TestLoadAll(t *testing.T) {
 		t.Errorf("Expected second plugin to be hello, got %q", plugs[1].Metadata.Name)
 	}
 }
+
+func TestCheckHelmVersion(t *testing.T) {
+	tests := []struct {
+		required   string
+		constraint string
+		current    string
+		err        bool
+	}{
+		{required: "", current: "v2.3.0"},
+		{required: "2.3.0", current: "v2.3.0"},
+		{required: "2.2.0", current: "v2.3.0+unreleased"},
+		{required: "2.4.0", current: "v2.3.0", err: true},
+		{required: "not-a-version", current: "v2.3.0", err: true},
+		{required: "2.4.0", current: "canary"},
+		{constraint: ">=2.3.0, <3", current: "v2.3.0"},
+		{constraint: "~2.2.0", current: "v2.3.0", err: true},
+		{constraint: "<2.3.0", current: "v2.3.0+unreleased", err: true},
+		{constraint: "not-a-constraint", current: "v2.3.0", err: true},
+		{constraint: "^3", current: "canary"},
+		{required: "2.2.0", constraint: "<2.3.0", current: "v2.3.0", err: true},
+		{required: "2.2.0", constraint: "<3", current: "v2.3.0"},
+		{constraint: ">=2.4.0", current: "v2.4.0-rc.1"},
+		{required: "2.4.0", current: "v2.4.0-rc.1+unreleased"},
+		{constraint: "<2.4.0", current: "v2.4.0-rc.1", err: true},
+	}
+
+	for _, tt := range tests {
+		p := &Plugin{Metadata: &Metadata{Name: "test", RequiredHelmVersion: tt.required, HelmVersion: tt.constraint}}
+		if err := p.CheckHelmVersion(tt.current); (err != nil) != tt.err {
+			t.Errorf("required %q, constraint %q, current %q: expected error %v, got %v", tt.required, tt.constraint, tt.current, tt.err, err)
+		}
+	}
+}
+
+func TestFindDownloader(t *testing.T) {
+	plugins := []*Plugin{
+		{Metadata: &Metadata{Name: "hello"}},
+		{Metadata: &Metadata{Name: "s3", Downloaders: []Downloader{
+			{Command: "bin/s3", Protocols: []string{"s3", "s3n"}},
+		}}},
+	}
+
+	for _, proto := range []string{"s3", "S3N"} {
+		if p, d := FindDownloader(plugins, proto); p == nil || d.Command != "bin/s3" {
+			t.Errorf("Expected the s3 downloader for %q, got %v", proto, d)
+		}
+	}
+	if p, _ := FindDownloader(plugins, "gs"); p != nil {
+		t.Errorf("Expected no downloader for gs, got %q", p.Metadata.Name)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	p := &Plugin{Dir: "/tmp", Metadata: &Metadata{Name: "echo"}}
+	d := &Downloader{Command: "echo -n $HELM_PLUGIN_NAME"}
+
+	out, err := p.Download(d, "test://example.com/index.yaml", "cert.pem", "key.pem", "ca.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "echo cert.pem key.pem ca.pem test://example.com/index.yaml"; out.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, out.String())
+	}
+
+	d.Command = "false"
+	if _, err := p.Download(d, "test://example.com/index.yaml", "", "", ""); err == nil {
+		t.Error("Expected a failing downloader to return an error")
+	}
+}
@@ -23,6 +23,9 @@ type Info struct {
 	Deleted *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=deleted" json:"deleted,omitempty"`
 	// Description is human-friendly "log entry" about this release.
 	Description string `protobuf:"bytes,5,opt,name=Description" json:"Description,omitempty"`
+	// Resources are the resources of the release as they exist in the
+	// cluster. They are only set in the response to a status request.
+	Resources []*Resource `protobuf:"bytes,6,rep,name=resources" json:"resources,omitempty"`
 }
 
 func (m *Info) Reset()                    { *m = Info{} }
@@ -58,27 +61,57 @@ func (m *Info) GetDeleted() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *Info) GetResources() []*Resource {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+// Resource describes a Kubernetes resource of a release.
+type Resource struct {
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion" json:"api_version,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	Namespace  string `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
+	// Object is the resource in the cluster, encoded as JSON. It is empty if
+	// the resource does not exist.
+	Object []byte `protobuf:"bytes,5,opt,name=object,proto3" json:"object,omitempty"`
+}
+
+func (m *Resource) Reset()                    { *m = Resource{} }
+func (m *Resource) String() string            { return proto.CompactTextString(m) }
+func (*Resource) ProtoMessage()               {}
+func (*Resource) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
 func init() {
 	proto.RegisterType((*Info)(nil), "hapi.release.Info")
+	proto.RegisterType((*Resource)(nil), "hapi.release.Resource")
 }
 
 func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x8f, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x95, 0x52, 0x5a, 0xd5, 0x6d, 0x19, 0x2c, 0x24, 0x42, 0x16, 0x22, 0xa6, 0x0e, 0xc8,
-	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0xe2, 0x73, 0xb1, 0xe4, 0xe6, 0x2c,
-	0xfb, 0x3a, 0xf0, 0x2f, 0xf8, 0xc9, 0xa8, 0xb6, 0x83, 0xd2, 0xa9, 0xab, 0xbf, 0xf7, 0x3e, 0xbf,
-	0x63, 0x57, 0xdf, 0xd2, 0x99, 0xc6, 0x83, 0x05, 0x19, 0xa0, 0x31, 0x9d, 0x46, 0xe1, 0x3c, 0x12,
-	0xf2, 0xc5, 0x01, 0x88, 0x0c, 0xaa, 0x9b, 0x2d, 0xe2, 0xd6, 0x42, 0x13, 0xd9, 0x66, 0xaf, 0x1b,
-	0x32, 0x3b, 0x08, 0x24, 0x77, 0x2e, 0xc5, 0xab, 0xeb, 0x23, 0x4f, 0x20, 0x49, 0xfb, 0x90, 0xd0,
-	0xed, 0xef, 0x88, 0x8d, 0x5f, 0x3b, 0x8d, 0xfc, 0x8e, 0x4d, 0x12, 0x28, 0x8b, 0xba, 0x58, 0xcd,
-	0xef, 0x2f, 0xc5, 0xf0, 0x0f, 0xf1, 0x16, 0x59, 0x9b, 0x33, 0xfc, 0x99, 0x5d, 0x68, 0xe3, 0x03,
-	0x7d, 0x2a, 0x70, 0x16, 0x7f, 0x40, 0x95, 0xa3, 0xd8, 0xaa, 0x44, 0xda, 0x22, 0xfa, 0x2d, 0xe2,
-	0xbd, 0xdf, 0xd2, 0x2e, 0x63, 0x63, 0x9d, 0x0b, 0xfc, 0x89, 0x2d, 0xad, 0x1c, 0x1a, 0xce, 0x4e,
-	0x1a, 0x16, 0x87, 0xc2, 0xbf, 0xe0, 0x91, 0x4d, 0x15, 0x58, 0x20, 0x50, 0xe5, 0xf8, 0x64, 0xb5,
-	0x8f, 0xf2, 0x9a, 0xcd, 0xd7, 0x10, 0xbe, 0xbc, 0x71, 0x64, 0xb0, 0x2b, 0xcf, 0xeb, 0x62, 0x35,
-	0x6b, 0x87, 0x4f, 0x2f, 0xb3, 0x8f, 0x69, 0xbe, 0x7a, 0x33, 0x89, 0xa6, 0x87, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x1a, 0x52, 0x8f, 0x9c, 0x89, 0x01, 0x00, 0x00,
+	// 329 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x50, 0x41, 0x4f, 0xf2, 0x40,
+	0x14, 0x4c, 0x81, 0xaf, 0x7c, 0x7d, 0x05, 0x0f, 0x1b, 0x83, 0x95, 0x98, 0xd0, 0x70, 0xe2, 0x60,
+	0xb6, 0x09, 0x72, 0x37, 0x1a, 0x2e, 0x5e, 0x57, 0xe3, 0xc1, 0x0b, 0x59, 0xda, 0x57, 0x5c, 0x2d,
+	0xdd, 0xcd, 0xee, 0x62, 0xe2, 0x4f, 0xf0, 0x8f, 0xf9, 0xbb, 0x4c, 0xb7, 0x6d, 0x28, 0x27, 0x4e,
+	0xdd, 0xce, 0xbc, 0x99, 0x37, 0x6f, 0xe0, 0xea, 0x9d, 0x2b, 0x91, 0x68, 0x2c, 0x90, 0x1b, 0x4c,
+	0x44, 0x99, 0x4b, 0xaa, 0xb4, 0xb4, 0x92, 0x8c, 0x2a, 0x82, 0x36, 0xc4, 0x74, 0xb6, 0x93, 0x72,
+	0x57, 0x60, 0xe2, 0xb8, 0xed, 0x21, 0x4f, 0xac, 0xd8, 0xa3, 0xb1, 0x7c, 0xaf, 0xea, 0xf1, 0xe9,
+	0xf5, 0x89, 0x8f, 0xb1, 0xdc, 0x1e, 0x4c, 0x4d, 0xcd, 0x7f, 0x7b, 0x30, 0x78, 0x2a, 0x73, 0x49,
+	0x6e, 0xc1, 0xaf, 0x89, 0xc8, 0x8b, 0xbd, 0x45, 0xb8, 0xbc, 0xa4, 0xdd, 0x1d, 0xf4, 0xd9, 0x71,
+	0xac, 0x99, 0x21, 0x0f, 0x70, 0x91, 0x0b, 0x6d, 0xec, 0x26, 0x43, 0x55, 0xc8, 0x6f, 0xcc, 0xa2,
+	0x9e, 0x53, 0x4d, 0x69, 0x9d, 0x85, 0xb6, 0x59, 0xe8, 0x4b, 0x9b, 0x85, 0x8d, 0x9d, 0x62, 0xdd,
+	0x08, 0xc8, 0x3d, 0x8c, 0x0b, 0xde, 0x75, 0xe8, 0x9f, 0x75, 0x18, 0x15, 0xbc, 0x63, 0xb0, 0x82,
+	0x61, 0x86, 0x05, 0x5a, 0xcc, 0xa2, 0xc1, 0x59, 0x69, 0x3b, 0x4a, 0x62, 0x08, 0xd7, 0x68, 0x52,
+	0x2d, 0x94, 0x15, 0xb2, 0x8c, 0xfe, 0xc5, 0xde, 0x22, 0x60, 0x5d, 0x88, 0xac, 0x20, 0xd0, 0x68,
+	0xe4, 0x41, 0xa7, 0x68, 0x22, 0x3f, 0xee, 0x2f, 0xc2, 0xe5, 0xe4, 0xb4, 0x0c, 0xd6, 0xd0, 0xec,
+	0x38, 0x38, 0xff, 0xf1, 0xe0, 0x7f, 0x8b, 0x93, 0x19, 0x84, 0x5c, 0x89, 0xcd, 0x17, 0x6a, 0x53,
+	0x2d, 0xf1, 0xdc, 0x12, 0xe0, 0x4a, 0xbc, 0xd6, 0x08, 0x21, 0x30, 0xf8, 0x14, 0x65, 0xdd, 0x5a,
+	0xc0, 0xdc, 0xbb, 0xc2, 0x4a, 0xbe, 0x47, 0xd7, 0x43, 0xc0, 0xdc, 0x9b, 0xdc, 0x40, 0x50, 0x7d,
+	0x8d, 0xe2, 0x29, 0xba, 0x2b, 0x03, 0x76, 0x04, 0xc8, 0x04, 0x7c, 0xb9, 0xfd, 0xc0, 0xd4, 0xba,
+	0x33, 0x46, 0xac, 0xf9, 0x7b, 0x0c, 0xde, 0x86, 0x4d, 0xd4, 0xad, 0xef, 0xba, 0xb8, 0xfb, 0x1b,
+	0x00, 0x69, 0x80, 0x27, 0x1a, 0x4b, 0x02, 0x00, 0x00,
 }
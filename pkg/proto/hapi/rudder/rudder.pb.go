@@ -0,0 +1,1009 @@
+// Code generated by protoc-gen-go.
+// source: hapi/rudder/rudder.proto
+// DO NOT EDIT!
+
+/*
+Package rudder is a generated protocol buffer package.
+
+It is generated from these files:
+	hapi/rudder/rudder.proto
+
+It has these top-level messages:
+	VersionReleaseRequest
+	VersionReleaseResponse
+	InstallReleaseRequest
+	InstallReleaseResponse
+	UpgradeReleaseRequest
+	UpgradeReleaseResponse
+	RollbackReleaseRequest
+	RollbackReleaseResponse
+	ReleaseStatusRequest
+	ReleaseStatusResponse
+	DeleteReleaseRequest
+	DeleteReleaseResponse
+	WaitForConditionsRequest
+	WaitForConditionsResponse
+	ExecHookRequest
+	ExecHookResponse
+	DeleteHookRequest
+	DeleteHookResponse
+	CreateTestPodRequest
+	CreateTestPodResponse
+	WaitForTestPodRequest
+	WaitForTestPodResponse
+	TestPodLogsRequest
+	TestPodLogsResponse
+	DeleteTestPodRequest
+	DeleteTestPodResponse
+*/
+package rudder
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import hapi_release "k8s.io/helm/pkg/proto/hapi/release"
+import hapi_release4 "k8s.io/helm/pkg/proto/hapi/release"
+import hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type VersionReleaseRequest struct {
+}
+
+func (m *VersionReleaseRequest) Reset()                    { *m = VersionReleaseRequest{} }
+func (m *VersionReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*VersionReleaseRequest) ProtoMessage()               {}
+func (*VersionReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type VersionReleaseResponse struct {
+	// Name is the name of the release module.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Version is the version of the release module.
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *VersionReleaseResponse) Reset()                    { *m = VersionReleaseResponse{} }
+func (m *VersionReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*VersionReleaseResponse) ProtoMessage()               {}
+func (*VersionReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type InstallReleaseRequest struct {
+	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// Timeout is the number of seconds to wait for the resources.
+	Timeout int64 `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+	// Wait specifies whether to wait until the resources are ready.
+	Wait bool `protobuf:"varint,3,opt,name=wait" json:"wait,omitempty"`
+}
+
+func (m *InstallReleaseRequest) Reset()                    { *m = InstallReleaseRequest{} }
+func (m *InstallReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*InstallReleaseRequest) ProtoMessage()               {}
+func (*InstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *InstallReleaseRequest) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+type InstallReleaseResponse struct {
+}
+
+func (m *InstallReleaseResponse) Reset()                    { *m = InstallReleaseResponse{} }
+func (m *InstallReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*InstallReleaseResponse) ProtoMessage()               {}
+func (*InstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type UpgradeReleaseRequest struct {
+	// Current is the deployed revision of the release.
+	Current *hapi_release5.Release `protobuf:"bytes,1,opt,name=current" json:"current,omitempty"`
+	// Target is the revision to upgrade to.
+	Target *hapi_release5.Release `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
+	// Force specifies whether resources that cannot be patched are recreated.
+	Force bool `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+	// Recreate specifies whether the pods of the release are restarted.
+	Recreate bool  `protobuf:"varint,4,opt,name=recreate" json:"recreate,omitempty"`
+	Timeout  int64 `protobuf:"varint,5,opt,name=timeout" json:"timeout,omitempty"`
+	Wait     bool  `protobuf:"varint,6,opt,name=wait" json:"wait,omitempty"`
+}
+
+func (m *UpgradeReleaseRequest) Reset()                    { *m = UpgradeReleaseRequest{} }
+func (m *UpgradeReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpgradeReleaseRequest) ProtoMessage()               {}
+func (*UpgradeReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *UpgradeReleaseRequest) GetCurrent() *hapi_release5.Release {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+func (m *UpgradeReleaseRequest) GetTarget() *hapi_release5.Release {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+type UpgradeReleaseResponse struct {
+	// Created are the resources the upgrade created, as kind/namespace/name.
+	Created []string `protobuf:"bytes,1,rep,name=created" json:"created,omitempty"`
+	// Error is the failure of the upgrade, if any. It is not returned as an
+	// RPC error so that created is reported also when the upgrade fails.
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *UpgradeReleaseResponse) Reset()                    { *m = UpgradeReleaseResponse{} }
+func (m *UpgradeReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpgradeReleaseResponse) ProtoMessage()               {}
+func (*UpgradeReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type RollbackReleaseRequest struct {
+	// Current is the deployed revision of the release.
+	Current *hapi_release5.Release `protobuf:"bytes,1,opt,name=current" json:"current,omitempty"`
+	// Target is the revision to roll back to.
+	Target   *hapi_release5.Release `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
+	Force    bool                   `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+	Recreate bool                   `protobuf:"varint,4,opt,name=recreate" json:"recreate,omitempty"`
+	Timeout  int64                  `protobuf:"varint,5,opt,name=timeout" json:"timeout,omitempty"`
+	Wait     bool                   `protobuf:"varint,6,opt,name=wait" json:"wait,omitempty"`
+}
+
+func (m *RollbackReleaseRequest) Reset()                    { *m = RollbackReleaseRequest{} }
+func (m *RollbackReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*RollbackReleaseRequest) ProtoMessage()               {}
+func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *RollbackReleaseRequest) GetCurrent() *hapi_release5.Release {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+func (m *RollbackReleaseRequest) GetTarget() *hapi_release5.Release {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+type RollbackReleaseResponse struct {
+}
+
+func (m *RollbackReleaseResponse) Reset()                    { *m = RollbackReleaseResponse{} }
+func (m *RollbackReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*RollbackReleaseResponse) ProtoMessage()               {}
+func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type ReleaseStatusRequest struct {
+	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+}
+
+func (m *ReleaseStatusRequest) Reset()                    { *m = ReleaseStatusRequest{} }
+func (m *ReleaseStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseStatusRequest) ProtoMessage()               {}
+func (*ReleaseStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ReleaseStatusRequest) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+type ReleaseStatusResponse struct {
+	// Resources is the status of the resources as kubectl prints it.
+	Resources string `protobuf:"bytes,1,opt,name=resources" json:"resources,omitempty"`
+	// ResourceList are the resources as they exist in the cluster.
+	ResourceList []*hapi_release4.Resource `protobuf:"bytes,2,rep,name=resource_list,json=resourceList" json:"resource_list,omitempty"`
+}
+
+func (m *ReleaseStatusResponse) Reset()                    { *m = ReleaseStatusResponse{} }
+func (m *ReleaseStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseStatusResponse) ProtoMessage()               {}
+func (*ReleaseStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ReleaseStatusResponse) GetResourceList() []*hapi_release4.Resource {
+	if m != nil {
+		return m.ResourceList
+	}
+	return nil
+}
+
+type DeleteReleaseRequest struct {
+	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// Manifests are the resources to delete, in the order to delete them in.
+	Manifests []string `protobuf:"bytes,2,rep,name=manifests" json:"manifests,omitempty"`
+}
+
+func (m *DeleteReleaseRequest) Reset()                    { *m = DeleteReleaseRequest{} }
+func (m *DeleteReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteReleaseRequest) ProtoMessage()               {}
+func (*DeleteReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *DeleteReleaseRequest) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+type DeleteReleaseResponse struct {
+	// Errors are the failures of the deletion of individual resources.
+	Errors []string `protobuf:"bytes,1,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *DeleteReleaseResponse) Reset()                    { *m = DeleteReleaseResponse{} }
+func (m *DeleteReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteReleaseResponse) ProtoMessage()               {}
+func (*DeleteReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+type WaitForConditionsRequest struct {
+	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	Timeout int64                  `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+	// Conditions maps the kinds of resources to the condition they have to report.
+	Conditions map[string]string `protobuf:"bytes,3,rep,name=conditions" json:"conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *WaitForConditionsRequest) Reset()                    { *m = WaitForConditionsRequest{} }
+func (m *WaitForConditionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*WaitForConditionsRequest) ProtoMessage()               {}
+func (*WaitForConditionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *WaitForConditionsRequest) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func (m *WaitForConditionsRequest) GetConditions() map[string]string {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+type WaitForConditionsResponse struct {
+}
+
+func (m *WaitForConditionsResponse) Reset()                    { *m = WaitForConditionsResponse{} }
+func (m *WaitForConditionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*WaitForConditionsResponse) ProtoMessage()               {}
+func (*WaitForConditionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+type ExecHookRequest struct {
+	Namespace string             `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Hook      *hapi_release.Hook `protobuf:"bytes,2,opt,name=hook" json:"hook,omitempty"`
+	// Event is the event the hook is executed for.
+	Event   hapi_release.Hook_Event `protobuf:"varint,3,opt,name=event,enum=hapi.release.Hook_Event" json:"event,omitempty"`
+	Timeout int64                   `protobuf:"varint,4,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *ExecHookRequest) Reset()                    { *m = ExecHookRequest{} }
+func (m *ExecHookRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExecHookRequest) ProtoMessage()               {}
+func (*ExecHookRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ExecHookRequest) GetHook() *hapi_release.Hook {
+	if m != nil {
+		return m.Hook
+	}
+	return nil
+}
+
+type ExecHookResponse struct {
+}
+
+func (m *ExecHookResponse) Reset()                    { *m = ExecHookResponse{} }
+func (m *ExecHookResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExecHookResponse) ProtoMessage()               {}
+func (*ExecHookResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+type DeleteHookRequest struct {
+	Namespace string             `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Hook      *hapi_release.Hook `protobuf:"bytes,2,opt,name=hook" json:"hook,omitempty"`
+}
+
+func (m *DeleteHookRequest) Reset()                    { *m = DeleteHookRequest{} }
+func (m *DeleteHookRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteHookRequest) ProtoMessage()               {}
+func (*DeleteHookRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *DeleteHookRequest) GetHook() *hapi_release.Hook {
+	if m != nil {
+		return m.Hook
+	}
+	return nil
+}
+
+type DeleteHookResponse struct {
+}
+
+func (m *DeleteHookResponse) Reset()                    { *m = DeleteHookResponse{} }
+func (m *DeleteHookResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteHookResponse) ProtoMessage()               {}
+func (*DeleteHookResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+type CreateTestPodRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Manifest  string `protobuf:"bytes,2,opt,name=manifest" json:"manifest,omitempty"`
+	Timeout   int64  `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *CreateTestPodRequest) Reset()                    { *m = CreateTestPodRequest{} }
+func (m *CreateTestPodRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateTestPodRequest) ProtoMessage()               {}
+func (*CreateTestPodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type CreateTestPodResponse struct {
+}
+
+func (m *CreateTestPodResponse) Reset()                    { *m = CreateTestPodResponse{} }
+func (m *CreateTestPodResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateTestPodResponse) ProtoMessage()               {}
+func (*CreateTestPodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+type WaitForTestPodRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Manifest  string `protobuf:"bytes,2,opt,name=manifest" json:"manifest,omitempty"`
+	Timeout   int64  `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *WaitForTestPodRequest) Reset()                    { *m = WaitForTestPodRequest{} }
+func (m *WaitForTestPodRequest) String() string            { return proto.CompactTextString(m) }
+func (*WaitForTestPodRequest) ProtoMessage()               {}
+func (*WaitForTestPodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type WaitForTestPodResponse struct {
+	// Phase is the phase the pod completed in.
+	Phase string `protobuf:"bytes,1,opt,name=phase" json:"phase,omitempty"`
+}
+
+func (m *WaitForTestPodResponse) Reset()                    { *m = WaitForTestPodResponse{} }
+func (m *WaitForTestPodResponse) String() string            { return proto.CompactTextString(m) }
+func (*WaitForTestPodResponse) ProtoMessage()               {}
+func (*WaitForTestPodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+type TestPodLogsRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Manifest  string `protobuf:"bytes,2,opt,name=manifest" json:"manifest,omitempty"`
+}
+
+func (m *TestPodLogsRequest) Reset()                    { *m = TestPodLogsRequest{} }
+func (m *TestPodLogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*TestPodLogsRequest) ProtoMessage()               {}
+func (*TestPodLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+type TestPodLogsResponse struct {
+	Logs string `protobuf:"bytes,1,opt,name=logs" json:"logs,omitempty"`
+}
+
+func (m *TestPodLogsResponse) Reset()                    { *m = TestPodLogsResponse{} }
+func (m *TestPodLogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*TestPodLogsResponse) ProtoMessage()               {}
+func (*TestPodLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+type DeleteTestPodRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	Manifest  string `protobuf:"bytes,2,opt,name=manifest" json:"manifest,omitempty"`
+}
+
+func (m *DeleteTestPodRequest) Reset()                    { *m = DeleteTestPodRequest{} }
+func (m *DeleteTestPodRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteTestPodRequest) ProtoMessage()               {}
+func (*DeleteTestPodRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+type DeleteTestPodResponse struct {
+}
+
+func (m *DeleteTestPodResponse) Reset()                    { *m = DeleteTestPodResponse{} }
+func (m *DeleteTestPodResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteTestPodResponse) ProtoMessage()               {}
+func (*DeleteTestPodResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func init() {
+	proto.RegisterType((*VersionReleaseRequest)(nil), "hapi.services.rudder.VersionReleaseRequest")
+	proto.RegisterType((*VersionReleaseResponse)(nil), "hapi.services.rudder.VersionReleaseResponse")
+	proto.RegisterType((*InstallReleaseRequest)(nil), "hapi.services.rudder.InstallReleaseRequest")
+	proto.RegisterType((*InstallReleaseResponse)(nil), "hapi.services.rudder.InstallReleaseResponse")
+	proto.RegisterType((*UpgradeReleaseRequest)(nil), "hapi.services.rudder.UpgradeReleaseRequest")
+	proto.RegisterType((*UpgradeReleaseResponse)(nil), "hapi.services.rudder.UpgradeReleaseResponse")
+	proto.RegisterType((*RollbackReleaseRequest)(nil), "hapi.services.rudder.RollbackReleaseRequest")
+	proto.RegisterType((*RollbackReleaseResponse)(nil), "hapi.services.rudder.RollbackReleaseResponse")
+	proto.RegisterType((*ReleaseStatusRequest)(nil), "hapi.services.rudder.ReleaseStatusRequest")
+	proto.RegisterType((*ReleaseStatusResponse)(nil), "hapi.services.rudder.ReleaseStatusResponse")
+	proto.RegisterType((*DeleteReleaseRequest)(nil), "hapi.services.rudder.DeleteReleaseRequest")
+	proto.RegisterType((*DeleteReleaseResponse)(nil), "hapi.services.rudder.DeleteReleaseResponse")
+	proto.RegisterType((*WaitForConditionsRequest)(nil), "hapi.services.rudder.WaitForConditionsRequest")
+	proto.RegisterType((*WaitForConditionsResponse)(nil), "hapi.services.rudder.WaitForConditionsResponse")
+	proto.RegisterType((*ExecHookRequest)(nil), "hapi.services.rudder.ExecHookRequest")
+	proto.RegisterType((*ExecHookResponse)(nil), "hapi.services.rudder.ExecHookResponse")
+	proto.RegisterType((*DeleteHookRequest)(nil), "hapi.services.rudder.DeleteHookRequest")
+	proto.RegisterType((*DeleteHookResponse)(nil), "hapi.services.rudder.DeleteHookResponse")
+	proto.RegisterType((*CreateTestPodRequest)(nil), "hapi.services.rudder.CreateTestPodRequest")
+	proto.RegisterType((*CreateTestPodResponse)(nil), "hapi.services.rudder.CreateTestPodResponse")
+	proto.RegisterType((*WaitForTestPodRequest)(nil), "hapi.services.rudder.WaitForTestPodRequest")
+	proto.RegisterType((*WaitForTestPodResponse)(nil), "hapi.services.rudder.WaitForTestPodResponse")
+	proto.RegisterType((*TestPodLogsRequest)(nil), "hapi.services.rudder.TestPodLogsRequest")
+	proto.RegisterType((*TestPodLogsResponse)(nil), "hapi.services.rudder.TestPodLogsResponse")
+	proto.RegisterType((*DeleteTestPodRequest)(nil), "hapi.services.rudder.DeleteTestPodRequest")
+	proto.RegisterType((*DeleteTestPodResponse)(nil), "hapi.services.rudder.DeleteTestPodResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion3
+
+// Client API for ReleaseModuleService service
+
+type ReleaseModuleServiceClient interface {
+	// Version returns the name and version of the release module.
+	Version(ctx context.Context, in *VersionReleaseRequest, opts ...grpc.CallOption) (*VersionReleaseResponse, error)
+	// InstallRelease creates the resources of a release.
+	InstallRelease(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (*InstallReleaseResponse, error)
+	// UpgradeRelease updates the resources of a release to a new revision.
+	UpgradeRelease(ctx context.Context, in *UpgradeReleaseRequest, opts ...grpc.CallOption) (*UpgradeReleaseResponse, error)
+	// RollbackRelease updates the resources of a release to an earlier revision.
+	RollbackRelease(ctx context.Context, in *RollbackReleaseRequest, opts ...grpc.CallOption) (*RollbackReleaseResponse, error)
+	// ReleaseStatus returns the status of the resources of a release.
+	ReleaseStatus(ctx context.Context, in *ReleaseStatusRequest, opts ...grpc.CallOption) (*ReleaseStatusResponse, error)
+	// DeleteRelease deletes resources of a release.
+	DeleteRelease(ctx context.Context, in *DeleteReleaseRequest, opts ...grpc.CallOption) (*DeleteReleaseResponse, error)
+	// WaitForConditions waits until the resources of a release report status conditions.
+	WaitForConditions(ctx context.Context, in *WaitForConditionsRequest, opts ...grpc.CallOption) (*WaitForConditionsResponse, error)
+	// ExecHook creates the resources of a hook and waits until they are ready.
+	ExecHook(ctx context.Context, in *ExecHookRequest, opts ...grpc.CallOption) (*ExecHookResponse, error)
+	// DeleteHook deletes the resources of a hook.
+	DeleteHook(ctx context.Context, in *DeleteHookRequest, opts ...grpc.CallOption) (*DeleteHookResponse, error)
+	// CreateTestPod creates the pod of a release test.
+	CreateTestPod(ctx context.Context, in *CreateTestPodRequest, opts ...grpc.CallOption) (*CreateTestPodResponse, error)
+	// WaitForTestPod waits until the pod of a release test completed.
+	WaitForTestPod(ctx context.Context, in *WaitForTestPodRequest, opts ...grpc.CallOption) (*WaitForTestPodResponse, error)
+	// TestPodLogs returns the logs of the pod of a release test.
+	TestPodLogs(ctx context.Context, in *TestPodLogsRequest, opts ...grpc.CallOption) (*TestPodLogsResponse, error)
+	// DeleteTestPod deletes the pod of a release test.
+	DeleteTestPod(ctx context.Context, in *DeleteTestPodRequest, opts ...grpc.CallOption) (*DeleteTestPodResponse, error)
+}
+
+type releaseModuleServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewReleaseModuleServiceClient(cc *grpc.ClientConn) ReleaseModuleServiceClient {
+	return &releaseModuleServiceClient{cc}
+}
+
+func (c *releaseModuleServiceClient) Version(ctx context.Context, in *VersionReleaseRequest, opts ...grpc.CallOption) (*VersionReleaseResponse, error) {
+	out := new(VersionReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/Version", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) InstallRelease(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (*InstallReleaseResponse, error) {
+	out := new(InstallReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/InstallRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) UpgradeRelease(ctx context.Context, in *UpgradeReleaseRequest, opts ...grpc.CallOption) (*UpgradeReleaseResponse, error) {
+	out := new(UpgradeReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/UpgradeRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) RollbackRelease(ctx context.Context, in *RollbackReleaseRequest, opts ...grpc.CallOption) (*RollbackReleaseResponse, error) {
+	out := new(RollbackReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/RollbackRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) ReleaseStatus(ctx context.Context, in *ReleaseStatusRequest, opts ...grpc.CallOption) (*ReleaseStatusResponse, error) {
+	out := new(ReleaseStatusResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/ReleaseStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) DeleteRelease(ctx context.Context, in *DeleteReleaseRequest, opts ...grpc.CallOption) (*DeleteReleaseResponse, error) {
+	out := new(DeleteReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/DeleteRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) WaitForConditions(ctx context.Context, in *WaitForConditionsRequest, opts ...grpc.CallOption) (*WaitForConditionsResponse, error) {
+	out := new(WaitForConditionsResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/WaitForConditions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) ExecHook(ctx context.Context, in *ExecHookRequest, opts ...grpc.CallOption) (*ExecHookResponse, error) {
+	out := new(ExecHookResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/ExecHook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) DeleteHook(ctx context.Context, in *DeleteHookRequest, opts ...grpc.CallOption) (*DeleteHookResponse, error) {
+	out := new(DeleteHookResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/DeleteHook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) CreateTestPod(ctx context.Context, in *CreateTestPodRequest, opts ...grpc.CallOption) (*CreateTestPodResponse, error) {
+	out := new(CreateTestPodResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/CreateTestPod", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) WaitForTestPod(ctx context.Context, in *WaitForTestPodRequest, opts ...grpc.CallOption) (*WaitForTestPodResponse, error) {
+	out := new(WaitForTestPodResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/WaitForTestPod", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) TestPodLogs(ctx context.Context, in *TestPodLogsRequest, opts ...grpc.CallOption) (*TestPodLogsResponse, error) {
+	out := new(TestPodLogsResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/TestPodLogs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseModuleServiceClient) DeleteTestPod(ctx context.Context, in *DeleteTestPodRequest, opts ...grpc.CallOption) (*DeleteTestPodResponse, error) {
+	out := new(DeleteTestPodResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.rudder.ReleaseModuleService/DeleteTestPod", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ReleaseModuleService service
+
+type ReleaseModuleServiceServer interface {
+	// Version returns the name and version of the release module.
+	Version(context.Context, *VersionReleaseRequest) (*VersionReleaseResponse, error)
+	// InstallRelease creates the resources of a release.
+	InstallRelease(context.Context, *InstallReleaseRequest) (*InstallReleaseResponse, error)
+	// UpgradeRelease updates the resources of a release to a new revision.
+	UpgradeRelease(context.Context, *UpgradeReleaseRequest) (*UpgradeReleaseResponse, error)
+	// RollbackRelease updates the resources of a release to an earlier revision.
+	RollbackRelease(context.Context, *RollbackReleaseRequest) (*RollbackReleaseResponse, error)
+	// ReleaseStatus returns the status of the resources of a release.
+	ReleaseStatus(context.Context, *ReleaseStatusRequest) (*ReleaseStatusResponse, error)
+	// DeleteRelease deletes resources of a release.
+	DeleteRelease(context.Context, *DeleteReleaseRequest) (*DeleteReleaseResponse, error)
+	// WaitForConditions waits until the resources of a release report status conditions.
+	WaitForConditions(context.Context, *WaitForConditionsRequest) (*WaitForConditionsResponse, error)
+	// ExecHook creates the resources of a hook and waits until they are ready.
+	ExecHook(context.Context, *ExecHookRequest) (*ExecHookResponse, error)
+	// DeleteHook deletes the resources of a hook.
+	DeleteHook(context.Context, *DeleteHookRequest) (*DeleteHookResponse, error)
+	// CreateTestPod creates the pod of a release test.
+	CreateTestPod(context.Context, *CreateTestPodRequest) (*CreateTestPodResponse, error)
+	// WaitForTestPod waits until the pod of a release test completed.
+	WaitForTestPod(context.Context, *WaitForTestPodRequest) (*WaitForTestPodResponse, error)
+	// TestPodLogs returns the logs of the pod of a release test.
+	TestPodLogs(context.Context, *TestPodLogsRequest) (*TestPodLogsResponse, error)
+	// DeleteTestPod deletes the pod of a release test.
+	DeleteTestPod(context.Context, *DeleteTestPodRequest) (*DeleteTestPodResponse, error)
+}
+
+func RegisterReleaseModuleServiceServer(s *grpc.Server, srv ReleaseModuleServiceServer) {
+	s.RegisterService(&_ReleaseModuleService_serviceDesc, srv)
+}
+
+func _ReleaseModuleService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).Version(ctx, req.(*VersionReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_InstallRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).InstallRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/InstallRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).InstallRelease(ctx, req.(*InstallReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_UpgradeRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).UpgradeRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/UpgradeRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).UpgradeRelease(ctx, req.(*UpgradeReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_RollbackRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).RollbackRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/RollbackRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).RollbackRelease(ctx, req.(*RollbackReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_ReleaseStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).ReleaseStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/ReleaseStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).ReleaseStatus(ctx, req.(*ReleaseStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_DeleteRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).DeleteRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/DeleteRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).DeleteRelease(ctx, req.(*DeleteReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_WaitForConditions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitForConditionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).WaitForConditions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/WaitForConditions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).WaitForConditions(ctx, req.(*WaitForConditionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_ExecHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).ExecHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/ExecHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).ExecHook(ctx, req.(*ExecHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_DeleteHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).DeleteHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/DeleteHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).DeleteHook(ctx, req.(*DeleteHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_CreateTestPod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTestPodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).CreateTestPod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/CreateTestPod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).CreateTestPod(ctx, req.(*CreateTestPodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_WaitForTestPod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitForTestPodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).WaitForTestPod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/WaitForTestPod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).WaitForTestPod(ctx, req.(*WaitForTestPodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_TestPodLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestPodLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).TestPodLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/TestPodLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).TestPodLogs(ctx, req.(*TestPodLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseModuleService_DeleteTestPod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTestPodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseModuleServiceServer).DeleteTestPod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.rudder.ReleaseModuleService/DeleteTestPod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseModuleServiceServer).DeleteTestPod(ctx, req.(*DeleteTestPodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ReleaseModuleService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hapi.services.rudder.ReleaseModuleService",
+	HandlerType: (*ReleaseModuleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Version",
+			Handler:    _ReleaseModuleService_Version_Handler,
+		},
+		{
+			MethodName: "InstallRelease",
+			Handler:    _ReleaseModuleService_InstallRelease_Handler,
+		},
+		{
+			MethodName: "UpgradeRelease",
+			Handler:    _ReleaseModuleService_UpgradeRelease_Handler,
+		},
+		{
+			MethodName: "RollbackRelease",
+			Handler:    _ReleaseModuleService_RollbackRelease_Handler,
+		},
+		{
+			MethodName: "ReleaseStatus",
+			Handler:    _ReleaseModuleService_ReleaseStatus_Handler,
+		},
+		{
+			MethodName: "DeleteRelease",
+			Handler:    _ReleaseModuleService_DeleteRelease_Handler,
+		},
+		{
+			MethodName: "WaitForConditions",
+			Handler:    _ReleaseModuleService_WaitForConditions_Handler,
+		},
+		{
+			MethodName: "ExecHook",
+			Handler:    _ReleaseModuleService_ExecHook_Handler,
+		},
+		{
+			MethodName: "DeleteHook",
+			Handler:    _ReleaseModuleService_DeleteHook_Handler,
+		},
+		{
+			MethodName: "CreateTestPod",
+			Handler:    _ReleaseModuleService_CreateTestPod_Handler,
+		},
+		{
+			MethodName: "WaitForTestPod",
+			Handler:    _ReleaseModuleService_WaitForTestPod_Handler,
+		},
+		{
+			MethodName: "TestPodLogs",
+			Handler:    _ReleaseModuleService_TestPodLogs_Handler,
+		},
+		{
+			MethodName: "DeleteTestPod",
+			Handler:    _ReleaseModuleService_DeleteTestPod_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: fileDescriptor0,
+}
+
+func init() { proto.RegisterFile("hapi/rudder/rudder.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 946 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0xc6, 0x71, 0xe2, 0xd8, 0x27, 0xb4, 0x69, 0x17, 0xdb, 0x51, 0x97, 0x5c, 0x64, 0x34, 0x43,
+	0x49, 0x71, 0x2a, 0xcf, 0x84, 0x1b, 0x06, 0x06, 0x2e, 0x28, 0x29, 0x65, 0xa6, 0x30, 0x1d, 0x95,
+	0x9f, 0x01, 0x66, 0x60, 0x54, 0xf9, 0x24, 0x51, 0xad, 0x68, 0xcd, 0xee, 0xca, 0xd0, 0x7b, 0xee,
+	0x78, 0x0a, 0x9e, 0x0a, 0x1e, 0x87, 0xd1, 0xfe, 0xc8, 0x92, 0x2c, 0x61, 0x41, 0x03, 0x17, 0xbd,
+	0xb2, 0xce, 0x9e, 0x2f, 0xe7, 0x3b, 0x7b, 0x7e, 0x37, 0xe0, 0x5c, 0x06, 0x8b, 0x68, 0xca, 0xd3,
+	0xd9, 0x0c, 0xb9, 0xf9, 0xf1, 0x16, 0x9c, 0x49, 0x46, 0x86, 0x99, 0xc6, 0x13, 0xc8, 0x97, 0x51,
+	0x88, 0xc2, 0xd3, 0x3a, 0x7a, 0xa0, 0xf1, 0x18, 0x63, 0x20, 0x70, 0x7a, 0xc9, 0xd8, 0x5c, 0xc3,
+	0x2b, 0x8a, 0x28, 0x39, 0x67, 0x46, 0x41, 0x4b, 0x0a, 0xf3, 0xab, 0x75, 0xee, 0x01, 0x8c, 0xbe,
+	0x46, 0x2e, 0x22, 0x96, 0xf8, 0xfa, 0xdc, 0xc7, 0x9f, 0x52, 0x14, 0xd2, 0x7d, 0x08, 0xe3, 0xaa,
+	0x42, 0x2c, 0x58, 0x22, 0x90, 0x10, 0xd8, 0x4e, 0x82, 0x2b, 0x74, 0x3a, 0x47, 0x9d, 0xe3, 0x81,
+	0xaf, 0xbe, 0x89, 0x03, 0xbb, 0x4b, 0x8d, 0x76, 0xb6, 0xd4, 0xb1, 0x15, 0xdd, 0x25, 0x8c, 0x3e,
+	0x4b, 0x84, 0x0c, 0xe2, 0xb8, 0x4c, 0x40, 0xa6, 0xb0, 0x6b, 0x5c, 0x51, 0x96, 0xf6, 0x4e, 0x47,
+	0x9e, 0xba, 0xaf, 0xf5, 0xcf, 0xc2, 0x2d, 0x2a, 0xe3, 0x90, 0xd1, 0x15, 0xb2, 0x54, 0x2a, 0x8e,
+	0xae, 0x6f, 0xc5, 0xcc, 0xa3, 0x9f, 0x83, 0x48, 0x3a, 0xdd, 0xa3, 0xce, 0x71, 0xdf, 0x57, 0xdf,
+	0xae, 0x03, 0xe3, 0x2a, 0xaf, 0xf6, 0xdf, 0xfd, 0xa3, 0x03, 0xa3, 0xaf, 0x16, 0x17, 0x3c, 0x98,
+	0xe1, 0xba, 0x4b, 0x61, 0xca, 0x39, 0x26, 0x72, 0x83, 0x4b, 0x06, 0x45, 0xee, 0x43, 0x4f, 0x06,
+	0xfc, 0x02, 0xb5, 0x47, 0x8d, 0x78, 0x03, 0x22, 0x43, 0xd8, 0x39, 0x67, 0x3c, 0x44, 0xe3, 0xa8,
+	0x16, 0x08, 0x85, 0x3e, 0xc7, 0x90, 0x63, 0x20, 0xd1, 0xd9, 0x56, 0x8a, 0x5c, 0x2e, 0xde, 0x79,
+	0xa7, 0xfe, 0xce, 0xbd, 0xc2, 0x9d, 0x1f, 0xc1, 0xb8, 0x7a, 0x31, 0x93, 0x33, 0x07, 0x76, 0xb5,
+	0xc5, 0x99, 0xd3, 0x39, 0xea, 0x66, 0xf9, 0x31, 0x62, 0xe6, 0x13, 0x72, 0xce, 0xb8, 0xc9, 0x9b,
+	0x16, 0xdc, 0x3f, 0x3b, 0x30, 0xf6, 0x59, 0x1c, 0x3f, 0x0b, 0xc2, 0xf9, 0x2b, 0x16, 0xa4, 0x3b,
+	0x70, 0xb0, 0x76, 0x33, 0x53, 0x19, 0x9f, 0xc2, 0xd0, 0x1c, 0x3d, 0x95, 0x81, 0x4c, 0xc5, 0xbf,
+	0x2d, 0x55, 0x97, 0xc3, 0xa8, 0x62, 0xc8, 0xe4, 0xe1, 0x10, 0x06, 0x1c, 0x05, 0x4b, 0x79, 0x88,
+	0xc2, 0x34, 0xd0, 0xea, 0x80, 0x7c, 0x00, 0x37, 0xac, 0xf0, 0x63, 0x1c, 0x89, 0x2c, 0x60, 0xdd,
+	0xe3, 0xbd, 0xd3, 0x71, 0x95, 0x4d, 0x43, 0xfc, 0xd7, 0x2d, 0xf8, 0x71, 0x24, 0xa4, 0x8b, 0x30,
+	0xfc, 0x04, 0x63, 0x94, 0xf8, 0xb2, 0x7d, 0x76, 0x08, 0x83, 0xab, 0x20, 0x89, 0xce, 0x51, 0x48,
+	0xa1, 0x3c, 0x18, 0xf8, 0xab, 0x03, 0x77, 0x0a, 0xa3, 0x0a, 0x8d, 0xb9, 0xda, 0x18, 0x7a, 0xaa,
+	0x76, 0x84, 0xa9, 0x30, 0x23, 0xb9, 0xbf, 0x6e, 0x81, 0xf3, 0x4d, 0x10, 0xc9, 0x87, 0x8c, 0x3f,
+	0x60, 0xc9, 0x2c, 0x92, 0x11, 0x4b, 0xc4, 0x7f, 0x30, 0x04, 0x7e, 0x00, 0x08, 0x73, 0xfb, 0x4e,
+	0x57, 0x45, 0xee, 0x23, 0xaf, 0x6e, 0x84, 0x7a, 0x4d, 0xee, 0x78, 0xab, 0x93, 0xb3, 0x44, 0xf2,
+	0x17, 0x7e, 0xc1, 0x22, 0xfd, 0x10, 0xf6, 0x2b, 0x6a, 0x72, 0x0b, 0xba, 0x73, 0x7c, 0x61, 0xf2,
+	0x98, 0x7d, 0x66, 0xc5, 0xbb, 0x0c, 0xe2, 0x14, 0x6d, 0x37, 0x29, 0xe1, 0xfd, 0xad, 0xf7, 0x3a,
+	0xee, 0x9b, 0x70, 0xa7, 0x86, 0xd6, 0x14, 0xde, 0xef, 0x1d, 0xd8, 0x3f, 0xfb, 0x05, 0xc3, 0x47,
+	0x8c, 0xcd, 0x6d, 0x68, 0x0e, 0x61, 0x90, 0x8d, 0x56, 0xb1, 0x08, 0x42, 0x3b, 0x6b, 0x57, 0x07,
+	0xe4, 0x2e, 0x6c, 0x67, 0xa3, 0xdf, 0xb4, 0x14, 0x29, 0x47, 0x4d, 0x99, 0x51, 0x7a, 0xe2, 0xc1,
+	0x0e, 0x2e, 0xb3, 0x5e, 0xcd, 0xba, 0xe9, 0xe6, 0xa9, 0xb3, 0x0e, 0xf4, 0xce, 0x32, 0xbd, 0xaf,
+	0x61, 0xc5, 0xf8, 0x6e, 0x97, 0xe2, 0xeb, 0x12, 0xb8, 0xb5, 0x72, 0xd1, 0xf8, 0xfd, 0x2d, 0xdc,
+	0xd6, 0xc5, 0x70, 0xed, 0x8e, 0xbb, 0x43, 0x20, 0x45, 0xd3, 0x86, 0xf0, 0x39, 0x0c, 0x1f, 0xa8,
+	0xa6, 0xff, 0x12, 0x85, 0x7c, 0xc2, 0x66, 0xed, 0x38, 0x29, 0xf4, 0x6d, 0x01, 0x9b, 0xc4, 0xe4,
+	0x72, 0xf1, 0xc2, 0xdd, 0xf2, 0x85, 0x0f, 0x60, 0x54, 0xe1, 0x32, 0x4e, 0xcc, 0x61, 0x64, 0x52,
+	0xf9, 0x3f, 0x78, 0xe1, 0xc1, 0xb8, 0x4a, 0x66, 0x1a, 0x6e, 0x08, 0x3b, 0x8b, 0x4b, 0xdb, 0x39,
+	0x03, 0x5f, 0x0b, 0xee, 0x17, 0x40, 0x0c, 0xf0, 0x31, 0xbb, 0x10, 0x2f, 0xed, 0x99, 0x7b, 0x0f,
+	0xde, 0x28, 0xd9, 0x5b, 0x3d, 0x02, 0x62, 0x76, 0x61, 0x67, 0x98, 0xfa, 0x76, 0x9f, 0xd8, 0x09,
+	0x74, 0x5d, 0x61, 0xc9, 0x52, 0x50, 0xb1, 0xa8, 0xe9, 0x4f, 0x7f, 0xdb, 0xcb, 0x47, 0xf5, 0xe7,
+	0x6c, 0x96, 0xc6, 0xf8, 0x54, 0xb7, 0x38, 0x39, 0x87, 0x5d, 0xf3, 0x6c, 0x21, 0x93, 0xfa, 0xe6,
+	0xaf, 0x7d, 0xee, 0xd0, 0x93, 0x76, 0x60, 0x53, 0x01, 0xaf, 0x91, 0x2b, 0xb8, 0x59, 0x7e, 0x5e,
+	0x34, 0xd1, 0xd5, 0x3e, 0x7e, 0xe8, 0x49, 0x3b, 0x70, 0x91, 0xae, 0xbc, 0xd9, 0x9b, 0xe8, 0x6a,
+	0x1f, 0x36, 0xf4, 0xa4, 0x1d, 0x38, 0xa7, 0x5b, 0xc0, 0x7e, 0x65, 0x47, 0x92, 0x06, 0x13, 0xf5,
+	0x8f, 0x04, 0x7a, 0xbf, 0x25, 0x3a, 0x67, 0x7c, 0x0e, 0x37, 0x4a, 0x1b, 0x93, 0xbc, 0xd3, 0x60,
+	0xa1, 0x66, 0x3f, 0xd3, 0x49, 0x2b, 0x6c, 0x91, 0xab, 0xb4, 0xc2, 0x9a, 0xb8, 0xea, 0xd6, 0x29,
+	0x9d, 0xb4, 0xc2, 0xe6, 0x5c, 0x4b, 0xb8, 0xbd, 0x36, 0xf6, 0x89, 0xf7, 0xcf, 0xd6, 0x12, 0x9d,
+	0xb6, 0xc6, 0xe7, 0xbc, 0xdf, 0x43, 0xdf, 0x4e, 0x6b, 0xf2, 0x56, 0xfd, 0x9f, 0x57, 0x16, 0x0e,
+	0xbd, 0xbb, 0x09, 0x96, 0x1b, 0x0f, 0x00, 0x56, 0xb3, 0x99, 0xbc, 0xfd, 0x77, 0x11, 0x29, 0x12,
+	0x1c, 0x6f, 0x06, 0x16, 0x73, 0x54, 0x1a, 0xbe, 0x4d, 0x39, 0xaa, 0xdb, 0x06, 0x74, 0xd2, 0x0a,
+	0x5b, 0x6c, 0xae, 0xf2, 0x88, 0x6d, 0x6a, 0xae, 0xda, 0xa9, 0x4f, 0x4f, 0xda, 0x81, 0x73, 0xba,
+	0x19, 0xec, 0x15, 0x26, 0x2a, 0x69, 0x88, 0xca, 0xfa, 0x10, 0xa7, 0xf7, 0x5a, 0x20, 0xd7, 0x8b,
+	0x7c, 0x43, 0x00, 0xeb, 0x26, 0x36, 0x9d, 0xb4, 0xc2, 0x5a, 0xae, 0x8f, 0xfb, 0xdf, 0xf5, 0x34,
+	0xe2, 0x59, 0x4f, 0xfd, 0x57, 0xf9, 0xee, 0x5f, 0x03, 0x00, 0xc4, 0x58, 0xc5, 0x41, 0xd5, 0x0e,
+	0x00, 0x00,
+}
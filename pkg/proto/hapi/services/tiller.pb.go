@@ -262,6 +262,23 @@ type UpdateReleaseRequest struct {
 	// ReuseValues will cause Tiller to reuse the values from the last release.
 	// This is ignored if reset_values is set.
 	ReuseValues bool `protobuf:"varint,10,opt,name=reuse_values,json=reuseValues" json:"reuse_values,omitempty"`
+	// ResetThenReuseValues will cause Tiller to start from the new chart's default
+	// values and then apply the user-supplied values of the last release on top,
+	// followed by any values in this request. This is ignored if reset_values is set.
+	ResetThenReuseValues bool `protobuf:"varint,11,opt,name=reset_then_reuse_values,json=resetThenReuseValues" json:"reset_then_reuse_values,omitempty"`
+	// WaitConditions maps resource kinds to the status condition type that marks
+	// them as ready when wait is set.
+	WaitConditions map[string]string `protobuf:"bytes,12,rep,name=wait_conditions,json=waitConditions" json:"wait_conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Atomic, if true, implies wait and rolls the release back to its previous
+	// revision if the upgrade fails.
+	Atomic bool `protobuf:"varint,13,opt,name=atomic" json:"atomic,omitempty"`
+	// SubNotes, if true, renders the NOTES.txt of subcharts into the notes.
+	SubNotes bool `protobuf:"varint,14,opt,name=sub_notes,json=subNotes" json:"sub_notes,omitempty"`
+	// Force, if true, will delete and recreate resources that cannot be patched
+	Force bool `protobuf:"varint,15,opt,name=force" json:"force,omitempty"`
+	// CleanupOnFail, if true, deletes the resources that the upgrade created
+	// when it fails.
+	CleanupOnFail bool `protobuf:"varint,16,opt,name=cleanup_on_fail,json=cleanupOnFail" json:"cleanup_on_fail,omitempty"`
 }
 
 func (m *UpdateReleaseRequest) Reset()                    { *m = UpdateReleaseRequest{} }
@@ -283,6 +300,13 @@ func (m *UpdateReleaseRequest) GetValues() *hapi_chart.Config {
 	return nil
 }
 
+func (m *UpdateReleaseRequest) GetWaitConditions() map[string]string {
+	if m != nil {
+		return m.WaitConditions
+	}
+	return nil
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
@@ -316,6 +340,8 @@ type RollbackReleaseRequest struct {
 	// wait, if true, will wait until all Pods, PVCs, and Services are in a ready state
 	// before marking the release as successful. It will wait for as long as timeout
 	Wait bool `protobuf:"varint,7,opt,name=wait" json:"wait,omitempty"`
+	// Force, if true, will delete and recreate resources that cannot be patched
+	Force bool `protobuf:"varint,8,opt,name=force" json:"force,omitempty"`
 }
 
 func (m *RollbackReleaseRequest) Reset()                    { *m = RollbackReleaseRequest{} }
@@ -365,6 +391,13 @@ type InstallReleaseRequest struct {
 	// wait, if true, will wait until all Pods, PVCs, and Services are in a ready state
 	// before marking the release as successful. It will wait for as long as timeout
 	Wait bool `protobuf:"varint,9,opt,name=wait" json:"wait,omitempty"`
+	// WaitConditions maps resource kinds to the status condition type that marks
+	// them as ready when wait is set.
+	WaitConditions map[string]string `protobuf:"bytes,10,rep,name=wait_conditions,json=waitConditions" json:"wait_conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Atomic, if true, implies wait and deletes the release if the install fails.
+	Atomic bool `protobuf:"varint,11,opt,name=atomic" json:"atomic,omitempty"`
+	// SubNotes, if true, renders the NOTES.txt of subcharts into the notes.
+	SubNotes bool `protobuf:"varint,12,opt,name=sub_notes,json=subNotes" json:"sub_notes,omitempty"`
 }
 
 func (m *InstallReleaseRequest) Reset()                    { *m = InstallReleaseRequest{} }
@@ -386,6 +419,13 @@ func (m *InstallReleaseRequest) GetValues() *hapi_chart.Config {
 	return nil
 }
 
+func (m *InstallReleaseRequest) GetWaitConditions() map[string]string {
+	if m != nil {
+		return m.WaitConditions
+	}
+	return nil
+}
+
 // InstallReleaseResponse is the response from a release installation.
 type InstallReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
@@ -503,6 +543,12 @@ type TestReleaseRequest struct {
 	Timeout int64 `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
 	// cleanup specifies whether or not to attempt pod deletion after test completes
 	Cleanup bool `protobuf:"varint,3,opt,name=cleanup" json:"cleanup,omitempty"`
+	// parallel specifies whether or not to run the test pods concurrently
+	Parallel bool `protobuf:"varint,4,opt,name=parallel" json:"parallel,omitempty"`
+	// max_parallel is the maximum number of test pods run at once, or 0 for no limit
+	MaxParallel uint32 `protobuf:"varint,5,opt,name=max_parallel,json=maxParallel" json:"max_parallel,omitempty"`
+	// logs specifies whether or not to stream the logs of the test pods once they complete
+	Logs bool `protobuf:"varint,6,opt,name=logs" json:"logs,omitempty"`
 }
 
 func (m *TestReleaseRequest) Reset()                    { *m = TestReleaseRequest{} }
@@ -999,79 +1045,93 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1170 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x57, 0xdb, 0x6e, 0xe3, 0x44,
-	0x18, 0xae, 0xe3, 0x1c, 0xff, 0x1e, 0x48, 0xa7, 0x27, 0xd7, 0x02, 0x54, 0x8c, 0xa0, 0xd9, 0x85,
-	0x4d, 0x21, 0x5c, 0x21, 0x21, 0xa4, 0x6e, 0x37, 0x6a, 0x0b, 0xa5, 0x2b, 0x39, 0xdb, 0x45, 0x42,
-	0x88, 0xc8, 0x4d, 0x26, 0xad, 0x59, 0xc7, 0x13, 0x3c, 0xe3, 0xb2, 0xbd, 0xe5, 0x8e, 0x47, 0xe1,
-	0x2d, 0x78, 0x01, 0x78, 0x01, 0x5e, 0x06, 0x79, 0x0e, 0x6e, 0xc6, 0xb5, 0x5b, 0x6f, 0x6e, 0x62,
-	0xcf, 0xfc, 0xe7, 0xef, 0xff, 0xfd, 0xcd, 0x04, 0xec, 0x6b, 0x6f, 0xe6, 0x1f, 0x50, 0x1c, 0xdd,
-	0xf8, 0x23, 0x4c, 0x0f, 0x98, 0x1f, 0x04, 0x38, 0xea, 0xce, 0x22, 0xc2, 0x08, 0xda, 0x4c, 0x64,
-	0x5d, 0x25, 0xeb, 0x0a, 0x99, 0xbd, 0xcd, 0x2d, 0x46, 0xd7, 0x5e, 0xc4, 0xc4, 0xaf, 0xd0, 0xb6,
-	0x77, 0xe6, 0xf7, 0x49, 0x38, 0xf1, 0xaf, 0xa4, 0x40, 0x84, 0x88, 0x70, 0x80, 0x3d, 0x8a, 0xd5,
-	0x53, 0x33, 0x52, 0x32, 0x3f, 0x9c, 0x10, 0x29, 0xd8, 0xd5, 0x04, 0x94, 0x79, 0x2c, 0xa6, 0x9a,
-	0xbf, 0x1b, 0x1c, 0x51, 0x9f, 0x84, 0xea, 0x29, 0x64, 0xce, 0xdf, 0x15, 0xd8, 0x38, 0xf3, 0x29,
-	0x73, 0x85, 0x21, 0x75, 0xf1, 0x6f, 0x31, 0xa6, 0x0c, 0x6d, 0x42, 0x2d, 0xf0, 0xa7, 0x3e, 0xb3,
-	0x8c, 0x3d, 0xa3, 0x63, 0xba, 0x62, 0x81, 0xb6, 0xa1, 0x4e, 0x26, 0x13, 0x8a, 0x99, 0x55, 0xd9,
-	0x33, 0x3a, 0x2d, 0x57, 0xae, 0xd0, 0xb7, 0xd0, 0xa0, 0x24, 0x62, 0xc3, 0xcb, 0x5b, 0xcb, 0xdc,
-	0x33, 0x3a, 0x6b, 0xbd, 0x4f, 0xba, 0x79, 0x50, 0x74, 0x93, 0x48, 0x03, 0x12, 0xb1, 0x6e, 0xf2,
-	0xf3, 0xfc, 0xd6, 0xad, 0x53, 0xfe, 0x4c, 0xfc, 0x4e, 0xfc, 0x80, 0xe1, 0xc8, 0xaa, 0x0a, 0xbf,
-	0x62, 0x85, 0x8e, 0x01, 0xb8, 0x5f, 0x12, 0x8d, 0x71, 0x64, 0xd5, 0xb8, 0xeb, 0x4e, 0x09, 0xd7,
-	0x2f, 0x13, 0x7d, 0xb7, 0x45, 0xd5, 0x2b, 0xfa, 0x06, 0x56, 0x04, 0x24, 0xc3, 0x11, 0x19, 0x63,
-	0x6a, 0xd5, 0xf7, 0xcc, 0xce, 0x5a, 0x6f, 0x57, 0xb8, 0x52, 0x08, 0x0f, 0x04, 0x68, 0x47, 0x64,
-	0x8c, 0xdd, 0x65, 0xa1, 0x9e, 0xbc, 0x53, 0xf4, 0x3e, 0xb4, 0x42, 0x6f, 0x8a, 0xe9, 0xcc, 0x1b,
-	0x61, 0xab, 0xc1, 0x33, 0xbc, 0xdb, 0x70, 0x7e, 0x81, 0xa6, 0x0a, 0xee, 0xf4, 0xa0, 0x2e, 0x4a,
-	0x43, 0xcb, 0xd0, 0xb8, 0x38, 0xff, 0xfe, 0xfc, 0xe5, 0x8f, 0xe7, 0xed, 0x25, 0xd4, 0x84, 0xea,
-	0xf9, 0xe1, 0x0f, 0xfd, 0xb6, 0x81, 0xd6, 0x61, 0xf5, 0xec, 0x70, 0xf0, 0x6a, 0xe8, 0xf6, 0xcf,
-	0xfa, 0x87, 0x83, 0xfe, 0x8b, 0x76, 0xc5, 0xf9, 0x10, 0x5a, 0x69, 0xce, 0xa8, 0x01, 0xe6, 0xe1,
-	0xe0, 0x48, 0x98, 0xbc, 0xe8, 0x0f, 0x8e, 0xda, 0x86, 0xf3, 0xa7, 0x01, 0x9b, 0x7a, 0x8b, 0xe8,
-	0x8c, 0x84, 0x14, 0x27, 0x3d, 0x1a, 0x91, 0x38, 0x4c, 0x7b, 0xc4, 0x17, 0x08, 0x41, 0x35, 0xc4,
-	0x6f, 0x55, 0x87, 0xf8, 0x7b, 0xa2, 0xc9, 0x08, 0xf3, 0x02, 0xde, 0x1d, 0xd3, 0x15, 0x0b, 0xf4,
-	0x25, 0x34, 0x65, 0xe9, 0xd4, 0xaa, 0xee, 0x99, 0x9d, 0xe5, 0xde, 0x96, 0x0e, 0x88, 0x8c, 0xe8,
-	0xa6, 0x6a, 0xce, 0x31, 0xec, 0x1c, 0x63, 0x95, 0x89, 0xc0, 0x4b, 0x4d, 0x4c, 0x12, 0xd7, 0x9b,
-	0x62, 0x9e, 0x4c, 0x12, 0xd7, 0x9b, 0x62, 0x64, 0x41, 0x43, 0x8e, 0x1b, 0x4f, 0xa7, 0xe6, 0xaa,
-	0xa5, 0xc3, 0xc0, 0xba, 0xef, 0x48, 0xd6, 0x95, 0xe7, 0xe9, 0x53, 0xa8, 0x26, 0xc3, 0xce, 0xdd,
-	0x2c, 0xf7, 0x90, 0x9e, 0xe7, 0x69, 0x38, 0x21, 0x2e, 0x97, 0xeb, 0xad, 0x32, 0xb3, 0xad, 0x3a,
-	0x99, 0x8f, 0x7a, 0x44, 0x42, 0x86, 0x43, 0xb6, 0x58, 0xfe, 0x67, 0xb0, 0x9b, 0xe3, 0x49, 0x16,
-	0x70, 0x00, 0x0d, 0x99, 0x1a, 0xf7, 0x56, 0x88, 0xab, 0xd2, 0x72, 0xfe, 0xa9, 0xc0, 0xe6, 0xc5,
-	0x6c, 0xec, 0x31, 0xac, 0x44, 0x0f, 0x24, 0xb5, 0x0f, 0x35, 0x4e, 0x1a, 0x12, 0x8b, 0x75, 0xe1,
-	0x5b, 0x30, 0xcb, 0x51, 0xf2, 0xeb, 0x0a, 0x39, 0x7a, 0x0a, 0xf5, 0x1b, 0x2f, 0x88, 0x31, 0xe5,
-	0x40, 0xa4, 0xa8, 0x49, 0x4d, 0xce, 0x38, 0xae, 0xd4, 0x40, 0x3b, 0xd0, 0x18, 0x47, 0xb7, 0xc3,
-	0x28, 0x0e, 0xf9, 0x27, 0xd8, 0x74, 0xeb, 0xe3, 0xe8, 0xd6, 0x8d, 0x43, 0xf4, 0x31, 0xac, 0x8e,
-	0x7d, 0xea, 0x5d, 0x06, 0x78, 0x78, 0x4d, 0xc8, 0x1b, 0xca, 0xbf, 0xc2, 0xa6, 0xbb, 0x22, 0x37,
-	0x4f, 0x92, 0x3d, 0x64, 0x27, 0x93, 0x34, 0x8a, 0xb0, 0xc7, 0xb0, 0x55, 0xe7, 0xf2, 0x74, 0x9d,
-	0x60, 0xc8, 0xfc, 0x29, 0x26, 0x31, 0xe3, 0x9f, 0x8e, 0xe9, 0xaa, 0x25, 0xfa, 0x08, 0x56, 0x22,
-	0x4c, 0x31, 0x1b, 0xca, 0x2c, 0x9b, 0xdc, 0x72, 0x99, 0xef, 0xbd, 0x16, 0x69, 0x21, 0xa8, 0xfe,
-	0xee, 0xf9, 0xcc, 0x6a, 0x71, 0x11, 0x7f, 0x17, 0x66, 0x31, 0xc5, 0xca, 0x0c, 0x94, 0x59, 0x4c,
-	0xb1, 0x30, 0x73, 0x4e, 0x60, 0x2b, 0x03, 0xe7, 0xa2, 0x9d, 0xf9, 0xd7, 0x80, 0x6d, 0x97, 0x04,
-	0xc1, 0xa5, 0x37, 0x7a, 0x53, 0xa2, 0x37, 0x73, 0x30, 0x56, 0x1e, 0x86, 0xd1, 0xcc, 0x81, 0x71,
-	0x6e, 0xdc, 0xaa, 0xda, 0xb8, 0x69, 0x00, 0xd7, 0x8a, 0x01, 0xae, 0xeb, 0x00, 0x2b, 0xf4, 0x1a,
-	0x77, 0xe8, 0x39, 0xdf, 0xc1, 0xce, 0xbd, 0x7a, 0x16, 0x05, 0xe7, 0xaf, 0x0a, 0x6c, 0x9d, 0x86,
-	0x94, 0x79, 0x41, 0x90, 0xc1, 0x26, 0x9d, 0x51, 0xa3, 0xf4, 0x8c, 0x56, 0xde, 0x65, 0x46, 0x4d,
-	0x0d, 0x5c, 0xd5, 0x89, 0xea, 0x5c, 0x27, 0x4a, 0xcd, 0xad, 0xc6, 0x16, 0xf5, 0x0c, 0x5b, 0xa0,
-	0x0f, 0x00, 0xc4, 0xa0, 0x71, 0xe7, 0x02, 0xc4, 0x16, 0xdf, 0x39, 0x97, 0xe4, 0xa0, 0x70, 0x6f,
-	0xe6, 0xe3, 0x3e, 0x37, 0xb5, 0xce, 0x29, 0x6c, 0x67, 0xa1, 0x5a, 0x14, 0xf6, 0x3f, 0x0c, 0xd8,
-	0xb9, 0x08, 0xfd, 0x5c, 0xe0, 0xf3, 0x86, 0xf2, 0x1e, 0x14, 0x95, 0x1c, 0x28, 0x36, 0xa1, 0x36,
-	0x8b, 0xa3, 0x2b, 0x2c, 0xa1, 0x15, 0x8b, 0xf9, 0x1a, 0xab, 0x5a, 0x8d, 0xce, 0x10, 0xac, 0xfb,
-	0x39, 0x2c, 0x58, 0x51, 0x92, 0x75, 0xca, 0xee, 0x2d, 0xc1, 0xe4, 0xce, 0x06, 0xac, 0x1f, 0x63,
-	0xf6, 0x5a, 0x7c, 0x00, 0xb2, 0x3c, 0xa7, 0x0f, 0x68, 0x7e, 0xf3, 0x2e, 0x9e, 0xdc, 0xd2, 0xe3,
-	0xa9, 0xab, 0x8e, 0xd2, 0x57, 0x5a, 0xce, 0xd7, 0xdc, 0xf7, 0x89, 0x4f, 0x19, 0x89, 0x6e, 0x1f,
-	0x82, 0xae, 0x0d, 0xe6, 0xd4, 0x7b, 0x2b, 0xc9, 0x3f, 0x79, 0x75, 0x8e, 0x79, 0x06, 0xa9, 0xa9,
-	0xcc, 0x60, 0xfe, 0x28, 0x35, 0xca, 0x1d, 0xa5, 0x3f, 0x03, 0x7a, 0x85, 0xd3, 0x53, 0xfd, 0x91,
-	0x53, 0x48, 0x35, 0xa1, 0xa2, 0x0f, 0x9a, 0x05, 0x8d, 0x51, 0x80, 0xbd, 0x30, 0x9e, 0xc9, 0xb6,
-	0xa9, 0xa5, 0xb3, 0x0f, 0x1b, 0x9a, 0x77, 0x99, 0x67, 0x52, 0x0f, 0xbd, 0x92, 0xde, 0x93, 0xd7,
-	0xde, 0x7f, 0x4d, 0x58, 0x53, 0xc7, 0xb0, 0xb8, 0x52, 0x21, 0x1f, 0x56, 0xe6, 0xef, 0x1b, 0xe8,
-	0x49, 0xf1, 0x8d, 0x2b, 0x73, 0x6d, 0xb4, 0x9f, 0x96, 0x51, 0x15, 0xb9, 0x38, 0x4b, 0x5f, 0x18,
-	0x88, 0x42, 0x3b, 0x7b, 0x0d, 0x40, 0xcf, 0xf2, 0x7d, 0x14, 0xdc, 0x3b, 0xec, 0x6e, 0x59, 0x75,
-	0x15, 0x16, 0xdd, 0xf0, 0xee, 0xeb, 0x67, 0x37, 0x7a, 0xd4, 0x8d, 0x7e, 0x5d, 0xb0, 0x0f, 0x4a,
-	0xeb, 0xa7, 0x71, 0x7f, 0x85, 0x55, 0xed, 0x54, 0x42, 0x05, 0x68, 0xe5, 0xdd, 0x04, 0xec, 0xcf,
-	0x4a, 0xe9, 0xa6, 0xb1, 0xa6, 0xb0, 0xa6, 0xd3, 0x0d, 0x2a, 0x70, 0x90, 0xcb, 0xdf, 0xf6, 0xe7,
-	0xe5, 0x94, 0xd3, 0x70, 0x14, 0xda, 0x59, 0x36, 0x28, 0xea, 0x63, 0x01, 0x73, 0x15, 0xf5, 0xb1,
-	0x88, 0x64, 0x9c, 0x25, 0xe4, 0x01, 0xdc, 0x91, 0x01, 0xda, 0x2f, 0x6c, 0x88, 0xce, 0x21, 0x76,
-	0xe7, 0x71, 0xc5, 0x34, 0xc4, 0x0c, 0xde, 0xcb, 0x9c, 0x96, 0xa8, 0x00, 0x9a, 0xfc, 0x4b, 0x82,
-	0xfd, 0xac, 0xa4, 0x76, 0xa6, 0x28, 0xc9, 0x2f, 0x0f, 0x14, 0xa5, 0x93, 0xd7, 0x03, 0x45, 0x65,
-	0xa8, 0xca, 0x59, 0x42, 0x3e, 0xac, 0xb9, 0x71, 0x28, 0x43, 0x27, 0x2c, 0x81, 0x0a, 0xac, 0xef,
-	0xf3, 0x93, 0xfd, 0xa4, 0x84, 0xe6, 0xdd, 0xf7, 0xfd, 0x1c, 0x7e, 0x6a, 0x2a, 0xd5, 0xcb, 0x3a,
-	0xff, 0xc7, 0xf9, 0xd5, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x30, 0x80, 0xed, 0x18, 0x42, 0x0f,
-	0x00, 0x00,
+	// 1395 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x72, 0xdb, 0xc4,
+	0x1b, 0xaf, 0x6c, 0xc7, 0x87, 0xcf, 0x89, 0xe3, 0x6c, 0xd2, 0x44, 0xd5, 0xff, 0x30, 0x41, 0x0c,
+	0xad, 0xdb, 0x52, 0x07, 0xc2, 0x30, 0x03, 0x0c, 0x53, 0x26, 0x4d, 0x4d, 0x52, 0x08, 0x29, 0xa3,
+	0xf4, 0x30, 0xc3, 0x05, 0x9e, 0x8d, 0xbd, 0x4e, 0x44, 0x65, 0xad, 0xd1, 0xae, 0xd2, 0xf8, 0x96,
+	0x3b, 0x86, 0xc7, 0xe0, 0x01, 0x78, 0x05, 0xde, 0x83, 0x5b, 0x1e, 0x84, 0xd9, 0x93, 0x22, 0x39,
+	0x72, 0xa2, 0x66, 0xb8, 0xb1, 0xf7, 0xdb, 0xef, 0xb8, 0xdf, 0xe1, 0xe7, 0x2f, 0x01, 0xe7, 0x14,
+	0x4f, 0xfc, 0x2d, 0x46, 0xa2, 0x33, 0x7f, 0x40, 0xd8, 0x16, 0xf7, 0x83, 0x80, 0x44, 0xdd, 0x49,
+	0x44, 0x39, 0x45, 0x6b, 0x82, 0xd7, 0x35, 0xbc, 0xae, 0xe2, 0x39, 0xeb, 0x52, 0x63, 0x70, 0x8a,
+	0x23, 0xae, 0x3e, 0x95, 0xb4, 0xb3, 0x91, 0xbe, 0xa7, 0xe1, 0xc8, 0x3f, 0xd1, 0x0c, 0xe5, 0x22,
+	0x22, 0x01, 0xc1, 0x8c, 0x98, 0xef, 0x8c, 0x92, 0xe1, 0xf9, 0xe1, 0x88, 0x6a, 0xc6, 0x9d, 0x0c,
+	0x83, 0x71, 0xcc, 0x63, 0x96, 0xb1, 0x77, 0x46, 0x22, 0xe6, 0xd3, 0xd0, 0x7c, 0x2b, 0x9e, 0xfb,
+	0x67, 0x09, 0x56, 0x0f, 0x7c, 0xc6, 0x3d, 0xa5, 0xc8, 0x3c, 0xf2, 0x73, 0x4c, 0x18, 0x47, 0x6b,
+	0xb0, 0x10, 0xf8, 0x63, 0x9f, 0xdb, 0xd6, 0xa6, 0xd5, 0x29, 0x7b, 0x8a, 0x40, 0xeb, 0x50, 0xa5,
+	0xa3, 0x11, 0x23, 0xdc, 0x2e, 0x6d, 0x5a, 0x9d, 0x86, 0xa7, 0x29, 0xf4, 0x18, 0x6a, 0x8c, 0x46,
+	0xbc, 0x7f, 0x3c, 0xb5, 0xcb, 0x9b, 0x56, 0xa7, 0xb5, 0xfd, 0x41, 0x37, 0x2f, 0x15, 0x5d, 0xe1,
+	0xe9, 0x88, 0x46, 0xbc, 0x2b, 0x3e, 0x9e, 0x4c, 0xbd, 0x2a, 0x93, 0xdf, 0xc2, 0xee, 0xc8, 0x0f,
+	0x38, 0x89, 0xec, 0x8a, 0xb2, 0xab, 0x28, 0xb4, 0x07, 0x20, 0xed, 0xd2, 0x68, 0x48, 0x22, 0x7b,
+	0x41, 0x9a, 0xee, 0x14, 0x30, 0xfd, 0x5c, 0xc8, 0x7b, 0x0d, 0x66, 0x8e, 0xe8, 0x4b, 0x58, 0x54,
+	0x29, 0xe9, 0x0f, 0xe8, 0x90, 0x30, 0xbb, 0xba, 0x59, 0xee, 0xb4, 0xb6, 0xef, 0x28, 0x53, 0x26,
+	0xc3, 0x47, 0x2a, 0x69, 0xbb, 0x74, 0x48, 0xbc, 0xa6, 0x12, 0x17, 0x67, 0x86, 0xfe, 0x0b, 0x8d,
+	0x10, 0x8f, 0x09, 0x9b, 0xe0, 0x01, 0xb1, 0x6b, 0x32, 0xc2, 0x8b, 0x0b, 0xf7, 0x47, 0xa8, 0x1b,
+	0xe7, 0xee, 0x36, 0x54, 0xd5, 0xd3, 0x50, 0x13, 0x6a, 0x2f, 0x0f, 0xbf, 0x3d, 0x7c, 0xfe, 0xfa,
+	0xb0, 0x7d, 0x0b, 0xd5, 0xa1, 0x72, 0xb8, 0xf3, 0x5d, 0xaf, 0x6d, 0xa1, 0x15, 0x58, 0x3a, 0xd8,
+	0x39, 0x7a, 0xd1, 0xf7, 0x7a, 0x07, 0xbd, 0x9d, 0xa3, 0xde, 0xd3, 0x76, 0xc9, 0xfd, 0x3f, 0x34,
+	0x92, 0x98, 0x51, 0x0d, 0xca, 0x3b, 0x47, 0xbb, 0x4a, 0xe5, 0x69, 0xef, 0x68, 0xb7, 0x6d, 0xb9,
+	0xbf, 0x5a, 0xb0, 0x96, 0x2d, 0x11, 0x9b, 0xd0, 0x90, 0x11, 0x51, 0xa3, 0x01, 0x8d, 0xc3, 0xa4,
+	0x46, 0x92, 0x40, 0x08, 0x2a, 0x21, 0x39, 0x37, 0x15, 0x92, 0x67, 0x21, 0xc9, 0x29, 0xc7, 0x81,
+	0xac, 0x4e, 0xd9, 0x53, 0x04, 0xfa, 0x18, 0xea, 0xfa, 0xe9, 0xcc, 0xae, 0x6c, 0x96, 0x3b, 0xcd,
+	0xed, 0xdb, 0xd9, 0x84, 0x68, 0x8f, 0x5e, 0x22, 0xe6, 0xee, 0xc1, 0xc6, 0x1e, 0x31, 0x91, 0xa8,
+	0x7c, 0x99, 0x8e, 0x11, 0x7e, 0xf1, 0x98, 0xd8, 0x96, 0xf6, 0x8b, 0xc7, 0x04, 0xd9, 0x50, 0xd3,
+	0xed, 0x26, 0xc3, 0x59, 0xf0, 0x0c, 0xe9, 0x72, 0xb0, 0x2f, 0x1b, 0xd2, 0xef, 0xca, 0xb3, 0x74,
+	0x17, 0x2a, 0xa2, 0xd9, 0xa5, 0x99, 0xe6, 0x36, 0xca, 0xc6, 0xf9, 0x2c, 0x1c, 0x51, 0x4f, 0xf2,
+	0xb3, 0xa5, 0x2a, 0xcf, 0x96, 0x6a, 0x3f, 0xed, 0x75, 0x97, 0x86, 0x9c, 0x84, 0xfc, 0x66, 0xf1,
+	0x1f, 0xc0, 0x9d, 0x1c, 0x4b, 0xfa, 0x01, 0x5b, 0x50, 0xd3, 0xa1, 0x49, 0x6b, 0x73, 0xf3, 0x6a,
+	0xa4, 0xdc, 0xdf, 0x17, 0x60, 0xed, 0xe5, 0x64, 0x88, 0x39, 0x31, 0xac, 0x2b, 0x82, 0xba, 0x07,
+	0x0b, 0x12, 0x34, 0x74, 0x2e, 0x56, 0x94, 0x6d, 0x79, 0xd5, 0xdd, 0x15, 0x9f, 0x9e, 0xe2, 0xa3,
+	0x07, 0x50, 0x3d, 0xc3, 0x41, 0x4c, 0x98, 0x5d, 0x4e, 0x67, 0x4d, 0x4b, 0x4a, 0xc4, 0xf1, 0xb4,
+	0x04, 0xda, 0x80, 0xda, 0x30, 0x9a, 0xf6, 0xa3, 0x38, 0x94, 0x23, 0x58, 0xf7, 0xaa, 0xc3, 0x68,
+	0xea, 0xc5, 0x21, 0x7a, 0x1f, 0x96, 0x86, 0x3e, 0xc3, 0xc7, 0x01, 0xe9, 0x9f, 0x52, 0xfa, 0x86,
+	0xc9, 0x29, 0xac, 0x7b, 0x8b, 0xfa, 0x72, 0x5f, 0xdc, 0x21, 0x47, 0x74, 0xd2, 0x20, 0x22, 0x98,
+	0x13, 0xbb, 0x2a, 0xf9, 0x09, 0x2d, 0x72, 0xc8, 0xfd, 0x31, 0xa1, 0x31, 0x97, 0xa3, 0x53, 0xf6,
+	0x0c, 0x89, 0xde, 0x83, 0xc5, 0x88, 0x30, 0xc2, 0xfb, 0x3a, 0xca, 0xba, 0xd4, 0x6c, 0xca, 0xbb,
+	0x57, 0x2a, 0x2c, 0x04, 0x95, 0xb7, 0xd8, 0xe7, 0x76, 0x43, 0xb2, 0xe4, 0x59, 0xa9, 0xc5, 0x8c,
+	0x18, 0x35, 0x30, 0x6a, 0x31, 0x23, 0x5a, 0xed, 0x53, 0xd8, 0x50, 0x96, 0xf9, 0x29, 0x09, 0xfb,
+	0x19, 0xe9, 0xa6, 0x94, 0x5e, 0x93, 0xec, 0x17, 0xa7, 0x24, 0xf4, 0x52, 0x6a, 0x27, 0xb0, 0x2c,
+	0x3c, 0xf4, 0x07, 0x34, 0x1c, 0xfa, 0xdc, 0xa7, 0x21, 0xb3, 0x17, 0xe5, 0x5c, 0x3c, 0xce, 0xc7,
+	0x9c, 0xbc, 0x92, 0x75, 0x5f, 0x63, 0x9f, 0xef, 0x26, 0x06, 0x7a, 0x21, 0x8f, 0xa6, 0x5e, 0xeb,
+	0x6d, 0xe6, 0x52, 0xe0, 0x1d, 0xe6, 0x74, 0xec, 0x0f, 0xec, 0x25, 0x95, 0x6c, 0x45, 0xa1, 0xff,
+	0x40, 0x83, 0xc5, 0xc7, 0xfd, 0x90, 0x72, 0xc2, 0xec, 0x96, 0x4a, 0x24, 0x8b, 0x8f, 0x0f, 0x05,
+	0x2d, 0x86, 0x78, 0x44, 0xa3, 0x01, 0xb1, 0x97, 0x25, 0x43, 0x11, 0xe8, 0x2e, 0x2c, 0x0f, 0x02,
+	0x82, 0xc3, 0x78, 0xd2, 0xa7, 0x61, 0x7f, 0x84, 0xfd, 0xc0, 0x6e, 0x4b, 0xfe, 0x92, 0xbe, 0x7e,
+	0x1e, 0x7e, 0x8d, 0xfd, 0xc0, 0xd9, 0x81, 0xd5, 0x9c, 0xc8, 0x50, 0x1b, 0xca, 0x6f, 0xc8, 0x54,
+	0xf7, 0x97, 0x38, 0x0a, 0x37, 0x32, 0x55, 0x1a, 0x40, 0x14, 0xf1, 0x45, 0xe9, 0x33, 0xcb, 0xdd,
+	0x87, 0xdb, 0x33, 0x2f, 0xbe, 0x69, 0xbf, 0xff, 0x6d, 0xc1, 0xba, 0x47, 0x83, 0xe0, 0x18, 0x0f,
+	0xde, 0x14, 0xe8, 0xf8, 0x54, 0x73, 0x96, 0xae, 0x6e, 0xce, 0x72, 0x4e, 0x73, 0xa6, 0x86, 0xb8,
+	0x92, 0x19, 0xe2, 0x4c, 0xdb, 0x2e, 0xcc, 0x6f, 0xdb, 0x6a, 0xb6, 0x6d, 0x4d, 0x4f, 0xd6, 0x52,
+	0x3d, 0x99, 0xd4, 0xa6, 0x9e, 0xaa, 0x8d, 0xfb, 0x0d, 0x6c, 0x5c, 0x7a, 0xe5, 0x4d, 0x53, 0xf6,
+	0x5b, 0x05, 0x6e, 0x3f, 0x0b, 0x19, 0xc7, 0x41, 0x30, 0x93, 0xb1, 0x04, 0x0f, 0xac, 0xc2, 0x78,
+	0x50, 0x7a, 0x17, 0x3c, 0x28, 0x67, 0x52, 0x6e, 0xea, 0x53, 0x49, 0xd5, 0xa7, 0x10, 0x46, 0x64,
+	0x90, 0xb9, 0x3a, 0x83, 0xcc, 0xe8, 0x7f, 0x00, 0x6a, 0x4c, 0xa5, 0x71, 0x95, 0xda, 0x86, 0xbc,
+	0x39, 0xd4, 0x40, 0x6c, 0xaa, 0x51, 0xcf, 0xaf, 0x46, 0x1a, 0x21, 0x4e, 0x2f, 0xcf, 0x31, 0xc8,
+	0x39, 0xfe, 0x2a, 0x7f, 0x8e, 0x73, 0xf3, 0xfa, 0x8e, 0x83, 0xdc, 0x9c, 0x3f, 0xc8, 0x8b, 0xd9,
+	0x41, 0xfe, 0x37, 0x46, 0xf1, 0x19, 0xac, 0xcf, 0x06, 0x7d, 0xd3, 0xc6, 0xfa, 0xc5, 0x82, 0x8d,
+	0x97, 0xa1, 0x9f, 0xdb, 0x5a, 0x79, 0xc3, 0x78, 0xa9, 0xd8, 0xa5, 0x9c, 0x62, 0xaf, 0xc1, 0xc2,
+	0x24, 0x8e, 0x4e, 0x88, 0x6e, 0x1e, 0x45, 0xa4, 0xab, 0x58, 0xc9, 0x54, 0xd1, 0xed, 0x83, 0x7d,
+	0x39, 0x86, 0x1b, 0xbe, 0x48, 0x44, 0x9d, 0xec, 0x0a, 0x0d, 0xb5, 0x17, 0xb8, 0xab, 0xb0, 0xb2,
+	0x47, 0xf8, 0x2b, 0x35, 0xf8, 0xfa, 0x79, 0x6e, 0x0f, 0x50, 0xfa, 0xf2, 0xc2, 0x9f, 0xbe, 0xca,
+	0xfa, 0x33, 0x8b, 0xb3, 0x91, 0x37, 0x52, 0xee, 0xe7, 0xd2, 0xf6, 0xbe, 0xcf, 0x38, 0x8d, 0xa6,
+	0x57, 0xa5, 0xae, 0x0d, 0xe5, 0x31, 0x3e, 0xd7, 0xab, 0x84, 0x38, 0xba, 0x7b, 0x80, 0xd2, 0xaa,
+	0x3a, 0x82, 0xf4, 0x62, 0x66, 0x15, 0x5b, 0xcc, 0xfe, 0xb0, 0x00, 0xbd, 0x20, 0xc9, 0x92, 0x78,
+	0xcd, 0x52, 0x63, 0xaa, 0x50, 0xca, 0xce, 0x92, 0x0d, 0x35, 0xfd, 0xa3, 0xa1, 0xeb, 0x66, 0x48,
+	0x81, 0x94, 0x13, 0x1c, 0xe1, 0x20, 0x20, 0x81, 0xde, 0x0f, 0x12, 0x5a, 0xfc, 0x1e, 0x8f, 0xf1,
+	0x79, 0x3f, 0xe1, 0x8b, 0xe1, 0x5f, 0xf2, 0x9a, 0x63, 0x7c, 0xfe, 0xbd, 0x11, 0x41, 0x50, 0x09,
+	0xe8, 0x09, 0xd3, 0xbb, 0x81, 0x3c, 0xbb, 0xf7, 0x60, 0x35, 0x13, 0xb0, 0x7e, 0xbb, 0xc8, 0x11,
+	0x3b, 0x31, 0x53, 0x30, 0x66, 0x27, 0xdb, 0x7f, 0xd5, 0xa1, 0x65, 0x16, 0x45, 0x35, 0xb8, 0xc8,
+	0x87, 0xc5, 0xf4, 0x46, 0x8c, 0xee, 0xcf, 0xff, 0x9b, 0x60, 0xe6, 0x0f, 0x1b, 0xe7, 0x41, 0x11,
+	0x51, 0x15, 0x8b, 0x7b, 0xeb, 0x23, 0x0b, 0x31, 0x68, 0xcf, 0x2e, 0xaa, 0xe8, 0x51, 0xbe, 0x8d,
+	0x39, 0x9b, 0xb1, 0xd3, 0x2d, 0x2a, 0x6e, 0xdc, 0xa2, 0x33, 0x58, 0xb9, 0xe0, 0xea, 0xed, 0x12,
+	0x5d, 0x6b, 0x26, 0xbb, 0xd0, 0x3a, 0x5b, 0x85, 0xe5, 0x13, 0xbf, 0x3f, 0xc1, 0x52, 0xe6, 0x17,
+	0x1e, 0x3d, 0x28, 0xbe, 0xf8, 0x38, 0x0f, 0x0b, 0xc9, 0x26, 0xbe, 0xc6, 0xd0, 0xca, 0x42, 0x18,
+	0x7a, 0xf8, 0x0e, 0xe8, 0xec, 0x7c, 0x58, 0x4c, 0x38, 0x71, 0xc7, 0xa0, 0x3d, 0x8b, 0x30, 0xf3,
+	0xea, 0x38, 0x07, 0x0d, 0x9d, 0x6e, 0x51, 0xf1, 0xc4, 0x29, 0x06, 0xb8, 0x00, 0x18, 0x74, 0x6f,
+	0x6e, 0x41, 0xb2, 0xb8, 0xe4, 0x74, 0xae, 0x17, 0x4c, 0x5c, 0x4c, 0x60, 0x79, 0x66, 0xc7, 0x40,
+	0x73, 0x52, 0x93, 0xbf, 0x70, 0x39, 0x8f, 0x0a, 0x4a, 0xcf, 0x3c, 0x4a, 0x63, 0xd6, 0x15, 0x8f,
+	0xca, 0x02, 0xa2, 0xd3, 0xb9, 0x5e, 0x30, 0x71, 0xe1, 0x43, 0xcb, 0x8b, 0x43, 0xed, 0x5a, 0xa0,
+	0x04, 0x9a, 0xa3, 0x7d, 0x19, 0xf2, 0x9c, 0xfb, 0x05, 0x24, 0x2f, 0xe6, 0xfb, 0x09, 0xfc, 0x50,
+	0x37, 0xa2, 0xc7, 0x55, 0xf9, 0x3f, 0x91, 0x4f, 0xfe, 0x19, 0x00, 0x3a, 0xac, 0xf4, 0x94, 0xe4,
+	0x11, 0x00, 0x00,
 }
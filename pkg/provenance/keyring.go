@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// LoadKeyring loads the public keys of the keyring at path. A keyring that
+// does not exist is empty.
+func LoadKeyring(path string) (openpgp.EntityList, error) {
+	keys, err := loadKeyRing(path)
+	if os.IsNotExist(err) {
+		return openpgp.EntityList{}, nil
+	}
+	return keys, err
+}
+
+// ReadKeys reads keys in either ASCII armored or binary form.
+func ReadKeys(in io.Reader) (openpgp.EntityList, error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	if keys, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data)); err == nil {
+		return keys, nil
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+// WriteKeyring writes the public parts of keys as a binary keyring to path.
+func WriteKeyring(path string, keys openpgp.EntityList) error {
+	var buf bytes.Buffer
+	for _, k := range keys {
+		if err := k.Serialize(&buf); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// KeyID returns the long ID of key in hexadecimal.
+func KeyID(key *openpgp.Entity) string {
+	return fmt.Sprintf("%016X", key.PrimaryKey.KeyId)
+}
+
+// MatchKey reports whether key is identified by id, which is a key ID (short
+// or long), a fingerprint, or the name or email address of an identity.
+func MatchKey(key *openpgp.Entity, id string) bool {
+	id = strings.TrimPrefix(strings.ToUpper(id), "0X")
+	if len(id) >= 8 {
+		fingerprint := fmt.Sprintf("%X", key.PrimaryKey.Fingerprint)
+		if strings.HasSuffix(fingerprint, id) {
+			return true
+		}
+	}
+	for _, ident := range key.Identities {
+		if ident.UserId == nil {
+			continue
+		}
+		if strings.EqualFold(ident.UserId.Name, id) || strings.EqualFold(ident.UserId.Email, id) || strings.EqualFold(ident.Name, id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyring(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-keyring-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ring := filepath.Join(dir, "keyring.gpg")
+
+	keys, err := LoadKeyring(ring)
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("Expected a missing keyring to be empty, got %d keys, %v", len(keys), err)
+	}
+
+	for _, f := range []string{testPubfile, testPasswordKeyfile} {
+		in, err := os.Open(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadKeys(in)
+		in.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %s", f, err)
+		}
+		keys = append(keys, read...)
+	}
+	if err := WriteKeyring(ring, keys); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err = LoadKeyring(ring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(keys))
+	}
+	for _, k := range keys {
+		if k.PrivateKey != nil {
+			t.Errorf("Expected only the public part of key %s to be written", KeyID(k))
+		}
+	}
+
+	tests := []struct {
+		id     string
+		expect bool
+	}{
+		{id: testKeyName, expect: true},
+		{id: "helm-testing@helm.sh", expect: true},
+		{id: KeyID(keys[0]), expect: true},
+		{id: "0x" + KeyID(keys[0])[8:], expect: true},
+		{id: "fake@helm.sh"},
+		{id: "nobody"},
+	}
+	for _, tt := range tests {
+		if MatchKey(keys[0], tt.id) != tt.expect {
+			t.Errorf("%q: expected match %t", tt.id, tt.expect)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+const (
+	// ConfigMediaType is the media type of the chart metadata blob.
+	ConfigMediaType = "application/vnd.cncf.helm.config.v1+json"
+	// ChartLayerMediaType is the media type of the packaged chart blob.
+	ChartLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	// ManifestMediaType is the media type of the manifest of a chart.
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+)
+
+// descriptor describes a blob referenced by a manifest.
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int    `json:"size"`
+}
+
+// manifest is an OCI image manifest.
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+// Client pushes charts to and pulls charts from OCI registries.
+type Client struct {
+	// Credentials looks up the credentials for a registry host.
+	Credentials CredentialsFunc
+	// HTTPClient is the client used for requests to registries.
+	HTTPClient *http.Client
+	// PlainHTTP accesses registries over HTTP instead of HTTPS. Registries
+	// on the loopback interface are always accessed over HTTP.
+	PlainHTTP bool
+
+	mu sync.Mutex
+	// auth caches the Authorization header per host and scope.
+	auth map[string]string
+}
+
+// NewClient returns a Client that uses the Docker credentials.
+func NewClient() *Client {
+	return &Client{
+		Credentials: DockerCredentials,
+		HTTPClient:  http.DefaultClient,
+	}
+}
+
+// Push uploads a packaged chart to ref. When ref has no tag, the chart is
+// tagged with its version. It returns ref with the tag and the digest of the
+// stored manifest.
+func (c *Client) Push(ref Reference, data []byte) (Reference, error) {
+	ch, err := chartutil.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return ref, err
+	}
+	if ch.Metadata == nil {
+		return ref, fmt.Errorf("chart has no metadata")
+	}
+	if ref.Tag == "" {
+		ref.Tag = ch.Metadata.Version
+	}
+	ref.Digest = ""
+
+	config, err := json.Marshal(ch.Metadata)
+	if err != nil {
+		return ref, err
+	}
+	m := manifest{
+		SchemaVersion: 2,
+		MediaType:     ManifestMediaType,
+		Config:        descriptor{MediaType: ConfigMediaType, Digest: digest(config), Size: len(config)},
+		Layers:        []descriptor{{MediaType: ChartLayerMediaType, Digest: digest(data), Size: len(data)}},
+	}
+	if err := c.uploadBlob(ref, config); err != nil {
+		return ref, err
+	}
+	if err := c.uploadBlob(ref, data); err != nil {
+		return ref, err
+	}
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		return ref, err
+	}
+	header := http.Header{"Content-Type": {ManifestMediaType}}
+	resp, err := c.do("PUT", c.url(ref, "manifests/"+ref.Tag), header, body, ref, "pull,push")
+	if err != nil {
+		return ref, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, http.StatusCreated); err != nil {
+		return ref, err
+	}
+	ref.Digest = digest(body)
+	return ref, nil
+}
+
+// Pull downloads the packaged chart that ref points at.
+func (c *Client) Pull(ref Reference) ([]byte, error) {
+	if ref.manifestRef() == "" {
+		return nil, fmt.Errorf("%s: a tag or digest is required", ref)
+	}
+
+	header := http.Header{"Accept": {ManifestMediaType}}
+	resp, err := c.do("GET", c.url(ref, "manifests/"+ref.manifestRef()), header, nil, ref, "pull")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%s: invalid manifest: %s", ref, err)
+	}
+
+	var layer *descriptor
+	for i := range m.Layers {
+		if m.Layers[i].MediaType == ChartLayerMediaType {
+			layer = &m.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("%s is not a chart", ref)
+	}
+
+	resp, err = c.do("GET", c.url(ref, "blobs/"+layer.Digest), nil, nil, ref, "pull")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if d := digest(data); d != layer.Digest {
+		return nil, fmt.Errorf("%s: chart digest %s does not match %s", ref, d, layer.Digest)
+	}
+	return data, nil
+}
+
+// uploadBlob uploads data to the repository of ref unless the registry
+// already has it.
+func (c *Client) uploadBlob(ref Reference, data []byte) error {
+	d := digest(data)
+	resp, err := c.do("HEAD", c.url(ref, "blobs/"+d), nil, nil, ref, "pull,push")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	start := c.url(ref, "blobs/uploads/")
+	resp, err = c.do("POST", start, nil, nil, ref, "pull,push")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if err := checkResponse(resp, http.StatusAccepted); err != nil {
+		return err
+	}
+	base, _ := url.Parse(start)
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("%s: registry returned no upload location", ref)
+	}
+	u := base.ResolveReference(loc)
+	q := u.Query()
+	q.Set("digest", d)
+	u.RawQuery = q.Encode()
+
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	resp, err = c.do("PUT", u.String(), header, data, ref, "pull,push")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp, http.StatusCreated)
+}
+
+// url returns the URL of a registry API endpoint for the repository of ref.
+func (c *Client) url(ref Reference, endpoint string) string {
+	scheme := "https"
+	if c.PlainHTTP || isLoopback(ref.Host) {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Host, ref.Repository, endpoint)
+}
+
+// do sends a request to the registry of ref. When the registry asks for
+// authentication, it authenticates for the given actions on the repository
+// and sends the request again.
+func (c *Client) do(method, u string, header http.Header, body []byte, ref Reference, actions string) (*http.Response, error) {
+	key := ref.Host + " " + ref.Repository + " " + actions
+	c.mu.Lock()
+	auth := c.auth[key]
+	c.mu.Unlock()
+
+	resp, err := c.send(method, u, header, body, auth)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	scope := fmt.Sprintf("repository:%s:%s", ref.Repository, actions)
+	if auth, err = c.authorize(ref.Host, challenge, scope); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.auth == nil {
+		c.auth = map[string]string{}
+	}
+	c.auth[key] = auth
+	c.mu.Unlock()
+	return c.send(method, u, header, body, auth)
+}
+
+func (c *Client) send(method, u string, header http.Header, body []byte, auth string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	return c.httpClient().Do(req)
+}
+
+// authorize answers an authentication challenge of the registry at host
+// and returns the Authorization header to send.
+func (c *Client) authorize(host, challenge, scope string) (string, error) {
+	var username, password string
+	if c.Credentials != nil {
+		var err error
+		if username, password, err = c.Credentials(host); err != nil {
+			return "", err
+		}
+	}
+
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if username == "" {
+			return "", fmt.Errorf("%s requires credentials (try 'docker login %s')", host, host)
+		}
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.SetBasicAuth(username, password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+		token, err := c.fetchToken(params, scope, username, password)
+		if err != nil {
+			return "", fmt.Errorf("could not authenticate to %s: %s", host, err)
+		}
+		return "Bearer " + token, nil
+	}
+	return "", fmt.Errorf("%s: unsupported authentication challenge %q", host, challenge)
+}
+
+// fetchToken gets a bearer token for scope from the token service that the
+// challenge parameters point at.
+func (c *Client) fetchToken(params map[string]string, scope, username, password string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	q := realm.Query()
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	q.Set("scope", scope)
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return "", err
+	}
+
+	var t struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", err
+	}
+	if t.Token == "" {
+		t.Token = t.AccessToken
+	}
+	if t.Token == "" {
+		return "", fmt.Errorf("token service returned no token")
+	}
+	return t.Token, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// parseChallenge parses a WWW-Authenticate header into the lowercase scheme
+// and its parameters.
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme := strings.ToLower(parts[0])
+	if len(parts) < 2 {
+		return scheme, params
+	}
+
+	s := parts[1]
+	for s != "" {
+		i := strings.Index(s, "=")
+		if i < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:i]))
+		s = strings.TrimSpace(s[i+1:])
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else if end := strings.Index(s, ","); end >= 0 {
+			value, s = s[:end], s[end:]
+		} else {
+			value, s = s, ""
+		}
+		params[key] = value
+		s = strings.TrimLeft(s, ", ")
+	}
+	return scheme, params
+}
+
+// checkResponse returns an error unless resp has the expected status code.
+// Registry error messages are included in the error.
+func checkResponse(resp *http.Response, expected int) error {
+	if resp.StatusCode == expected {
+		return nil
+	}
+	var e struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&e); err == nil && len(e.Errors) > 0 {
+		return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL, e.Errors[0].Code, e.Errors[0].Message)
+	}
+	return fmt.Errorf("%s %s: %s", resp.Request.Method, resp.Request.URL, resp.Status)
+}
+
+// digest returns the sha256 digest of data in OCI form.
+func digest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+// isLoopback reports whether host, with an optional port, is a loopback
+// address.
+func isLoopback(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRegistry is an in-memory registry that requires a bearer token from
+// its token service at /token.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+	url       string
+}
+
+func newFakeRegistry() (*fakeRegistry, *httptest.Server) {
+	r := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	srv := httptest.NewServer(r)
+	r.url = srv.URL
+	return r, srv
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path == "/token" {
+		if user, pass, ok := req.BasicAuth(); !ok || user != "helm" || pass != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"token": "t0ken"}`)
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer t0ken" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, r.url))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := strings.TrimPrefix(req.URL.Path, "/v2/charts/mychart/")
+	body, _ := ioutil.ReadAll(req.Body)
+	switch {
+	case req.Method == "POST" && p == "blobs/uploads/":
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/charts/mychart/blobs/uploads/%d", r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == "PUT" && strings.HasPrefix(p, "blobs/uploads/"):
+		r.blobs[req.URL.Query().Get("digest")] = body
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == "PUT" && strings.HasPrefix(p, "manifests/"):
+		r.manifests[strings.TrimPrefix(p, "manifests/")] = body
+		r.manifests[digest(body)] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(p, "blobs/"):
+		b, ok := r.blobs[strings.TrimPrefix(p, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case strings.HasPrefix(p, "manifests/"):
+		m, ok := r.manifests[strings.TrimPrefix(p, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors": [{"code": "MANIFEST_UNKNOWN", "message": "manifest unknown"}]}`)
+			return
+		}
+		w.Header().Set("Content-Type", ManifestMediaType)
+		w.Write(m)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClientPushPull(t *testing.T) {
+	fake, srv := newFakeRegistry()
+	defer srv.Close()
+
+	data, err := ioutil.ReadFile("testdata/compressedchart-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient()
+	c.Credentials = func(host string) (string, string, error) {
+		return "helm", "s3cret", nil
+	}
+
+	ref, err := ParseReference("oci://" + strings.TrimPrefix(srv.URL, "http://") + "/charts/mychart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushed, err := c.Push(ref, data)
+	if err != nil {
+		t.Fatalf("Push failed: %s", err)
+	}
+	if pushed.Tag != "0.1.0" {
+		t.Errorf("Expected the chart to be tagged with its version, got %q", pushed.Tag)
+	}
+	if _, ok := fake.manifests["0.1.0"]; !ok {
+		t.Error("Expected the registry to hold a manifest tagged 0.1.0")
+	}
+	if _, ok := fake.manifests[pushed.Digest]; !ok {
+		t.Errorf("Expected the registry to hold a manifest with digest %s", pushed.Digest)
+	}
+	if len(fake.blobs) != 2 {
+		t.Errorf("Expected 2 blobs, got %d", len(fake.blobs))
+	}
+
+	// A new client has to authenticate again.
+	c = NewClient()
+	c.Credentials = func(host string) (string, string, error) {
+		return "helm", "s3cret", nil
+	}
+	pulled, err := c.Pull(pushed)
+	if err != nil {
+		t.Fatalf("Pull failed: %s", err)
+	}
+	if !bytes.Equal(pulled, data) {
+		t.Error("Expected the pulled chart to match the pushed one")
+	}
+
+	ref.Tag = "0.2.0"
+	if _, err := c.Pull(ref); err == nil || !strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("Expected a manifest unknown error, got %v", err)
+	}
+
+	c.Credentials = func(host string) (string, string, error) {
+		return "helm", "wrong", nil
+	}
+	c.auth = nil
+	if _, err := c.Pull(pushed); err == nil {
+		t.Error("Expected pulling with wrong credentials to fail")
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:samalba/my-app:pull,push"`)
+	if scheme != "bearer" {
+		t.Errorf("Expected scheme bearer, got %q", scheme)
+	}
+	expect := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:samalba/my-app:pull,push",
+	}
+	for k, v := range expect {
+		if params[k] != v {
+			t.Errorf("Expected %s=%q, got %q", k, v, params[k])
+		}
+	}
+
+	if scheme, _ := parseChallenge(`Basic realm="registry"`); scheme != "basic" {
+		t.Errorf("Expected scheme basic, got %q", scheme)
+	}
+}
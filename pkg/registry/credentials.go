@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CredentialsFunc returns the credentials for a registry host. An empty
+// username means that the registry is accessed anonymously.
+type CredentialsFunc func(host string) (username, password string, err error)
+
+// dockerHubAuthKey is the key that 'docker login' stores Docker Hub
+// credentials under.
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfig is the part of the Docker configuration file that holds
+// registry credentials.
+type dockerConfig struct {
+	Auths       map[string]dockerAuth `json:"auths"`
+	CredsStore  string                `json:"credsStore"`
+	CredHelpers map[string]string     `json:"credHelpers"`
+}
+
+type dockerAuth struct {
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// DockerConfigPath returns the path of the Docker configuration file, which
+// is config.json in $DOCKER_CONFIG or in ~/.docker.
+func DockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = os.Getenv("USERPROFILE")
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// DockerCredentials looks up the credentials for host in the Docker
+// configuration. Credential helpers configured for the host, or for all hosts
+// with 'credsStore', take precedence over credentials stored in the file.
+func DockerCredentials(host string) (string, string, error) {
+	b, err := ioutil.ReadFile(DockerConfigPath())
+	if os.IsNotExist(err) {
+		return "", "", nil
+	} else if err != nil {
+		return "", "", err
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return "", "", fmt.Errorf("could not parse %s: %s", DockerConfigPath(), err)
+	}
+
+	if helper, ok := cfg.CredHelpers[host]; ok {
+		return credentialHelper(helper, host)
+	}
+	if cfg.CredsStore != "" {
+		return credentialHelper(cfg.CredsStore, host)
+	}
+
+	for key, a := range cfg.Auths {
+		if authHost(key) != host {
+			continue
+		}
+		if a.Auth == "" {
+			return a.Username, a.Password, nil
+		}
+		dec, err := base64.StdEncoding.DecodeString(a.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid credentials for %s: %s", host, err)
+		}
+		parts := strings.SplitN(string(dec), ":", 2)
+		if len(parts) != 2 {
+			return "", "", fmt.Errorf("invalid credentials for %s", host)
+		}
+		return parts[0], parts[1], nil
+	}
+	return "", "", nil
+}
+
+// authHost returns the registry host for a key of the 'auths' section, which
+// may be a bare host or a URL.
+func authHost(key string) string {
+	if key == dockerHubAuthKey {
+		return "registry-1.docker.io"
+	}
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "http://")
+	if i := strings.Index(key, "/"); i >= 0 {
+		key = key[:i]
+	}
+	return key
+}
+
+// credentialHelper runs 'docker-credential-NAME get' to look up the
+// credentials for host.
+func credentialHelper(name, host string) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker-credential-"+name, "get")
+	cmd.Stdin = strings.NewReader(host)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		out := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(out, "credentials not found") {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("credential helper %q failed: %s: %s", name, err, out)
+	}
+
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return "", "", fmt.Errorf("credential helper %q returned invalid output: %s", name, err)
+	}
+	return creds.Username, creds.Secret, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDockerCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	old := os.Getenv("DOCKER_CONFIG")
+	os.Setenv("DOCKER_CONFIG", dir)
+	defer os.Setenv("DOCKER_CONFIG", old)
+
+	// "aGVsbTpzM2NyZXQ=" is "helm:s3cret"
+	config := `{
+  "auths": {
+    "registry.example.com": {"auth": "aGVsbTpzM2NyZXQ="},
+    "https://other.example.com/v2/": {"username": "other", "password": "pass"}
+  },
+  "credHelpers": {"helper.example.com": "helm-test-missing"}
+}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		host     string
+		username string
+		password string
+		err      bool
+	}{
+		{host: "registry.example.com", username: "helm", password: "s3cret"},
+		{host: "other.example.com", username: "other", password: "pass"},
+		{host: "unknown.example.com"},
+		{host: "helper.example.com", err: true},
+	}
+
+	for _, tt := range tests {
+		username, password, err := DockerCredentials(tt.host)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: expected error %t, got %v", tt.host, tt.err, err)
+			continue
+		}
+		if username != tt.username || password != tt.password {
+			t.Errorf("%s: expected %q/%q, got %q/%q", tt.host, tt.username, tt.password, username, password)
+		}
+	}
+}
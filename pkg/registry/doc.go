@@ -0,0 +1,29 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package registry stores charts in OCI registries.
+
+A chart is stored as an OCI image manifest with a config blob holding the
+chart metadata and a single layer holding the packaged chart. Charts are
+referenced with the 'oci://' scheme, as in
+
+	oci://registry.example.com/myproject/mychart:0.1.0
+
+Registry credentials are read from the Docker configuration, so that
+'docker login' and Docker credential helpers work for charts as well.
+*/
+package registry // import "k8s.io/helm/pkg/registry"
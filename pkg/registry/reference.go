@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Scheme is the URL scheme of chart references in OCI registries.
+const Scheme = "oci://"
+
+// Reference points at a chart in an OCI registry.
+type Reference struct {
+	// Host is the registry host, with an optional port.
+	Host string
+	// Repository is the path of the chart in the registry.
+	Repository string
+	// Tag is the tag of the chart. It may be empty.
+	Tag string
+	// Digest is the digest of the chart manifest. It may be empty.
+	Digest string
+}
+
+// IsReference reports whether ref is an OCI chart reference.
+func IsReference(ref string) bool {
+	return strings.HasPrefix(ref, Scheme)
+}
+
+// ParseReference parses a reference of the form
+// oci://HOST/REPOSITORY[:TAG][@DIGEST].
+func ParseReference(ref string) (Reference, error) {
+	var r Reference
+	if !IsReference(ref) {
+		return r, fmt.Errorf("invalid chart reference %q: must start with %s", ref, Scheme)
+	}
+	s := strings.TrimPrefix(ref, Scheme)
+
+	if i := strings.Index(s, "@"); i >= 0 {
+		s, r.Digest = s[:i], s[i+1:]
+		if !strings.Contains(r.Digest, ":") {
+			return r, fmt.Errorf("invalid chart reference %q: malformed digest", ref)
+		}
+	}
+
+	i := strings.Index(s, "/")
+	if i <= 0 {
+		return r, fmt.Errorf("invalid chart reference %q: missing repository", ref)
+	}
+	r.Host, s = s[:i], s[i+1:]
+
+	// A colon after the last slash separates the tag; one before it belongs
+	// to the host.
+	if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") {
+		s, r.Tag = s[:i], s[i+1:]
+		if r.Tag == "" {
+			return r, fmt.Errorf("invalid chart reference %q: empty tag", ref)
+		}
+	}
+	r.Repository = strings.Trim(s, "/")
+	if r.Repository == "" {
+		return r, fmt.Errorf("invalid chart reference %q: missing repository", ref)
+	}
+	return r, nil
+}
+
+// Name returns the chart name, the last element of the repository path.
+func (r Reference) Name() string {
+	return path.Base(r.Repository)
+}
+
+// String returns the reference in its oci:// form.
+func (r Reference) String() string {
+	s := Scheme + r.Host + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// manifestRef returns the tag or digest that identifies the manifest,
+// preferring the digest.
+func (r Reference) manifestRef() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref    string
+		expect Reference
+		err    bool
+	}{
+		{
+			ref:    "oci://example.com/charts/mychart",
+			expect: Reference{Host: "example.com", Repository: "charts/mychart"},
+		},
+		{
+			ref:    "oci://localhost:5000/mychart:0.1.0",
+			expect: Reference{Host: "localhost:5000", Repository: "mychart", Tag: "0.1.0"},
+		},
+		{
+			ref:    "oci://example.com/mychart:0.1.0@sha256:abc",
+			expect: Reference{Host: "example.com", Repository: "mychart", Tag: "0.1.0", Digest: "sha256:abc"},
+		},
+		{ref: "https://example.com/mychart", err: true},
+		{ref: "oci://example.com", err: true},
+		{ref: "oci://example.com/mychart:", err: true},
+		{ref: "oci://example.com/mychart@abc", err: true},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseReference(tt.ref)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.ref, tt.err, err)
+			continue
+		}
+		if tt.err {
+			continue
+		}
+		if r != tt.expect {
+			t.Errorf("%q: expected %+v, got %+v", tt.ref, tt.expect, r)
+		}
+		if r.String() != tt.ref {
+			t.Errorf("%q: expected the reference to round trip, got %q", tt.ref, r.String())
+		}
+	}
+}
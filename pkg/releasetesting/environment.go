@@ -19,25 +19,50 @@ package releasetesting
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
+	"sync"
 	"time"
 
 	"k8s.io/kubernetes/pkg/api"
 
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
-	"k8s.io/helm/pkg/tiller/environment"
 )
 
+// KubeClient is the part of environment.KubeClient that runs test pods.
+type KubeClient interface {
+	Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
+	Delete(namespace string, reader io.Reader) error
+	WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (api.PodPhase, error)
+	GetPodLogs(namespace string, reader io.Reader) (string, error)
+}
+
 // Environment encapsulates information about where test suite executes and returns results
 type Environment struct {
 	Namespace  string
-	KubeClient environment.KubeClient
+	KubeClient KubeClient
 	Stream     services.ReleaseService_RunReleaseTestServer
 	Timeout    int64
+	// Parallel runs the tests concurrently, at most MaxParallel at once or
+	// all at once if MaxParallel is not positive.
+	Parallel    bool
+	MaxParallel int
+	// Logs streams the logs of each test pod once it completes.
+	Logs bool
+
+	// streamMu serializes messages to Stream, which isn't safe for
+	// concurrent use.
+	streamMu sync.Mutex
 }
 
 func (env *Environment) createTestPod(test *test) error {
+	// A pod of the same name left over from an earlier run would make the
+	// creation fail, so it is deleted first.
+	if err := env.KubeClient.Delete(env.Namespace, bytes.NewBufferString(test.manifest)); err != nil {
+		log.Printf("Error deleting existing pod %s: %s", test.result.Name, err)
+	}
+
 	b := bytes.NewBufferString(test.manifest)
 	if err := env.KubeClient.Create(env.Namespace, b, env.Timeout, false); err != nil {
 		log.Printf(err.Error())
@@ -62,6 +87,15 @@ func (env *Environment) getTestPodStatus(test *test) (api.PodPhase, error) {
 	return status, err
 }
 
+func (env *Environment) streamLogs(test *test) error {
+	logs, err := env.KubeClient.GetPodLogs(env.Namespace, bytes.NewBufferString(test.manifest))
+	if err != nil {
+		log.Printf("Error getting logs for pod %s: %s", test.result.Name, err)
+		return env.streamError(err.Error())
+	}
+	return env.streamMessage(fmt.Sprintf("POD LOGS: %s\n%s", test.result.Name, logs))
+}
+
 func (env *Environment) streamResult(r *release.TestRun) error {
 	switch r.Status {
 	case release.TestRun_SUCCESS:
@@ -107,6 +141,8 @@ func (env *Environment) streamUnknown(name, info string) error {
 }
 
 func (env *Environment) streamMessage(msg string) error {
+	env.streamMu.Lock()
+	defer env.streamMu.Unlock()
 	resp := &services.TestReleaseResponse{Msg: msg}
 	return env.Stream.Send(resp)
 }
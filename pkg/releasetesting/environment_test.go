@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/release"
@@ -56,10 +57,30 @@ func TestCreateTestPodFailure(t *testing.T) {
 	}
 }
 
+func TestCreateTestPodDeletesExistingPod(t *testing.T) {
+	env := testEnvFixture()
+	kc := &recordingKubeClient{}
+	env.KubeClient = kc
+	test := testFixture()
+
+	if err := env.createTestPod(test); err != nil {
+		t.Fatalf("Expected no error, got an error: %s", err)
+	}
+	if strings.Join(kc.calls, ",") != "delete,create" {
+		t.Errorf("Expected the pod to be deleted before it is created, got calls %v", kc.calls)
+	}
+
+	env.KubeClient = newDeleteFailingKubeClient()
+	if err := env.createTestPod(testFixture()); err != nil {
+		t.Errorf("Expected a failed deletion to be ignored, got: %s", err)
+	}
+}
+
 func TestDeleteTestPods(t *testing.T) {
 	mockTestSuite := testSuiteFixture([]string{manifestWithTestSuccessHook})
 	mockTestEnv := newMockTestingEnvironment()
-	mockTestEnv.KubeClient = newGetFailingKubeClient()
+	kc := newGetFailingKubeClient()
+	mockTestEnv.KubeClient = kc
 
 	mockTestEnv.DeleteTestPods(mockTestSuite.TestManifests)
 
@@ -69,7 +90,7 @@ func TestDeleteTestPods(t *testing.T) {
 	}
 
 	for _, testManifest := range mockTestSuite.TestManifests {
-		if _, err := mockTestEnv.KubeClient.Get(mockTestEnv.Namespace, bytes.NewBufferString(testManifest)); err == nil {
+		if _, err := kc.Get(mockTestEnv.Namespace, bytes.NewBufferString(testManifest)); err == nil {
 			t.Error("Expected error, got nil")
 		}
 	}
@@ -153,3 +174,18 @@ func newCreateFailingKubeClient() *createFailingKubeClient {
 func (p *createFailingKubeClient) Create(ns string, r io.Reader, t int64, shouldWait bool) error {
 	return errors.New("We ran out of budget and couldn't create finding-nemo")
 }
+
+type recordingKubeClient struct {
+	tillerEnv.PrintingKubeClient
+	calls []string
+}
+
+func (p *recordingKubeClient) Create(ns string, r io.Reader, t int64, shouldWait bool) error {
+	p.calls = append(p.calls, "create")
+	return nil
+}
+
+func (p *recordingKubeClient) Delete(ns string, r io.Reader) error {
+	p.calls = append(p.calls, "delete")
+	return nil
+}
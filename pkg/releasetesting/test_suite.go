@@ -19,6 +19,7 @@ package releasetesting
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/ghodss/yaml"
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -68,53 +69,109 @@ func (ts *TestSuite) Run(env *Environment) error {
 		env.streamMessage("No Tests Found")
 	}
 
-	for _, testManifest := range ts.TestManifests {
-		test, err := newTest(testManifest)
-		if err != nil {
+	if env.Parallel {
+		if err := ts.runParallel(env); err != nil {
 			return err
 		}
+	} else {
+		for _, testManifest := range ts.TestManifests {
+			result, err := runTest(env, testManifest)
+			if err != nil {
+				return err
+			}
+			ts.Results = append(ts.Results, result)
+		}
+	}
+
+	ts.CompletedAt = timeconv.Now()
+	return nil
+}
 
-		test.result.StartedAt = timeconv.Now()
-		if err := env.streamRunning(test.result.Name); err != nil {
+// runParallel runs the tests of the suite concurrently, at most
+// env.MaxParallel at once. The results keep the order of the manifests.
+func (ts *TestSuite) runParallel(env *Environment) error {
+	limit := env.MaxParallel
+	if limit <= 0 || limit > len(ts.TestManifests) {
+		limit = len(ts.TestManifests)
+	}
+
+	results := make([]*release.TestRun, len(ts.TestManifests))
+	errs := make([]error, len(ts.TestManifests))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, testManifest := range ts.TestManifests {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, testManifest string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i], errs[i] = runTest(env, testManifest)
+		}(i, testManifest)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
 			return err
 		}
+		ts.Results = append(ts.Results, results[i])
+	}
+	return nil
+}
 
-		resourceCreated := true
-		if err := env.createTestPod(test); err != nil {
-			resourceCreated = false
-			if streamErr := env.streamError(test.result.Info); streamErr != nil {
-				return err
-			}
+// runTest runs the test pod of testManifest, streams its progress and
+// returns its result.
+func runTest(env *Environment, testManifest string) (*release.TestRun, error) {
+	test, err := newTest(testManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	test.result.StartedAt = timeconv.Now()
+	if err := env.streamRunning(test.result.Name); err != nil {
+		return nil, err
+	}
+
+	resourceCreated := true
+	if err := env.createTestPod(test); err != nil {
+		resourceCreated = false
+		if streamErr := env.streamError(test.result.Info); streamErr != nil {
+			return nil, err
 		}
+	}
 
-		resourceCleanExit := true
-		status := api.PodUnknown
-		if resourceCreated {
-			status, err = env.getTestPodStatus(test)
-			if err != nil {
-				resourceCleanExit = false
-				if streamErr := env.streamUnknown(test.result.Name, test.result.Info); streamErr != nil {
-					return streamErr
-				}
+	resourceCleanExit := true
+	status := api.PodUnknown
+	if resourceCreated {
+		status, err = env.getTestPodStatus(test)
+		if err != nil {
+			resourceCleanExit = false
+			if streamErr := env.streamUnknown(test.result.Name, test.result.Info); streamErr != nil {
+				return nil, streamErr
 			}
 		}
+	}
 
-		if resourceCreated && resourceCleanExit {
-			if err := test.assignTestResult(status); err != nil {
-				return err
-			}
+	if resourceCreated && resourceCleanExit {
+		if err := test.assignTestResult(status); err != nil {
+			return nil, err
+		}
 
-			if err := env.streamResult(test.result); err != nil {
-				return err
-			}
+		if err := env.streamResult(test.result); err != nil {
+			return nil, err
 		}
+	}
 
-		test.result.CompletedAt = timeconv.Now()
-		ts.Results = append(ts.Results, test.result)
+	if resourceCreated && env.Logs {
+		if err := env.streamLogs(test); err != nil {
+			return nil, err
+		}
 	}
 
-	ts.CompletedAt = timeconv.Now()
-	return nil
+	test.result.CompletedAt = timeconv.Now()
+	return test.result, nil
 }
 
 func (t *test) assignTestResult(podStatus api.PodPhase) error {
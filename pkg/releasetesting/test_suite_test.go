@@ -18,6 +18,7 @@ package releasetesting
 
 import (
 	"io"
+	"io/ioutil"
 	"os"
 	"testing"
 	"time"
@@ -208,6 +209,66 @@ func TestRunSuccessWithTestFailureHook(t *testing.T) {
 	}
 }
 
+func TestRunParallel(t *testing.T) {
+	ts := testSuiteFixture([]string{manifestWithTestSuccessHook, manifestWithTestFailureHook, manifestWithTestSuccessHook})
+	env := testEnvFixture()
+	env.Parallel = true
+	env.MaxParallel = 2
+	kc := newBlockingKubeClient()
+	env.KubeClient = kc
+
+	done := make(chan error)
+	go func() { done <- ts.Run(env) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-kc.started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected 2 tests to run at once, got %d", i)
+		}
+	}
+	select {
+	case <-kc.started:
+		t.Fatal("Expected no more than 2 tests to run at once")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(kc.release)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if len(ts.Results) != 3 {
+		t.Fatalf("Expected 3 test results, got %d", len(ts.Results))
+	}
+	for i, name := range []string{"finding-nemo", "gold-rush", "finding-nemo"} {
+		if ts.Results[i].Name != name {
+			t.Errorf("Expected result %d to be %s, got %s", i, name, ts.Results[i].Name)
+		}
+	}
+	if stream := env.Stream.(*mockStream); len(stream.messages) != 6 {
+		t.Errorf("Expected 6 messages, got %d", len(stream.messages))
+	}
+}
+
+func TestRunWithLogs(t *testing.T) {
+	ts := testSuiteFixture([]string{manifestWithTestFailureHook})
+	env := testEnvFixture()
+	env.Logs = true
+	env.KubeClient = newPodFailedKubeClient()
+	if err := ts.Run(env); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := env.Stream.(*mockStream)
+	if len(stream.messages) != 3 {
+		t.Fatalf("Expected 3 messages, got %d", len(stream.messages))
+	}
+	expect := "POD LOGS: gold-rush\nCONTAINER: gold-finding-test\nno gold found\n"
+	if msg := stream.messages[2].Msg; msg != expect {
+		t.Errorf("Expected message %q, got %q", expect, msg)
+	}
+}
+
 func TestExtractTestManifestsFromHooks(t *testing.T) {
 	rel := releaseStub()
 	testManifests, err := extractTestManifestsFromHooks(rel.Hooks)
@@ -341,3 +402,28 @@ func newPodFailedKubeClient() *podFailedKubeClient {
 func (p *podFailedKubeClient) WaitAndGetCompletedPodPhase(ns string, r io.Reader, timeout time.Duration) (api.PodPhase, error) {
 	return api.PodFailed, nil
 }
+
+func (p *podFailedKubeClient) GetPodLogs(ns string, r io.Reader) (string, error) {
+	return "CONTAINER: gold-finding-test\nno gold found\n", nil
+}
+
+// blockingKubeClient blocks waiting for test pods until release is closed.
+type blockingKubeClient struct {
+	tillerEnv.PrintingKubeClient
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingKubeClient() *blockingKubeClient {
+	return &blockingKubeClient{
+		PrintingKubeClient: tillerEnv.PrintingKubeClient{Out: ioutil.Discard},
+		started:            make(chan struct{}, 3),
+		release:            make(chan struct{}),
+	}
+}
+
+func (p *blockingKubeClient) WaitAndGetCompletedPodPhase(ns string, r io.Reader, timeout time.Duration) (api.PodPhase, error) {
+	p.started <- struct{}{}
+	<-p.release
+	return api.PodSucceeded, nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	"github.com/ghodss/yaml"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/tlsutil"
 	"k8s.io/helm/pkg/urlutil"
@@ -40,6 +42,8 @@ type Entry struct {
 	CertFile string `json:"certFile"`
 	KeyFile  string `json:"keyFile"`
 	CAFile   string `json:"caFile"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 // ChartRepository represents a chart repository
@@ -48,6 +52,9 @@ type ChartRepository struct {
 	ChartPaths []string
 	IndexFile  *IndexFile
 	Client     *http.Client
+	// Plugins are the plugins that download URLs of other protocols than
+	// HTTP and HTTPS.
+	Plugins []*plugin.Plugin
 }
 
 // Getter is an interface to support GET to the specified URL.
@@ -58,7 +65,7 @@ type Getter interface {
 // NewChartRepository constructs ChartRepository
 func NewChartRepository(cfg *Entry) (*ChartRepository, error) {
 	var client *http.Client
-	if cfg.CertFile != "" && cfg.KeyFile != "" && cfg.CAFile != "" {
+	if cfg.CertFile != "" || cfg.KeyFile != "" || cfg.CAFile != "" {
 		tlsConf, err := tlsutil.NewClientTLS(cfg.CertFile, cfg.KeyFile, cfg.CAFile)
 		if err != nil {
 			return nil, fmt.Errorf("can't create TLS config for client: %s", err.Error())
@@ -88,14 +95,98 @@ func NewChartRepository(cfg *Entry) (*ChartRepository, error) {
 }
 
 // Get issues a GET using configured client to the specified URL.
-func (r *ChartRepository) Get(url string) (*http.Response, error) {
-	resp, err := r.Client.Get(url)
+//
+// If the repository is configured with credentials, they are sent with
+// requests to the host of the repository, but not to other hosts.
+func (r *ChartRepository) Get(href string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", href, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.do(req)
+}
+
+// do sends req with the credentials of the repository.
+//
+// GET requests for URLs of other protocols than HTTP and HTTPS are passed
+// to the downloader plugin for the protocol.
+func (r *ChartRepository) do(req *http.Request) (*http.Response, error) {
+	if scheme := req.URL.Scheme; scheme != "http" && scheme != "https" && req.Method == "GET" {
+		return r.pluginGet(req)
+	}
+
+	if (r.Config.Username != "" || r.Config.Password != "") && r.sameHost(req.URL) {
+		req.SetBasicAuth(r.Config.Username, r.Config.Password)
+	}
+
+	resp, err := r.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
+// pluginGet downloads the URL of req with a downloader plugin.
+func (r *ChartRepository) pluginGet(req *http.Request) (*http.Response, error) {
+	p, d := plugin.FindDownloader(r.Plugins, req.URL.Scheme)
+	if p == nil {
+		return nil, fmt.Errorf("no downloader plugin for the %q protocol of %s", req.URL.Scheme, req.URL)
+	}
+	body, err := p.Download(d, req.URL.String(), r.Config.CertFile, r.Config.KeyFile, r.Config.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{},
+		Body:          ioutil.NopCloser(body),
+		ContentLength: int64(body.Len()),
+		Request:       req,
+	}, nil
+}
+
+// UploadChart uploads the chart archive at path to the repository, as served
+// by 'helm serve'. If force is set, an existing version of the chart is
+// replaced.
+func (r *ChartRepository) UploadChart(path string, force bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	u := strings.TrimSuffix(r.Config.URL, "/") + UploadPath
+	if force {
+		u += "?force=true"
+	}
+	req, err := http.NewRequest("POST", u, f)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload %s to %s: %s: %s", filepath.Base(path), r.Config.URL, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// sameHost reports whether u points to the host of the repository.
+func (r *ChartRepository) sameHost(u *url.URL) bool {
+	ru, err := url.Parse(r.Config.URL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ru.Host, u.Host)
+}
+
 // Load loads a directory of charts as if it were a repository.
 //
 // It requires the presence of an index.yaml file in the directory.
@@ -133,14 +224,45 @@ func (r *ChartRepository) Load() error {
 // cachePath is prepended to any index that does not have an absolute path. This
 // is for pre-2.2.0 repo files.
 func (r *ChartRepository) DownloadIndexFile(cachePath string) error {
-	var indexURL string
+	// In Helm 2.2.0 the config.cache was accidentally switched to an absolute
+	// path, which broke backward compatibility. This fixes it by prepending a
+	// global cache path to relative paths.
+	//
+	// It is changed on DownloadIndexFile because that was the method that
+	// originally carried the cache path.
+	cp := r.Config.Cache
+	if !filepath.IsAbs(cp) {
+		cp = filepath.Join(cachePath, cp)
+	}
+
+	indexURL := strings.TrimSuffix(r.Config.URL, "/") + "/index.yaml"
+	req, err := http.NewRequest("GET", indexURL, nil)
+	if err != nil {
+		return err
+	}
+
+	// Only ask for changes if the cached index is still around.
+	validators, _ := loadIndexValidators(cp)
+	if _, err := os.Stat(cp); err == nil && validators != nil {
+		if validators.ETag != "" {
+			req.Header.Set("If-None-Match", validators.ETag)
+		}
+		if validators.LastModified != "" {
+			req.Header.Set("If-Modified-Since", validators.LastModified)
+		}
+	}
 
-	indexURL = strings.TrimSuffix(r.Config.URL, "/") + "/index.yaml"
-	resp, err := r.Get(indexURL)
+	resp, err := r.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s : %s", indexURL, resp.Status)
+	}
 
 	index, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -151,18 +273,53 @@ func (r *ChartRepository) DownloadIndexFile(cachePath string) error {
 		return err
 	}
 
-	// In Helm 2.2.0 the config.cache was accidentally switched to an absolute
-	// path, which broke backward compatibility. This fixes it by prepending a
-	// global cache path to relative paths.
-	//
-	// It is changed on DownloadIndexFile because that was the method that
-	// originally carried the cache path.
-	cp := r.Config.Cache
-	if !filepath.IsAbs(cp) {
-		cp = filepath.Join(cachePath, cp)
+	if err := ioutil.WriteFile(cp, index, 0644); err != nil {
+		return err
+	}
+	return writeIndexValidators(cp, &indexValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	})
+}
+
+// indexValidators are the HTTP cache validators of a downloaded index, used
+// to only download the index again once it has changed.
+type indexValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// indexValidatorsPath returns the path of the validators of the index cached
+// at indexPath.
+func indexValidatorsPath(indexPath string) string {
+	return indexPath + ".validators"
+}
+
+func loadIndexValidators(indexPath string) (*indexValidators, error) {
+	b, err := ioutil.ReadFile(indexValidatorsPath(indexPath))
+	if err != nil {
+		return nil, err
+	}
+	v := &indexValidators{}
+	if err := yaml.Unmarshal(b, v); err != nil {
+		return nil, err
 	}
+	return v, nil
+}
 
-	return ioutil.WriteFile(cp, index, 0644)
+func writeIndexValidators(indexPath string, v *indexValidators) error {
+	p := indexValidatorsPath(indexPath)
+	if v.ETag == "" && v.LastModified == "" {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, b, 0644)
 }
 
 // Index generates an index for the chart repository and writes an index.yaml file.
@@ -17,12 +17,17 @@ limitations under the License.
 package repo
 
 import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
@@ -183,3 +188,112 @@ func verifyIndex(t *testing.T, actual *IndexFile) {
 		}
 	}
 }
+
+func TestChartRepositoryBasicAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	r, err := NewChartRepository(&Entry{Name: "auth", URL: srv.URL, Username: "user", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := r.Get(srv.URL + "/index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	// credentials are not sent to other hosts
+	other, err := NewChartRepository(&Entry{Name: "other", URL: "http://example-charts.com", Username: "user", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = other.Get(srv.URL + "/index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	anon, err := NewChartRepository(&Entry{Name: "anon", URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := anon.DownloadIndexFile(os.TempDir()); err == nil {
+		t.Error("Expected an error downloading an index without credentials")
+	}
+}
+
+func TestChartRepositoryCAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	ca, err := ioutil.TempFile("", "helm-repo-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(ca.Name())
+	if err := pem.Encode(ca, &pem.Block{Type: "CERTIFICATE", Bytes: srv.TLS.Certificates[0].Certificate[0]}); err != nil {
+		t.Fatal(err)
+	}
+	ca.Close()
+
+	r, err := NewChartRepository(&Entry{Name: "tls", URL: srv.URL, CAFile: ca.Name()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := r.Get(srv.URL + "/index.yaml")
+	if err != nil {
+		t.Fatalf("Expected the server to be verified with the CA file, got %s", err)
+	}
+	resp.Body.Close()
+
+	if _, err := NewChartRepository(&Entry{Name: "tls", URL: srv.URL, CertFile: "cert.pem"}); err == nil {
+		t.Error("Expected an error for a certificate without a key")
+	}
+}
+
+func TestDownloadIndexFileWithPlugin(t *testing.T) {
+	plugins, err := plugin.LoadAll("testdata/plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := filepath.Abs("testdata/server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := ioutil.TempDir("", "helm-repo-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cache)
+
+	r, err := NewChartRepository(&Entry{Name: "fake", URL: "fake://" + dir, Cache: "fake-index.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DownloadIndexFile(cache); err == nil {
+		t.Error("Expected an error without a downloader plugin")
+	}
+
+	r.Plugins = plugins
+	if err := r.DownloadIndexFile(cache); err != nil {
+		t.Fatalf("Failed to download the index with a plugin: %s", err)
+	}
+	if _, err := LoadIndexFile(filepath.Join(cache, "fake-index.yaml")); err != nil {
+		t.Errorf("Expected the downloaded index to load: %s", err)
+	}
+}
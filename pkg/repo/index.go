@@ -132,6 +132,21 @@ func (i IndexFile) Has(name, version string) bool {
 	return err == nil
 }
 
+// remove removes the given version of the named chart from the index.
+func (i IndexFile) remove(name, version string) {
+	var kept ChartVersions
+	for _, cv := range i.Entries[name] {
+		if cv.Version != version {
+			kept = append(kept, cv)
+		}
+	}
+	if len(kept) == 0 {
+		delete(i.Entries, name)
+		return
+	}
+	i.Entries[name] = kept
+}
+
 // SortEntries sorts the entries by version in descending order.
 //
 // In canonical form, the individual version records should be sorted so that
@@ -177,6 +177,67 @@ func TestDownloadIndexFile(t *testing.T) {
 	verifyLocalIndex(t, i)
 }
 
+func TestDownloadIndexFileNotModified(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("testdata/local-index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const etag = `"v1"`
+	downloads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", etag)
+		w.Write(fileBytes)
+	}))
+	defer srv.Close()
+
+	dirName, err := ioutil.TempDir("", "tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirName)
+
+	indexFilePath := filepath.Join(dirName, testRepo+"-index.yaml")
+	r, err := NewChartRepository(&Entry{
+		Name:  testRepo,
+		URL:   srv.URL,
+		Cache: indexFilePath,
+	})
+	if err != nil {
+		t.Fatalf("Problem creating chart repository from %s: %v", testRepo, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := r.DownloadIndexFile(""); err != nil {
+			t.Fatalf("%#v", err)
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("Expected the unchanged index to be downloaded once, got %d downloads", downloads)
+	}
+
+	// a missing cached index is downloaded again
+	if err := os.Remove(indexFilePath); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DownloadIndexFile(""); err != nil {
+		t.Fatalf("%#v", err)
+	}
+	if downloads != 2 {
+		t.Errorf("Expected the missing index to be downloaded, got %d downloads", downloads)
+	}
+	i, err := LoadIndexFile(indexFilePath)
+	if err != nil {
+		t.Fatalf("Index failed to load: %s", err)
+	}
+	verifyLocalIndex(t, i)
+}
+
 func verifyLocalIndex(t *testing.T, i *IndexFile) {
 	numEntries := len(i.Entries)
 	if numEntries != 2 {
@@ -17,12 +17,17 @@ limitations under the License.
 package repo
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"fmt"
 	htemplate "html/template"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/ghodss/yaml"
 
@@ -51,15 +56,42 @@ const indexHTMLTemplate = `
 </html>
 `
 
+// UploadPath is the path of the API to which charts are uploaded.
+const UploadPath = "/api/charts"
+
+// maxUploadSize is the size limit of an uploaded chart archive.
+const maxUploadSize = 20 * 1024 * 1024
+
 // RepositoryServer is an HTTP handler for serving a chart repository.
+//
+// If Username or Password is set, requests must authenticate with them using
+// HTTP basic authentication.
+//
+// Packaged charts can be uploaded by POSTing them to UploadPath. They are
+// added to the index with URLs relative to URL. Uploads are only accepted
+// when the server requires authentication.
 type RepositoryServer struct {
 	RepoPath string
+	URL      string
+	Username string
+	Password string
+
+	// mu serializes uploads, which rewrite the index.
+	mu sync.Mutex
 }
 
 // ServeHTTP implements the http.Handler interface.
 func (s *RepositoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="Helm Repository"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	uri := r.URL.Path
 	switch uri {
+	case UploadPath:
+		s.upload(w, r)
 	case "/", "/charts/", "/charts/index.html", "/charts/index":
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		s.htmlIndex(w, r)
@@ -78,6 +110,115 @@ func StartLocalRepo(path, address string) error {
 	return http.ListenAndServe(address, s)
 }
 
+// upload saves the chart archive in the body of r to the repository and adds
+// it to the index. Existing chart versions are only replaced if the "force"
+// query parameter is set.
+func (s *RepositoryServer) upload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.Username == "" && s.Password == "" {
+		http.Error(w, "uploads are only accepted by repositories that require authentication", http.StatusForbidden)
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxUploadSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxUploadSize {
+		http.Error(w, "chart archive is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	ch, err := chartutil.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid chart archive: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	indexPath := filepath.Join(s.RepoPath, "index.yaml")
+	i, err := LoadIndexFile(indexPath)
+	if os.IsNotExist(err) {
+		i, err = NewIndexFile(), nil
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	md := ch.Metadata
+	filename, err := s.chartPath(md)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+	if i.Has(md.Name, md.Version) {
+		if !force {
+			http.Error(w, fmt.Sprintf("%s-%s already exists", md.Name, md.Version), http.StatusConflict)
+			return
+		}
+		i.remove(md.Name, md.Version)
+	}
+
+	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	digest, err := provenance.DigestFile(filename)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	i.Add(md, filepath.Base(filename), s.URL, digest)
+	i.SortEntries()
+	if err := i.WriteFile(indexPath, 0644); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "%s-%s has been added to the repository\n", md.Name, md.Version)
+}
+
+// chartPath returns the path in the repository of the archive of the chart
+// md describes. The name and version come from the uploaded Chart.yaml, so
+// they must not lead out of the repository.
+func (s *RepositoryServer) chartPath(md *chart.Metadata) (string, error) {
+	for _, v := range []string{md.Name, md.Version} {
+		if v == "" || strings.ContainsAny(v, `/\`) || strings.Contains(v, "..") {
+			return "", fmt.Errorf("invalid chart name or version %q", v)
+		}
+	}
+	repoPath := filepath.Clean(s.RepoPath)
+	filename := filepath.Join(repoPath, fmt.Sprintf("%s-%s.tgz", md.Name, md.Version))
+	if filepath.Dir(filename) != repoPath {
+		return "", fmt.Errorf("invalid chart name or version %s-%s", md.Name, md.Version)
+	}
+	return filename, nil
+}
+
+// authorized reports whether r carries the credentials of the server.
+func (s *RepositoryServer) authorized(r *http.Request) bool {
+	if s.Username == "" && s.Password == "" {
+		return true
+	}
+	u, p, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	userOK := subtle.ConstantTimeCompare([]byte(u), []byte(s.Username)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(p), []byte(s.Password)) == 1
+	return userOK && passOK
+}
+
 func (s *RepositoryServer) htmlIndex(w http.ResponseWriter, r *http.Request) {
 	t := htemplate.Must(htemplate.New("index.html").Parse(indexHTMLTemplate))
 	// load index
@@ -17,9 +17,16 @@ limitations under the License.
 package repo
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/pem"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -63,3 +70,231 @@ func TestRepositoryServer(t *testing.T) {
 	}
 
 }
+
+func TestRepositoryServerBasicAuth(t *testing.T) {
+	s := &RepositoryServer{RepoPath: "testdata/server", Username: "admin", Password: "s3cr3t"}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		user     string
+		pass     string
+		useAuth  bool
+		expected int
+	}{
+		{"no credentials", "", "", false, http.StatusUnauthorized},
+		{"wrong password", "admin", "guess", true, http.StatusUnauthorized},
+		{"valid credentials", "admin", "s3cr3t", true, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", srv.URL+"/charts/index.yaml", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.useAuth {
+			req.SetBasicAuth(tt.user, tt.pass)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode != tt.expected {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.expected, res.StatusCode)
+		}
+	}
+}
+
+func TestRepositoryServerTLS(t *testing.T) {
+	expectedIndexYAML, err := ioutil.ReadFile("testdata/server/index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &RepositoryServer{RepoPath: "testdata/server", Username: "admin", Password: "s3cr3t"}
+	srv := httptest.NewTLSServer(s)
+	defer srv.Close()
+
+	ca, err := ioutil.TempFile("", "helm-serve-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(ca.Name())
+	if err := pem.Encode(ca, &pem.Block{Type: "CERTIFICATE", Bytes: srv.TLS.Certificates[0].Certificate[0]}); err != nil {
+		t.Fatal(err)
+	}
+	ca.Close()
+
+	r, err := NewChartRepository(&Entry{Name: "tls", URL: srv.URL, CAFile: ca.Name(), Username: "admin", Password: "s3cr3t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := r.Get(srv.URL + "/charts/index.yaml")
+	if err != nil {
+		t.Fatalf("Expected the index to be served over HTTPS, got %s", err)
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || string(body) != string(expectedIndexYAML) {
+		t.Errorf("Expected the index with status %d, got %d: %q", http.StatusOK, res.StatusCode, body)
+	}
+
+	if _, err := http.Get(srv.URL + "/charts/index.yaml"); err == nil {
+		t.Error("Expected an error for a client that does not trust the certificate")
+	}
+}
+
+func TestRepositoryServerUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &RepositoryServer{RepoPath: dir, Username: "admin", Password: "s3cr3t"}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	s.URL = srv.URL
+
+	r, err := NewChartRepository(&Entry{Name: "upload", URL: srv.URL, Username: "admin", Password: "s3cr3t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chart := "testdata/repository/frobnitz-1.2.3.tgz"
+	if err := r.UploadChart(chart, false); err != nil {
+		t.Fatalf("Failed to upload chart: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "frobnitz-1.2.3.tgz")); err != nil {
+		t.Errorf("Expected the chart to be saved: %s", err)
+	}
+
+	i, err := LoadIndexFile(filepath.Join(dir, "index.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cv, err := i.Get("frobnitz", "1.2.3")
+	if err != nil {
+		t.Fatalf("Expected the chart to be indexed: %s", err)
+	}
+	if expect := srv.URL + "/frobnitz-1.2.3.tgz"; len(cv.URLs) != 1 || cv.URLs[0] != expect {
+		t.Errorf("Expected URLs [%s], got %v", expect, cv.URLs)
+	}
+	if cv.Digest == "" {
+		t.Error("Expected a digest")
+	}
+
+	if err := r.UploadChart(chart, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an error uploading an existing chart version, got %v", err)
+	}
+	if err := r.UploadChart(chart, true); err != nil {
+		t.Errorf("Expected a forced upload to replace the chart, got %s", err)
+	}
+	if i, err = LoadIndexFile(filepath.Join(dir, "index.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(i.Entries["frobnitz"]); n != 1 {
+		t.Errorf("Expected 1 version of frobnitz, got %d", n)
+	}
+
+	anon, err := NewChartRepository(&Entry{Name: "anon", URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := anon.UploadChart(chart, true); err == nil {
+		t.Error("Expected an unauthenticated upload to fail")
+	}
+}
+
+func TestRepositoryServerUploadWithoutAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &RepositoryServer{RepoPath: dir}
+	data, err := ioutil.ReadFile("testdata/repository/frobnitz-1.2.3.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", UploadPath, bytes.NewReader(data))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, rec.Code)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "frobnitz-1.2.3.tgz")); !os.IsNotExist(err) {
+		t.Errorf("Expected the chart not to be saved, got %v", err)
+	}
+}
+
+func TestRepositoryServerUploadPathTraversal(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "repo")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &RepositoryServer{RepoPath: dir, Username: "admin", Password: "s3cr3t"}
+	tests := []struct {
+		name, version string
+	}{
+		{"../evil", "1.0.0"},
+		{"evil", "1.0.0/../../evil"},
+		{"..", "1.0.0"},
+		{`..\evil`, "1.0.0"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", UploadPath, bytes.NewReader(chartArchive(t, tt.name, tt.version)))
+		req.SetBasicAuth("admin", "s3cr3t")
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s-%s: expected status %d, got %d: %s", tt.name, tt.version, http.StatusBadRequest, rec.Code, rec.Body)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(tmp, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected nothing to be written outside the repository, got %v", files)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected nothing to be written to the repository, got %d files", len(files))
+	}
+}
+
+// chartArchive returns a packaged chart with the given name and version.
+func chartArchive(t *testing.T, name, version string) []byte {
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(zw)
+	chartfile := []byte(fmt.Sprintf("name: %q\nversion: %q\n", name, version))
+	hdr := &tar.Header{Name: "evil/Chart.yaml", Mode: 0644, Size: int64(len(chartfile))}
+	if err := tw.WriteHeader(hdr); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(chartfile); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,3 @@
+#!/bin/sh
+# Prints the local file that a fake:// URL points at.
+exec cat "${4#fake://}"
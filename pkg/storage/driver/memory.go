@@ -141,6 +141,12 @@ func (mem *Memory) Delete(key string) (*rspb.Release, error) {
 		}
 		if recs, ok := mem.cache[name]; ok {
 			if r := recs.Remove(key); r != nil {
+				// recs.Remove changes the slice reference, so we have to re-assign it.
+				if len(recs) == 0 {
+					delete(mem.cache, name)
+				} else {
+					mem.cache[name] = recs
+				}
 				return r.rls, nil
 			}
 		}
@@ -165,4 +165,15 @@ func TestMemoryDelete(t *testing.T) {
 			}
 		}
 	}
+
+	// the deleted release must no longer show up in queries
+	rls, err := ts.Query(map[string]string{"NAME": "rls-a"})
+	if err != nil {
+		t.Fatalf("Failed to query: %s", err)
+	}
+	for _, r := range rls {
+		if r == nil || r.Version == 1 {
+			t.Errorf("Expected rls-a.v1 to be deleted, got %v", r)
+		}
+	}
 }
@@ -0,0 +1,305 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+var _ Driver = (*SQL)(nil)
+
+// SQLDriverName is the string name of the driver.
+const SQLDriverName = "SQL"
+
+// sqlLabelColumns maps the labels used in queries to the columns of the
+// releases table.
+var sqlLabelColumns = map[string]string{
+	"NAME":    "name",
+	"OWNER":   "owner",
+	"STATUS":  "status",
+	"VERSION": "version",
+}
+
+// sqlMigrations are the schema changes of the releases table, in the order in
+// which they are applied. Applied migrations are recorded in the
+// releases_schema table; new migrations must only ever be appended.
+var sqlMigrations = []string{
+	`CREATE TABLE releases (
+		key VARCHAR(67) PRIMARY KEY,
+		body TEXT NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		version INTEGER NOT NULL,
+		status VARCHAR(32) NOT NULL,
+		owner VARCHAR(32) NOT NULL,
+		created_at BIGINT NOT NULL,
+		modified_at BIGINT NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX releases_name_idx ON releases (name)`,
+}
+
+// SQL is the storage driver that keeps releases in a table of an SQL
+// database. The queries are written for PostgreSQL.
+type SQL struct {
+	db *sql.DB
+}
+
+// NewSQL initializes a new SQL driver on db and migrates the schema of the
+// releases table to the latest version.
+func NewSQL(db *sql.DB) (*SQL, error) {
+	s := &SQL{db: db}
+	if err := s.migrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate the database schema: %s", err)
+	}
+	return s, nil
+}
+
+// Name returns the name of the driver.
+func (s *SQL) Name() string {
+	return SQLDriverName
+}
+
+// migrate applies the migrations that have not been applied yet.
+func (s *SQL) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS releases_schema (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM releases_schema`).Scan(&current); err != nil {
+		return err
+	}
+
+	for i := current; i < len(sqlMigrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqlMigrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %s", i+1, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO releases_schema (version) VALUES ($1)`, i+1); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %s", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Printf("sql: applied schema migration %d", i+1)
+	}
+	return nil
+}
+
+// Get fetches the release named by key. The corresponding release is returned
+// or error if not found.
+func (s *SQL) Get(key string) (*rspb.Release, error) {
+	var body string
+	err := s.db.QueryRow(`SELECT body FROM releases WHERE key = $1`, key).Scan(&body)
+	if err == sql.ErrNoRows {
+		return nil, ErrReleaseNotFound
+	}
+	if err != nil {
+		logSQLErrf(err, "get: failed to get %q", key)
+		return nil, err
+	}
+
+	r, err := decodeRelease(body)
+	if err != nil {
+		logSQLErrf(err, "get: failed to decode data %q", key)
+		return nil, err
+	}
+	return r, nil
+}
+
+// List fetches all releases and returns the list releases such
+// that filter(release) == true.
+func (s *SQL) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	rels, err := s.query(`SELECT body FROM releases WHERE owner = $1`, "TILLER")
+	if err != nil {
+		logSQLErrf(err, "list: failed to list")
+		return nil, err
+	}
+
+	var results []*rspb.Release
+	for _, rls := range rels {
+		if filter(rls) {
+			results = append(results, rls)
+		}
+	}
+	return results, nil
+}
+
+// Query fetches all releases that match the provided map of labels.
+func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
+	q, args, err := sqlLabelQuery(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	rels, err := s.query(q, args...)
+	if err != nil {
+		logSQLErrf(err, "query: failed to query with labels")
+		return nil, err
+	}
+	if len(rels) == 0 {
+		return nil, ErrReleaseNotFound
+	}
+	return rels, nil
+}
+
+// Create stores the release under key. If a release is already stored
+// under key, ErrReleaseExists is returned.
+func (s *SQL) Create(key string, rls *rspb.Release) error {
+	body, err := encodeRelease(rls)
+	if err != nil {
+		logSQLErrf(err, "create: failed to encode release %q", rls.Name)
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var exists int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM releases WHERE key = $1`, key).Scan(&exists)
+	if err == nil && exists > 0 {
+		err = ErrReleaseExists
+	}
+	if err == nil {
+		_, err = tx.Exec(
+			`INSERT INTO releases (key, body, name, version, status, owner, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			key, body, rls.Name, rls.Version, rls.Info.Status.Code.String(), "TILLER", time.Now().Unix(),
+		)
+	}
+	if err != nil {
+		tx.Rollback()
+		if err != ErrReleaseExists {
+			logSQLErrf(err, "create: failed to create")
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update updates the release stored under key. If no release is stored under
+// key, ErrReleaseNotFound is returned.
+func (s *SQL) Update(key string, rls *rspb.Release) error {
+	body, err := encodeRelease(rls)
+	if err != nil {
+		logSQLErrf(err, "update: failed to encode release %q", rls.Name)
+		return err
+	}
+
+	res, err := s.db.Exec(
+		`UPDATE releases SET body = $1, status = $2, modified_at = $3 WHERE key = $4`,
+		body, rls.Info.Status.Code.String(), time.Now().Unix(), key,
+	)
+	if err != nil {
+		logSQLErrf(err, "update: failed to update")
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrReleaseNotFound
+	}
+	return nil
+}
+
+// Delete deletes the release stored under key.
+func (s *SQL) Delete(key string) (*rspb.Release, error) {
+	rls, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec(`DELETE FROM releases WHERE key = $1`, key); err != nil {
+		logSQLErrf(err, "delete: failed to delete %q", key)
+		return rls, err
+	}
+	return rls, nil
+}
+
+// query runs a query that selects the body of releases and decodes them.
+// Releases that fail to decode are skipped.
+func (s *SQL) query(q string, args ...interface{}) ([]*rspb.Release, error) {
+	rows, err := s.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*rspb.Release
+	for rows.Next() {
+		var body string
+		if err := rows.Scan(&body); err != nil {
+			return nil, err
+		}
+		rls, err := decodeRelease(body)
+		if err != nil {
+			logSQLErrf(err, "failed to decode release")
+			continue
+		}
+		results = append(results, rls)
+	}
+	return results, rows.Err()
+}
+
+// sqlLabelQuery builds the query selecting the releases that match labels.
+func sqlLabelQuery(labels map[string]string) (string, []interface{}, error) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	conds := []string{}
+	args := []interface{}{}
+	for _, k := range keys {
+		col, ok := sqlLabelColumns[k]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown label %q", k)
+		}
+		var v interface{} = labels[k]
+		if col == "version" {
+			n, err := strconv.Atoi(labels[k])
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid label value: %q: %s", labels[k], err)
+			}
+			v = n
+		}
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf("%s = $%d", col, len(args)))
+	}
+
+	q := `SELECT body FROM releases`
+	if len(conds) > 0 {
+		q += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return q, args, nil
+}
+
+// logSQLErrf wraps an error with a formatted string (used for debugging)
+func logSQLErrf(err error, format string, args ...interface{}) {
+	log.Printf("sql: %s: %s\n", fmt.Sprintf(format, args...), err)
+}
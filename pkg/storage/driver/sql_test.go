@@ -0,0 +1,331 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// newTestFixtureSQL returns an SQL driver on a mock database whose schema is
+// up to date, along with the mock to set further expectations on.
+func newTestFixtureSQL(t *testing.T) (*SQL, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create the mock database: %s", err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS releases_schema`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM releases_schema`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(len(sqlMigrations)))
+
+	s, err := NewSQL(db)
+	if err != nil {
+		t.Fatalf("Failed to create the SQL driver: %s", err)
+	}
+	return s, mock
+}
+
+// encodedRelease returns the body of rls as the SQL driver stores it.
+func encodedRelease(t *testing.T, rls *rspb.Release) string {
+	body, err := encodeRelease(rls)
+	if err != nil {
+		t.Fatalf("Failed to encode release: %s", err)
+	}
+	return body
+}
+
+func TestSQLName(t *testing.T) {
+	s := &SQL{}
+	if s.Name() != SQLDriverName {
+		t.Errorf("Expected name to be %q, got %q", SQLDriverName, s.Name())
+	}
+}
+
+func TestSQLLabelQuery(t *testing.T) {
+	var tests = []struct {
+		desc   string
+		labels map[string]string
+		query  string
+		args   []interface{}
+		err    bool
+	}{
+		{
+			"no labels",
+			map[string]string{},
+			"SELECT body FROM releases",
+			[]interface{}{},
+			false,
+		},
+		{
+			"name and owner",
+			map[string]string{"NAME": "smug-pigeon", "OWNER": "TILLER"},
+			"SELECT body FROM releases WHERE name = $1 AND owner = $2",
+			[]interface{}{"smug-pigeon", "TILLER"},
+			false,
+		},
+		{
+			"status and version",
+			map[string]string{"STATUS": "DEPLOYED", "VERSION": "3"},
+			"SELECT body FROM releases WHERE status = $1 AND version = $2",
+			[]interface{}{"DEPLOYED", 3},
+			false,
+		},
+		{
+			"unknown label",
+			map[string]string{"FOO": "bar"},
+			"",
+			nil,
+			true,
+		},
+		{
+			"invalid version",
+			map[string]string{"VERSION": "three"},
+			"",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		query, args, err := sqlLabelQuery(tt.labels)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: expected error %t, got %v", tt.desc, tt.err, err)
+			continue
+		}
+		if query != tt.query {
+			t.Errorf("%s: expected query %q, got %q", tt.desc, tt.query, query)
+		}
+		if !tt.err && !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: expected args %v, got %v", tt.desc, tt.args, args)
+		}
+	}
+}
+
+func TestSQLMigrate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create the mock database: %s", err)
+	}
+	defer db.Close()
+
+	// The first migration was applied already, so only the second one runs.
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS releases_schema`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM releases_schema`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX releases_name_idx`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO releases_schema (version) VALUES ($1)`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if _, err := NewSQL(db); err != nil {
+		t.Fatalf("Failed to migrate: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLMigrateFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create the mock database: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS releases_schema`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM releases_schema`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE releases (`)).WillReturnError(errors.New("permission denied"))
+	mock.ExpectRollback()
+
+	if _, err := NewSQL(db); err == nil {
+		t.Fatal("Expected the failed migration to be reported")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLGet(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, mock := newTestFixtureSQL(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT body FROM releases WHERE key = $1`)).
+		WithArgs(key).
+		WillReturnRows(sqlmock.NewRows([]string{"body"}).AddRow(encodedRelease(t, rel)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT body FROM releases WHERE key = $1`)).
+		WithArgs("smug-pigeon.v2").
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected release %v, got %v", rel, got)
+	}
+	if _, err := s.Get("smug-pigeon.v2"); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLCreate(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, mock := newTestFixtureSQL(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM releases WHERE key = $1`)).
+		WithArgs(key).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO releases (key, body, name, version, status, owner, created_at)`)).
+		WithArgs(key, encodedRelease(t, rel), rel.Name, rel.Version, "DEPLOYED", "TILLER", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Creating the release again fails, as it exists.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM releases WHERE key = $1`)).
+		WithArgs(key).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	if err := s.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if err := s.Create(key, rel); err != ErrReleaseExists {
+		t.Errorf("Expected ErrReleaseExists, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLUpdate(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_SUPERSEDED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, mock := newTestFixtureSQL(t)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE releases SET body = $1, status = $2, modified_at = $3 WHERE key = $4`)).
+		WithArgs(encodedRelease(t, rel), "SUPERSEDED", sqlmock.AnyArg(), key).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE releases SET body = $1, status = $2, modified_at = $3 WHERE key = $4`)).
+		WithArgs(encodedRelease(t, rel), "SUPERSEDED", sqlmock.AnyArg(), key).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := s.Update(key, rel); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	if err := s.Update(key, rel); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound for a missing release, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLDelete(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DELETED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, mock := newTestFixtureSQL(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT body FROM releases WHERE key = $1`)).
+		WithArgs(key).
+		WillReturnRows(sqlmock.NewRows([]string{"body"}).AddRow(encodedRelease(t, rel)))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM releases WHERE key = $1`)).
+		WithArgs(key).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	got, err := s.Delete(key)
+	if err != nil {
+		t.Fatalf("Failed to delete release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected the deleted release %v, got %v", rel, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLList(t *testing.T) {
+	deployed := releaseStub("smug-pigeon", 2, "default", rspb.Status_DEPLOYED)
+	superseded := releaseStub("smug-pigeon", 1, "default", rspb.Status_SUPERSEDED)
+
+	s, mock := newTestFixtureSQL(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT body FROM releases WHERE owner = $1`)).
+		WithArgs("TILLER").
+		WillReturnRows(sqlmock.NewRows([]string{"body"}).
+			AddRow(encodedRelease(t, deployed)).
+			AddRow("not a release").
+			AddRow(encodedRelease(t, superseded)))
+
+	rels, err := s.List(func(rls *rspb.Release) bool {
+		return rls.Info.Status.Code == rspb.Status_DEPLOYED
+	})
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(rels) != 1 || !reflect.DeepEqual(rels[0], deployed) {
+		t.Errorf("Expected only the deployed release, got %v", rels)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSQLQuery(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+
+	s, mock := newTestFixtureSQL(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT body FROM releases WHERE name = $1 AND status = $2`)).
+		WithArgs("smug-pigeon", "DEPLOYED").
+		WillReturnRows(sqlmock.NewRows([]string{"body"}).AddRow(encodedRelease(t, rel)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT body FROM releases WHERE name = $1`)).
+		WithArgs("angry-bird").
+		WillReturnRows(sqlmock.NewRows([]string{"body"}))
+
+	rels, err := s.Query(map[string]string{"NAME": "smug-pigeon", "STATUS": "DEPLOYED"})
+	if err != nil {
+		t.Fatalf("Failed to query releases: %s", err)
+	}
+	if len(rels) != 1 || !reflect.DeepEqual(rels[0], rel) {
+		t.Errorf("Expected the deployed release, got %v", rels)
+	}
+	if _, err := s.Query(map[string]string{"NAME": "angry-bird"}); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -28,6 +28,11 @@ import (
 // Storage represents a storage engine for a Release.
 type Storage struct {
 	driver.Driver
+
+	// MaxHistory specifies the maximum number of revisions that are kept per
+	// release, including the most recent one. Values of 0 or less mean that
+	// no limit is imposed.
+	MaxHistory int
 }
 
 // Get retrieves the release from storage. An error is returned
@@ -41,9 +46,65 @@ func (s *Storage) Get(name string, version int32) (*rspb.Release, error) {
 // Create creates a new storage entry holding the release. An
 // error is returned if the storage driver failed to store the
 // release, or a release with identical an key already exists.
+//
+// If MaxHistory is set, the oldest revisions of the release are removed
+// once the new one is stored, so that at most MaxHistory revisions remain.
+// The new revision, the revision it replaces and the latest deployed revision
+// are always kept, even if that exceeds MaxHistory.
 func (s *Storage) Create(rls *rspb.Release) error {
 	log.Printf("Create release %q (v%d) in storage\n", rls.Name, rls.Version)
-	return s.Driver.Create(makeKey(rls.Name, rls.Version), rls)
+	if err := s.Driver.Create(makeKey(rls.Name, rls.Version), rls); err != nil {
+		return err
+	}
+	if s.MaxHistory > 0 {
+		// Pruning failures must not fail the release that was recorded.
+		if err := s.removeLeastRecent(rls.Name, s.MaxHistory); err != nil {
+			log.Printf("warning: failed to prune history of %q: %s", rls.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeLeastRecent removes the oldest revisions of the named release until at
+// most max revisions are left. The two most recent revisions and the latest
+// deployed revision are never removed.
+func (s *Storage) removeLeastRecent(name string, max int) error {
+	h, err := s.History(name)
+	if err != nil {
+		return err
+	}
+	if len(h) <= max {
+		return nil
+	}
+
+	relutil.SortByRevision(h)
+	// len(h) > max >= 1, so there are at least two revisions.
+	keep := map[int32]bool{h[len(h)-1].Version: true, h[len(h)-2].Version: true}
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Info != nil && h[i].Info.Status != nil && h[i].Info.Status.Code == rspb.Status_DEPLOYED {
+			keep[h[i].Version] = true
+			break
+		}
+	}
+
+	left, pruned := len(h), 0
+	for _, rls := range h {
+		if left <= max {
+			break
+		}
+		if keep[rls.Version] {
+			continue
+		}
+		if _, err := s.Delete(rls.Name, rls.Version); err != nil {
+			return err
+		}
+		left--
+		pruned++
+	}
+	if pruned > 0 {
+		log.Printf("Pruned %d revision(s) of %q", pruned, name)
+	}
+	return nil
 }
 
 // Update update the release in storage. An error is returned if the
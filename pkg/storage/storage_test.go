@@ -217,6 +217,92 @@ func TestStorageHistory(t *testing.T) {
 	}
 }
 
+func TestStorageMaxHistory(t *testing.T) {
+	storage := Init(driver.NewMemory())
+	storage.MaxHistory = 2
+
+	const name = "angry-bird"
+
+	// create the release records in the storage
+	for v := int32(1); v <= 4; v++ {
+		rls := ReleaseTestData{Name: name, Version: v, Status: rspb.Status_SUPERSEDED}.ToRelease()
+		assertErrNil(t.Fatal, storage.Create(rls), fmt.Sprintf("Storing release 'angry-bird' (v%d)", v))
+	}
+
+	h, err := storage.History(name)
+	if err != nil {
+		t.Fatalf("Failed to query for release history (%q): %s\n", name, err)
+	}
+	if len(h) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d\n", len(h))
+	}
+	for _, v := range []int32{3, 4} {
+		if _, err := storage.Get(name, v); err != nil {
+			t.Errorf("Expected revision %d to be kept: %s", v, err)
+		}
+	}
+}
+
+func TestStorageMaxHistoryOne(t *testing.T) {
+	storage := Init(driver.NewMemory())
+	storage.MaxHistory = 1
+
+	const name = "angry-bird"
+
+	// v1 is deployed; v2 replaces it in an upgrade that is still underway.
+	v1 := ReleaseTestData{Name: name, Version: 1, Status: rspb.Status_DEPLOYED}.ToRelease()
+	assertErrNil(t.Fatal, storage.Create(v1), "Storing release 'angry-bird' (v1)")
+	v2 := ReleaseTestData{Name: name, Version: 2, Status: rspb.Status_UNKNOWN}.ToRelease()
+	assertErrNil(t.Fatal, storage.Create(v2), "Storing release 'angry-bird' (v2)")
+
+	// The upgrade records the replaced revision afterwards.
+	v1.Info.Status.Code = rspb.Status_SUPERSEDED
+	assertErrNil(t.Fatal, storage.Update(v1), "Updating release 'angry-bird' (v1)")
+	v2.Info.Status.Code = rspb.Status_DEPLOYED
+	assertErrNil(t.Fatal, storage.Update(v2), "Updating release 'angry-bird' (v2)")
+
+	// The next revision prunes v1.
+	v3 := ReleaseTestData{Name: name, Version: 3, Status: rspb.Status_UNKNOWN}.ToRelease()
+	assertErrNil(t.Fatal, storage.Create(v3), "Storing release 'angry-bird' (v3)")
+	if _, err := storage.Get(name, 1); err == nil {
+		t.Error("Expected revision 1 to be pruned")
+	}
+	for _, v := range []int32{2, 3} {
+		if _, err := storage.Get(name, v); err != nil {
+			t.Errorf("Expected revision %d to be kept: %s", v, err)
+		}
+	}
+}
+
+func TestStorageMaxHistoryKeepsDeployed(t *testing.T) {
+	storage := Init(driver.NewMemory())
+	storage.MaxHistory = 2
+
+	const name = "angry-bird"
+
+	rls := ReleaseTestData{Name: name, Version: 1, Status: rspb.Status_SUPERSEDED}.ToRelease()
+	assertErrNil(t.Fatal, storage.Create(rls), "Storing release 'angry-bird' (v1)")
+	rls = ReleaseTestData{Name: name, Version: 2, Status: rspb.Status_DEPLOYED}.ToRelease()
+	assertErrNil(t.Fatal, storage.Create(rls), "Storing release 'angry-bird' (v2)")
+	for v := int32(3); v <= 6; v++ {
+		rls := ReleaseTestData{Name: name, Version: v, Status: rspb.Status_FAILED}.ToRelease()
+		assertErrNil(t.Fatal, storage.Create(rls), fmt.Sprintf("Storing release 'angry-bird' (v%d)", v))
+	}
+
+	h, err := storage.History(name)
+	if err != nil {
+		t.Fatalf("Failed to query for release history (%q): %s\n", name, err)
+	}
+	if len(h) != 3 {
+		t.Errorf("Expected 3 revisions, got %d\n", len(h))
+	}
+	for _, v := range []int32{2, 5, 6} {
+		if _, err := storage.Get(name, v); err != nil {
+			t.Errorf("Expected revision %d to be kept: %s", v, err)
+		}
+	}
+}
+
 func TestStorageLast(t *testing.T) {
 	storage := Init(driver.NewMemory())
 
@@ -29,6 +29,13 @@ import (
 // ErrNotList indicates that a non-list was treated as a list.
 var ErrNotList = errors.New("not a list")
 
+// MaxIndex is the largest list index that can be set. Lists are filled up to
+// the index, so larger indexes could exhaust the memory.
+const MaxIndex = 65536
+
+// RunesValueReader converts the runes of a value to the value that is set.
+type RunesValueReader func([]rune) (interface{}, error)
+
 // ToYAML takes a string of arguments and converts to a YAML document.
 func ToYAML(s string) (string, error) {
 	m, err := Parse(s)
@@ -45,7 +52,18 @@ func ToYAML(s string) (string, error) {
 func Parse(s string) (map[string]interface{}, error) {
 	vals := map[string]interface{}{}
 	scanner := bytes.NewBufferString(s)
-	t := newParser(scanner, vals)
+	t := newParser(scanner, vals, false)
+	err := t.parse()
+	return vals, err
+}
+
+// ParseString parses a set line and forces a string value.
+//
+// A set line is of the form name1=value1,name2=value2
+func ParseString(s string) (map[string]interface{}, error) {
+	vals := map[string]interface{}{}
+	scanner := bytes.NewBufferString(s)
+	t := newParser(scanner, vals, true)
 	err := t.parse()
 	return vals, err
 }
@@ -56,19 +74,52 @@ func Parse(s string) (map[string]interface{}, error) {
 // dest version.
 func ParseInto(s string, dest map[string]interface{}) error {
 	scanner := bytes.NewBufferString(s)
-	t := newParser(scanner, dest)
+	t := newParser(scanner, dest, false)
+	return t.parse()
+}
+
+// ParseIntoString parses a strvals line and merges the result into dest,
+// keeping all values as strings.
+//
+// This method always returns a string as the value.
+func ParseIntoString(s string, dest map[string]interface{}) error {
+	scanner := bytes.NewBufferString(s)
+	t := newParser(scanner, dest, true)
+	return t.parse()
+}
+
+// ParseIntoFile parses a strvals line and merges the result into dest,
+// setting each value to what reader returns for it.
+//
+// This is used to set values from the contents of files, where reader reads
+// the file that the value names.
+func ParseIntoFile(s string, dest map[string]interface{}, reader RunesValueReader) error {
+	scanner := bytes.NewBufferString(s)
+	t := newFileParser(scanner, dest, reader)
 	return t.parse()
 }
 
 // parser is a simple parser that takes a strvals line and parses it into a
 // map representation.
+//
+// Values are converted by reader.
 type parser struct {
-	sc   *bytes.Buffer
-	data map[string]interface{}
+	sc     *bytes.Buffer
+	data   map[string]interface{}
+	reader RunesValueReader
+}
+
+// newParser returns a parser that converts values to booleans and integers
+// where possible, unless st is true, in which case they are kept as strings.
+func newParser(sc *bytes.Buffer, data map[string]interface{}, st bool) *parser {
+	reader := func(rs []rune) (interface{}, error) {
+		return typedVal(rs, st), nil
+	}
+	return &parser{sc: sc, data: data, reader: reader}
 }
 
-func newParser(sc *bytes.Buffer, data map[string]interface{}) *parser {
-	return &parser{sc: sc, data: data}
+func newFileParser(sc *bytes.Buffer, data map[string]interface{}, reader RunesValueReader) *parser {
+	return &parser{sc: sc, data: data, reader: reader}
 }
 
 func (t *parser) parse() error {
@@ -93,7 +144,7 @@ func runeSet(r []rune) map[rune]bool {
 }
 
 func (t *parser) key(data map[string]interface{}) error {
-	stop := runeSet([]rune{'=', ',', '.'})
+	stop := runeSet([]rune{'=', '[', ',', '.'})
 	for {
 		switch k, last, err := runesUntil(t.sc, stop); {
 		case err != nil:
@@ -103,25 +154,25 @@ func (t *parser) key(data map[string]interface{}) error {
 			return fmt.Errorf("key %q has no value", string(k))
 			//set(data, string(k), "")
 			//return err
+		case last == '[':
+			// We are in a list index context, so find or create the target
+			// list and set the item at the index.
+			list := []interface{}{}
+			if v, ok := data[string(k)]; ok && v != nil {
+				if list, ok = v.([]interface{}); !ok {
+					return fmt.Errorf("key %q is not a list", string(k))
+				}
+			}
+			list, e := t.listItem(list)
+			set(data, string(k), list)
+			return e
 		case last == '=':
 			//End of key. Consume =, Get value.
-			// FIXME: Get value list first
-			vl, e := t.valList()
-			switch e {
-			case nil:
-				set(data, string(k), vl)
-				return nil
-			case io.EOF:
-				set(data, string(k), "")
-				return e
-			case ErrNotList:
-				v, e := t.val()
-				set(data, string(k), typedVal(v))
-				return e
-			default:
-				return e
+			v, e := t.value()
+			if e == nil || e == io.EOF {
+				set(data, string(k), v)
 			}
-
+			return e
 		case last == ',':
 			// No value given. Set the value to empty string. Return error.
 			set(data, string(k), "")
@@ -129,8 +180,10 @@ func (t *parser) key(data map[string]interface{}) error {
 		case last == '.':
 			// First, create or find the target map.
 			inner := map[string]interface{}{}
-			if _, ok := data[string(k)]; ok {
-				inner = data[string(k)].(map[string]interface{})
+			if v, ok := data[string(k)]; ok && v != nil {
+				if inner, ok = v.(map[string]interface{}); !ok {
+					return fmt.Errorf("key %q is not a map", string(k))
+				}
 			}
 
 			// Recurse
@@ -144,6 +197,112 @@ func (t *parser) key(data map[string]interface{}) error {
 	}
 }
 
+// value reads the value after a '='. An io.EOF error means that the value
+// ends the line.
+func (t *parser) value() (interface{}, error) {
+	vl, e := t.valList()
+	switch e {
+	case nil:
+		return vl, nil
+	case io.EOF:
+		return "", e
+	case ErrNotList:
+		rs, e := t.val()
+		if e != nil && e != io.EOF {
+			return nil, e
+		}
+		v, err := t.reader(rs)
+		if err != nil {
+			return nil, err
+		}
+		return v, e
+	default:
+		return nil, e
+	}
+}
+
+// keyIndex reads the index of a list item up to the closing ']'. The index
+// '+' appends to a list of the given length.
+func (t *parser) keyIndex(length int) (int, error) {
+	stop := runeSet([]rune{']'})
+	v, _, err := runesUntil(t.sc, stop)
+	if err != nil {
+		return 0, fmt.Errorf("index %q must terminate with ']'", string(v))
+	}
+	if string(v) == "+" {
+		return length, nil
+	}
+	i, err := strconv.Atoi(string(v))
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid index %q", string(v))
+	}
+	if i > MaxIndex {
+		return 0, fmt.Errorf("index %d is larger than the maximum index %d", i, MaxIndex)
+	}
+	return i, nil
+}
+
+// listItem sets the item of list at the index that follows a '[' to what
+// comes after the closing ']': a value, an item of a nested list, or a key
+// of a nested map.
+func (t *parser) listItem(list []interface{}) ([]interface{}, error) {
+	i, err := t.keyIndex(len(list))
+	if err != nil {
+		return list, err
+	}
+
+	stop := runeSet([]rune{'[', '.', '='})
+	switch k, last, err := runesUntil(t.sc, stop); {
+	case len(k) > 0:
+		return list, fmt.Errorf("unexpected data at end of list index: %q", string(k))
+	case err != nil:
+		return list, fmt.Errorf("list index %d has no value", i)
+	case last == '=':
+		v, e := t.value()
+		if e == nil || e == io.EOF {
+			list = setIndex(list, i, v)
+		}
+		return list, e
+	case last == '[':
+		// A nested list.
+		inner := []interface{}{}
+		if i < len(list) && list[i] != nil {
+			var ok bool
+			if inner, ok = list[i].([]interface{}); !ok {
+				return list, fmt.Errorf("list index %d is not a list", i)
+			}
+		}
+		inner, e := t.listItem(inner)
+		return setIndex(list, i, inner), e
+	default:
+		// A nested map.
+		inner := map[string]interface{}{}
+		if i < len(list) && list[i] != nil {
+			var ok bool
+			if inner, ok = list[i].(map[string]interface{}); !ok {
+				return list, fmt.Errorf("list index %d is not a map", i)
+			}
+		}
+		e := t.key(inner)
+		if len(inner) == 0 {
+			return list, fmt.Errorf("list index %d has no value", i)
+		}
+		return setIndex(list, i, inner), e
+	}
+}
+
+// setIndex sets the item of list at index to val, growing the list with nil
+// items as needed.
+func setIndex(list []interface{}, index int, val interface{}) []interface{} {
+	if len(list) <= index {
+		newlist := make([]interface{}, index+1)
+		copy(newlist, list)
+		list = newlist
+	}
+	list[index] = val
+	return list
+}
+
 func set(data map[string]interface{}, key string, val interface{}) {
 	// If key is empty, don't set it.
 	if len(key) == 0 {
@@ -183,10 +342,18 @@ func (t *parser) valList() ([]interface{}, error) {
 			if r, _, e := t.sc.ReadRune(); e == nil && r != ',' {
 				t.sc.UnreadRune()
 			}
-			list = append(list, typedVal(v))
+			val, err := t.reader(v)
+			if err != nil {
+				return list, err
+			}
+			list = append(list, val)
 			return list, nil
 		case last == ',':
-			list = append(list, typedVal(v))
+			val, err := t.reader(v)
+			if err != nil {
+				return list, err
+			}
+			list = append(list, val)
 		}
 	}
 }
@@ -216,8 +383,11 @@ func inMap(k rune, m map[rune]bool) bool {
 	return ok
 }
 
-func typedVal(v []rune) interface{} {
+func typedVal(v []rune, st bool) interface{} {
 	val := string(v)
+	if st {
+		return val
+	}
 	if strings.EqualFold(val, "true") {
 		return true
 	}
@@ -226,6 +396,10 @@ func typedVal(v []rune) interface{} {
 		return false
 	}
 
+	if strings.EqualFold(val, "null") {
+		return nil
+	}
+
 	if iv, err := strconv.ParseInt(val, 10, 64); err == nil {
 		return iv
 	}
@@ -16,6 +16,8 @@ limitations under the License.
 package strvals
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -66,6 +68,10 @@ func TestParseSet(t *testing.T) {
 			str: "name1,name2",
 			err: true,
 		},
+		{
+			str:    "name1=null,name2=NULL",
+			expect: map[string]interface{}{"name1": nil, "name2": nil},
+		},
 		{
 			"name1=one\\,two,name2=three\\,four",
 			map[string]interface{}{"name1": "one,two", "name2": "three,four"},
@@ -184,6 +190,293 @@ func TestParseSet(t *testing.T) {
 	}
 }
 
+func TestParseSetIndex(t *testing.T) {
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    bool
+	}{
+		{
+			str:    "list[0]=foo",
+			expect: map[string]interface{}{"list": []string{"foo"}},
+		},
+		{
+			str:    "list[0]=foo,list[1]=bar",
+			expect: map[string]interface{}{"list": []string{"foo", "bar"}},
+		},
+		{
+			str:    "list[0]=foo,list[1]=bar,list[0]=baz",
+			expect: map[string]interface{}{"list": []string{"baz", "bar"}},
+		},
+		{
+			str:    "list[0]=foo,list[3]=bar",
+			expect: map[string]interface{}{"list": []interface{}{"foo", nil, nil, "bar"}},
+		},
+		{
+			str:    "list[0]={a,b}",
+			expect: map[string]interface{}{"list": []interface{}{[]string{"a", "b"}}},
+		},
+		{
+			str: "list[0].foo=bar,list[0].hello=world,list[1].foo=baz",
+			expect: map[string]interface{}{"list": []interface{}{
+				map[string]interface{}{"foo": "bar", "hello": "world"},
+				map[string]interface{}{"foo": "baz"},
+			}},
+		},
+		{
+			str: "list[0][0]=a,list[0][1]=b,list[1][0]=c",
+			expect: map[string]interface{}{"list": []interface{}{
+				[]string{"a", "b"},
+				[]string{"c"},
+			}},
+		},
+		{
+			str: "outer.list[0].inner[1].name=deep",
+			expect: map[string]interface{}{"outer": map[string]interface{}{"list": []interface{}{
+				map[string]interface{}{"inner": []interface{}{
+					nil,
+					map[string]interface{}{"name": "deep"},
+				}},
+			}}},
+		},
+		{
+			str: "servers[+].port=80,servers[+].port=443,servers[1].host=example.com",
+			expect: map[string]interface{}{"servers": []interface{}{
+				map[string]interface{}{"port": 80},
+				map[string]interface{}{"port": 443, "host": "example.com"},
+			}},
+		},
+		{
+			str:    "list[0]=a,list[+]=b,list[+]=c",
+			expect: map[string]interface{}{"list": []string{"a", "b", "c"}},
+		},
+		{
+			str:    "list[0][+]=a,list[0][+]=b",
+			expect: map[string]interface{}{"list": []interface{}{[]string{"a", "b"}}},
+		},
+		{
+			str:    "list[0]=,name=x",
+			expect: map[string]interface{}{"list": []string{""}, "name": "x"},
+		},
+		{
+			str:    "name1=one\\,two\\[0\\],key\\[0\\]=v,name2=[a]",
+			expect: map[string]interface{}{"name1": "one,two[0]", "key[0]": "v", "name2": "[a]"},
+		},
+		{
+			str: "list[x]=foo",
+			err: true,
+		},
+		{
+			str: "list[-1]=foo",
+			err: true,
+		},
+		{
+			str: "list[999999999]=foo",
+			err: true,
+		},
+		{
+			str: "list[0][65537]=foo",
+			err: true,
+		},
+		{
+			str: "list[0=foo",
+			err: true,
+		},
+		{
+			str: "list[0]foo=bar",
+			err: true,
+		},
+		{
+			str: "list[0]",
+			err: true,
+		},
+		{
+			str: "name=foo,name[0]=bar",
+			err: true,
+		},
+		{
+			str: "list[0]=foo,list.name=bar",
+			err: true,
+		},
+		{
+			str: "list[0]=foo,list[0].name=bar",
+			err: true,
+		},
+		{
+			str: "list[0]=foo,list[0][0]=bar",
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.str)
+		if err != nil {
+			if tt.err {
+				continue
+			}
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+		if tt.err {
+			t.Errorf("%s: Expected error. Got nil", tt.str)
+			continue
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+
+		// The YAML reads back to the same values.
+		y3, err := ToYAML(tt.str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(y3), &back); err != nil {
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+		if y4, _ := yaml.Marshal(back); string(y4) != string(y1) {
+			t.Errorf("%s: Expected round trip:\n%s\nGot:\n%s", tt.str, y1, y4)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    bool
+	}{
+		{
+			str:    "long_int_string=1234567890",
+			expect: map[string]interface{}{"long_int_string": "1234567890"},
+		},
+		{
+			str:    "boolean=true,mode=0777,none=null",
+			expect: map[string]interface{}{"boolean": "true", "mode": "0777", "none": "null"},
+		},
+		{
+			str:    "outer.inner=false",
+			expect: map[string]interface{}{"outer": map[string]interface{}{"inner": "false"}},
+		},
+		{
+			str:    "list={1,true,three}",
+			expect: map[string]interface{}{"list": []string{"1", "true", "three"}},
+		},
+		{
+			str: "name1,name2",
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseString(tt.str)
+		if err != nil {
+			if tt.err {
+				continue
+			}
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+		if tt.err {
+			t.Errorf("%s: Expected error. Got nil", tt.str)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+	}
+}
+
+func TestParseIntoString(t *testing.T) {
+	got := map[string]interface{}{
+		"outer": map[string]interface{}{
+			"inner1": "overwrite",
+			"inner2": 2,
+		},
+	}
+	input := "outer.inner1=1,outer.inner3=true"
+	expect := map[string]interface{}{
+		"outer": map[string]interface{}{
+			"inner1": "1",
+			"inner2": 2,
+			"inner3": "true",
+		},
+	}
+
+	if err := ParseIntoString(input, got); err != nil {
+		t.Fatal(err)
+	}
+
+	y1, err := yaml.Marshal(expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y2, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatalf("Error serializing parsed value: %s", err)
+	}
+
+	if string(y1) != string(y2) {
+		t.Errorf("%s: Expected:\n%s\nGot:\n%s", input, y1, y2)
+	}
+}
+
+func TestParseIntoFile(t *testing.T) {
+	got := map[string]interface{}{}
+	input := "name1=path1,outer.inner={path2,path3}"
+	expect := map[string]interface{}{
+		"name1": "value1",
+		"outer": map[string]interface{}{
+			"inner": []string{"value2", "value3"},
+		},
+	}
+	reader := func(rs []rune) (interface{}, error) {
+		return strings.Replace(string(rs), "path", "value", 1), nil
+	}
+
+	if err := ParseIntoFile(input, got, reader); err != nil {
+		t.Fatal(err)
+	}
+
+	y1, err := yaml.Marshal(expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y2, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatalf("Error serializing parsed value: %s", err)
+	}
+
+	if string(y1) != string(y2) {
+		t.Errorf("%s: Expected:\n%s\nGot:\n%s", input, y1, y2)
+	}
+
+	failing := func(rs []rune) (interface{}, error) {
+		return nil, fmt.Errorf("cannot read %s", string(rs))
+	}
+	for _, input := range []string{"name1=path1", "name1={path1,path2}"} {
+		if err := ParseIntoFile(input, map[string]interface{}{}, failing); err == nil || !strings.Contains(err.Error(), "cannot read path1") {
+			t.Errorf("%s: Expected the reader error, got %v", input, err)
+		}
+	}
+}
+
 func TestParseInto(t *testing.T) {
 	got := map[string]interface{}{
 		"outer": map[string]interface{}{
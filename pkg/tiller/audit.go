@@ -0,0 +1,154 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// AuditEntry is a record of a mutating operation performed by Tiller.
+type AuditEntry struct {
+	Time         time.Time `json:"time"`
+	User         string    `json:"user"`
+	Address      string    `json:"address,omitempty"`
+	Operation    string    `json:"operation"`
+	Release      string    `json:"release,omitempty"`
+	Chart        string    `json:"chart,omitempty"`
+	Version      int32     `json:"version,omitempty"`
+	ValuesDigest string    `json:"valuesDigest,omitempty"`
+	DryRun       bool      `json:"dryRun,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// auditor writes audit entries as JSON lines.
+type auditor struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// auditLog is the audit log of the server. Auditing is disabled when nil.
+var auditLog *auditor
+
+// EnableAuditLog makes Tiller record every mutating RPC to w, one JSON
+// encoded AuditEntry per line. It must be called before the server is started.
+func EnableAuditLog(w io.Writer) {
+	auditLog = &auditor{enc: json.NewEncoder(w)}
+}
+
+func (a *auditor) record(e *AuditEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.enc.Encode(e); err != nil {
+		log.Printf("warning: failed to write audit log entry: %s", err)
+	}
+}
+
+// auditRequest records the RPC named by fullMethod in the audit log if it
+// mutates releases.
+func auditRequest(ctx context.Context, fullMethod string, req, resp interface{}, err error) {
+	if auditLog == nil {
+		return
+	}
+	_, m := splitMethod(fullMethod)
+	e := newAuditEntry(m, req, resp)
+	if e == nil {
+		return
+	}
+	e.Time = time.Now().UTC()
+	e.User, e.Address = auditUser(ctx)
+	if err != nil {
+		e.Error = err.Error()
+	}
+	auditLog.record(e)
+}
+
+// newAuditEntry describes the request of a mutating RPC. It returns nil for
+// RPCs that do not change releases.
+func newAuditEntry(method string, req, resp interface{}) *AuditEntry {
+	e := &AuditEntry{Operation: method}
+	switch r := req.(type) {
+	case *services.InstallReleaseRequest:
+		e.Release = r.Name
+		if res, ok := resp.(*services.InstallReleaseResponse); ok && res != nil && res.Release != nil {
+			e.Release = res.Release.Name
+		}
+		e.Chart = chartName(r.Chart)
+		e.ValuesDigest = valuesDigest(r.Values)
+		e.DryRun = r.DryRun
+	case *services.UpdateReleaseRequest:
+		e.Release = r.Name
+		e.Chart = chartName(r.Chart)
+		e.ValuesDigest = valuesDigest(r.Values)
+		e.DryRun = r.DryRun
+	case *services.RollbackReleaseRequest:
+		e.Release = r.Name
+		e.Version = r.Version
+		e.DryRun = r.DryRun
+	case *services.UninstallReleaseRequest:
+		e.Release = r.Name
+	default:
+		return nil
+	}
+	return e
+}
+
+// auditUser returns the common name of the client certificate of the caller,
+// or "anonymous" if it did not present one, and the caller's address.
+func auditUser(ctx context.Context) (user, addr string) {
+	user = "anonymous"
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return user, ""
+	}
+	if p.Addr != nil {
+		addr = p.Addr.String()
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if certs := info.State.PeerCertificates; len(certs) > 0 && certs[0].Subject.CommonName != "" {
+			user = certs[0].Subject.CommonName
+		}
+	}
+	return user, addr
+}
+
+func chartName(ch *chart.Chart) string {
+	if ch == nil || ch.Metadata == nil {
+		return ""
+	}
+	return ch.Metadata.Name + "-" + ch.Metadata.Version
+}
+
+func valuesDigest(vals *chart.Config) string {
+	if vals == nil || vals.Raw == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(vals.Raw))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
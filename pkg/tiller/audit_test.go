@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestAuditRequest(t *testing.T) {
+	var buf bytes.Buffer
+	EnableAuditLog(&buf)
+	defer func() { auditLog = nil }()
+
+	ch := &chart.Chart{Metadata: &chart.Metadata{Name: "hello", Version: "0.1.0"}}
+	ctx := context.Background()
+
+	auditRequest(ctx, "/hapi.services.tiller.ReleaseService/InstallRelease",
+		&services.InstallReleaseRequest{Chart: ch, Values: &chart.Config{Raw: "name: value\n"}},
+		&services.InstallReleaseResponse{Release: &release.Release{Name: "angry-panda"}},
+		nil)
+	auditRequest(ctx, "/hapi.services.tiller.ReleaseService/RollbackRelease",
+		&services.RollbackReleaseRequest{Name: "angry-panda", Version: 1},
+		nil,
+		errors.New("rollback failed"))
+	auditRequest(ctx, "/hapi.services.tiller.ReleaseService/ListReleases",
+		&services.ListReleasesRequest{},
+		nil,
+		nil)
+
+	dec := json.NewDecoder(&buf)
+	var entries []AuditEntry
+	for dec.More() {
+		var e AuditEntry
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("Failed to decode audit entry: %s", err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 audit entries, got %d", len(entries))
+	}
+
+	install := entries[0]
+	if install.Operation != "InstallRelease" || install.Release != "angry-panda" || install.Chart != "hello-0.1.0" {
+		t.Errorf("Unexpected install entry: %+v", install)
+	}
+	if install.User != "anonymous" {
+		t.Errorf("Expected anonymous user, got %q", install.User)
+	}
+	if expect := "sha256:23dc9ee71eed1c3cca0ab3d080330cf24469fa0d57a2f1156ee3ceccb3ae25c7"; install.ValuesDigest != expect {
+		t.Errorf("Expected values digest %q, got %q", expect, install.ValuesDigest)
+	}
+
+	rollback := entries[1]
+	if rollback.Operation != "RollbackRelease" || rollback.Version != 1 || rollback.Error != "rollback failed" {
+		t.Errorf("Unexpected rollback entry: %+v", rollback)
+	}
+}
@@ -112,6 +112,12 @@ type KubeClient interface {
 	// by "\n---\n").
 	Get(namespace string, reader io.Reader) (string, error)
 
+	// GetResources gets one or more resources as they exist in the cluster.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	GetResources(namespace string, reader io.Reader) ([]*kube.Resource, error)
+
 	// Delete destroys one or more resources.
 	//
 	// namespace must contain a valid existing namespace.
@@ -134,7 +140,20 @@ type KubeClient interface {
 	//
 	// reader must contain a YAML stream (one or more YAML documents separated
 	// by "\n---\n").
-	Update(namespace string, originalReader, modifiedReader io.Reader, recreate bool, timeout int64, shouldWait bool) error
+	//
+	// If force is set, resources that cannot be patched are deleted and
+	// recreated.
+	//
+	// Update returns the resources it created, as kind/namespace/name, also
+	// when it fails.
+	Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error)
+
+	// WaitForConditions waits until every resource whose kind is a key of
+	// conditions reports the mapped status condition type as "True".
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	WaitForConditions(namespace string, reader io.Reader, timeout int64, conditions map[string]string) error
 
 	Build(namespace string, reader io.Reader) (kube.Result, error)
 	BuildUnstructured(namespace string, reader io.Reader) (kube.Result, error)
@@ -142,6 +161,12 @@ type KubeClient interface {
 	// WaitAndGetCompletedPodPhase waits up to a timeout until a pod enters a completed phase
 	// and returns said phase (PodSucceeded or PodFailed qualify)
 	WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (api.PodPhase, error)
+
+	// GetPodLogs returns the logs of the containers of the pods in reader.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	GetPodLogs(namespace string, reader io.Reader) (string, error)
 }
 
 // PrintingKubeClient implements KubeClient, but simply prints the reader to
@@ -162,6 +187,12 @@ func (p *PrintingKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", err
 }
 
+// GetResources implements KubeClient GetResources.
+func (p *PrintingKubeClient) GetResources(ns string, r io.Reader) ([]*kube.Resource, error) {
+	_, err := io.Copy(p.Out, r)
+	return []*kube.Resource{}, err
+}
+
 // Delete implements KubeClient delete.
 //
 // It only prints out the content to be deleted.
@@ -177,8 +208,14 @@ func (p *PrintingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int
 }
 
 // Update implements KubeClient Update.
-func (p *PrintingKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, recreate bool, timeout int64, shouldWait bool) error {
+func (p *PrintingKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
 	_, err := io.Copy(p.Out, modifiedReader)
+	return nil, err
+}
+
+// WaitForConditions implements KubeClient WaitForConditions.
+func (p *PrintingKubeClient) WaitForConditions(ns string, r io.Reader, timeout int64, conditions map[string]string) error {
+	_, err := io.Copy(p.Out, r)
 	return err
 }
 
@@ -198,6 +235,12 @@ func (p *PrintingKubeClient) WaitAndGetCompletedPodPhase(namespace string, reade
 	return api.PodUnknown, err
 }
 
+// GetPodLogs implements KubeClient GetPodLogs.
+func (p *PrintingKubeClient) GetPodLogs(namespace string, reader io.Reader) (string, error) {
+	_, err := io.Copy(p.Out, reader)
+	return "", err
+}
+
 // Environment provides the context for executing a client request.
 //
 // All services in a context are concurrency safe.
@@ -45,15 +45,21 @@ func (k *mockKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWai
 func (k *mockKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
+func (k *mockKubeClient) GetResources(ns string, r io.Reader) ([]*kube.Resource, error) {
+	return []*kube.Resource{}, nil
+}
 func (k *mockKubeClient) Delete(ns string, r io.Reader) error {
 	return nil
 }
-func (k *mockKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, recreate bool, timeout int64, shouldWait bool) error {
-	return nil
+func (k *mockKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
+	return nil, nil
 }
 func (k *mockKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) WaitForConditions(ns string, r io.Reader, timeout int64, conditions map[string]string) error {
+	return nil
+}
 func (k *mockKubeClient) Build(ns string, reader io.Reader) (kube.Result, error) {
 	return []*resource.Info{}, nil
 }
@@ -64,6 +70,10 @@ func (k *mockKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io
 	return api.PodUnknown, nil
 }
 
+func (k *mockKubeClient) GetPodLogs(namespace string, reader io.Reader) (string, error) {
+	return "", nil
+}
+
 func (k *mockKubeClient) WaitAndGetCompletedPodStatus(namespace string, reader io.Reader, timeout time.Duration) (api.PodPhase, error) {
 	return "", nil
 }
@@ -45,9 +45,38 @@ func (hs *hookWeightSorter) Swap(i, j int) {
 	hs.hooks[i], hs.hooks[j] = hs.hooks[j], hs.hooks[i]
 }
 
+// Less orders hooks by weight. Hooks of the same weight are ordered by kind,
+// as in InstallOrder, and then by name.
 func (hs *hookWeightSorter) Less(i, j int) bool {
-	if hs.hooks[i].Weight == hs.hooks[j].Weight {
-		return hs.hooks[i].Name < hs.hooks[j].Name
+	a, b := hs.hooks[i], hs.hooks[j]
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	if a.Kind != b.Kind {
+		return kindLess(a.Kind, b.Kind)
+	}
+	return a.Name < b.Name
+}
+
+// kindLess orders kinds as in InstallOrder. Unknown kinds come last, in
+// alphabetical order.
+func kindLess(a, b string) bool {
+	first, aok := installOrderIndex[a]
+	second, bok := installOrderIndex[b]
+	switch {
+	case aok && bok:
+		return first < second
+	case aok != bok:
+		return aok
+	default:
+		return a < b
 	}
-	return hs.hooks[i].Weight < hs.hooks[j].Weight
 }
+
+var installOrderIndex = func() map[string]int {
+	o := make(map[string]int, len(InstallOrder))
+	for i, k := range InstallOrder {
+		o[k] = i
+	}
+	return o
+}()
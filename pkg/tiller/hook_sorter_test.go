@@ -71,3 +71,23 @@ func TestHookSorter(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expect, got)
 	}
 }
+
+func TestHookSorterKinds(t *testing.T) {
+	hooks := []*release.Hook{
+		{Name: "migrate", Kind: "Job", Weight: 1},
+		{Name: "settings", Kind: "ConfigMap", Weight: 1},
+		{Name: "migrate", Kind: "ConfigMap", Weight: 1},
+		{Name: "widget", Kind: "Widget", Weight: 1},
+		{Name: "gadget", Kind: "Gadget", Weight: 1},
+		{Name: "credentials", Kind: "Secret", Weight: 1},
+		{Name: "cleanup", Kind: "Job", Weight: 0},
+	}
+
+	res := sortByHookWeight(hooks)
+	expect := []string{"Job/cleanup", "Secret/credentials", "ConfigMap/migrate", "ConfigMap/settings", "Job/migrate", "Gadget/gadget", "Widget/widget"}
+	for i, r := range res {
+		if got := r.Kind + "/" + r.Name; got != expect[i] {
+			t.Errorf("Expected %q at %d, got %q", expect[i], i, got)
+		}
+	}
+}
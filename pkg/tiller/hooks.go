@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -42,6 +43,74 @@ var events = map[string]release.Hook_Event{
 	hooks.PostRollback:       release.Hook_POST_ROLLBACK,
 	hooks.ReleaseTestSuccess: release.Hook_RELEASE_TEST_SUCCESS,
 	hooks.ReleaseTestFailure: release.Hook_RELEASE_TEST_FAILURE,
+	hooks.CRDInstall:         release.Hook_CRD_INSTALL,
+}
+
+var deletePolicies = map[string]release.Hook_DeletePolicy{
+	hooks.HookSucceeded:      release.Hook_SUCCEEDED,
+	hooks.HookFailed:         release.Hook_FAILED,
+	hooks.BeforeHookCreation: release.Hook_BEFORE_HOOK_CREATION,
+}
+
+// crdHead is the part of a CustomResourceDefinition that declares the API
+// version of its custom resources.
+type crdHead struct {
+	Kind     string `json:"kind"`
+	Metadata *struct {
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Group    string `json:"group"`
+		Version  string `json:"version"`
+		Versions []struct {
+			Name string `json:"name"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// withCRDVersions returns apis extended by the API versions that the
+// CustomResourceDefinitions in files with a crd-install hook declare.
+func withCRDVersions(apis chartutil.VersionSet, files map[string]string) (chartutil.VersionSet, error) {
+	var versions []string
+	for n, c := range files {
+		if strings.HasPrefix(path.Base(n), "_") || !strings.Contains(c, "CustomResourceDefinition") {
+			continue
+		}
+		var crd crdHead
+		if err := yaml.Unmarshal([]byte(c), &crd); err != nil {
+			return apis, fmt.Errorf("YAML parse error on %s: %s", n, err)
+		}
+		if crd.Kind != "CustomResourceDefinition" || crd.Metadata == nil || crd.Spec.Group == "" {
+			continue
+		}
+		if !isCRDInstallHook(crd.Metadata.Annotations[hooks.HookAnno]) {
+			continue
+		}
+		if crd.Spec.Version != "" {
+			versions = append(versions, crd.Spec.Group+"/"+crd.Spec.Version)
+		}
+		for _, v := range crd.Spec.Versions {
+			versions = append(versions, crd.Spec.Group+"/"+v.Name)
+		}
+	}
+	if len(versions) == 0 {
+		return apis, nil
+	}
+
+	extended := chartutil.NewVersionSet(versions...)
+	for v := range apis {
+		extended[v] = struct{}{}
+	}
+	return extended, nil
+}
+
+func isCRDInstallHook(hookTypes string) bool {
+	for _, hookType := range strings.Split(hookTypes, ",") {
+		if strings.ToLower(strings.TrimSpace(hookType)) == hooks.CRDInstall {
+			return true
+		}
+	}
+	return false
 }
 
 // manifest represents a manifest file, which has a name and some content.
@@ -76,7 +145,17 @@ func sortManifests(files map[string]string, apis chartutil.VersionSet, sort Sort
 	hs := []*release.Hook{}
 	generic := []manifest{}
 
-	for n, c := range files {
+	// The custom resources of the chart are not available before its
+	// crd-install hooks ran.
+	apis, err := withCRDVersions(apis, files)
+	if err != nil {
+		return hs, generic, err
+	}
+
+	// Walk the templates in path order, so that manifests of the same Kind
+	// are installed in the same order every time.
+	for _, n := range sortedNames(files) {
+		c := files[n]
 		// Skip partials. We could return these as a separate map, but there doesn't
 		// seem to be any need for that at this time.
 		if strings.HasPrefix(path.Base(n), "_") {
@@ -140,7 +219,28 @@ func sortManifests(files map[string]string, apis chartutil.VersionSet, sort Sort
 			log.Printf("info: skipping unknown hook: %q", hookTypes)
 			continue
 		}
+
+		if policies, ok := sh.Metadata.Annotations[hooks.HookDeleteAnno]; ok {
+			for _, policy := range strings.Split(policies, ",") {
+				policy = strings.ToLower(strings.TrimSpace(policy))
+				if p, ok := deletePolicies[policy]; ok {
+					h.DeletePolicies = append(h.DeletePolicies, p)
+				} else {
+					log.Printf("info: skipping unknown hook delete policy: %q", policy)
+				}
+			}
+		}
 		hs = append(hs, h)
 	}
 	return hs, sortByKind(generic, sort), nil
 }
+
+// sortedNames returns the template paths of files in lexical order.
+func sortedNames(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package tiller
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -33,6 +34,7 @@ func TestSortManifests(t *testing.T) {
 		path     string
 		kind     string
 		hooks    []release.Hook_Event
+		policies []release.Hook_DeletePolicy
 		manifest string
 	}{
 		{
@@ -51,16 +53,18 @@ metadata:
 `,
 		},
 		{
-			name:  "second",
-			path:  "two",
-			kind:  "ReplicaSet",
-			hooks: []release.Hook_Event{release.Hook_POST_INSTALL},
+			name:     "second",
+			path:     "two",
+			kind:     "ReplicaSet",
+			hooks:    []release.Hook_Event{release.Hook_POST_INSTALL},
+			policies: []release.Hook_DeletePolicy{release.Hook_BEFORE_HOOK_CREATION, release.Hook_SUCCEEDED},
 			manifest: `kind: ReplicaSet
 apiVersion: v1beta1
 metadata:
   name: second
   annotations:
     "helm.sh/hook": post-install
+    "helm.sh/hook-delete-policy": before-hook-creation, Hook-Succeeded, no-such-policy
 `,
 		}, {
 			name:  "third",
@@ -153,6 +157,14 @@ metadata:
 						t.Errorf("Expected event %d, got %d", expect.hooks[i], out.Events[i])
 					}
 				}
+				if len(out.DeletePolicies) != len(expect.policies) {
+					t.Errorf("Expected delete policies %v, got %v", expect.policies, out.DeletePolicies)
+				}
+				for i := 0; i < len(out.DeletePolicies) && i < len(expect.policies); i++ {
+					if out.DeletePolicies[i] != expect.policies[i] {
+						t.Errorf("Expected delete policy %d, got %d", expect.policies[i], out.DeletePolicies[i])
+					}
+				}
 			}
 		}
 		if !found {
@@ -184,6 +196,79 @@ metadata:
 
 }
 
+var crdManifest = `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+  annotations:
+    "helm.sh/hook": crd-install
+spec:
+  group: stable.example.com
+  version: v1
+  names:
+    kind: CronTab
+    plural: crontabs
+  scope: Namespaced
+`
+
+var crManifest = `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: my-crontab
+`
+
+func TestSortManifestsCRDInstall(t *testing.T) {
+	apis := chartutil.NewVersionSet("v1", "apiextensions.k8s.io/v1beta1")
+	manifests := map[string]string{
+		"templates/crd.yaml":     crdManifest,
+		"templates/crontab.yaml": crManifest,
+	}
+
+	hs, generic, err := sortManifests(manifests, apis, InstallOrder)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(hs) != 1 || hs[0].Events[0] != release.Hook_CRD_INSTALL {
+		t.Errorf("Expected the CRD to be a crd-install hook, got %v", hs)
+	}
+	if len(generic) != 1 || generic[0].head.Kind != "CronTab" {
+		t.Errorf("Expected the custom resource to be a manifest, got %v", generic)
+	}
+	if apis.Has("stable.example.com/v1") {
+		t.Error("Expected the version set passed in to be left alone")
+	}
+
+	// Without the crd-install hook, the API version of the custom resource
+	// has to be available already.
+	manifests["templates/crd.yaml"] = strings.Replace(crdManifest, "crd-install", "pre-install", 1)
+	if _, _, err := sortManifests(manifests, apis, InstallOrder); err == nil {
+		t.Error("Expected an error about the unavailable API version")
+	}
+}
+
+func TestSortManifestsPathOrder(t *testing.T) {
+	manifests := map[string]string{}
+	var want []string
+	for _, n := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		name := "templates/" + n + ".yaml"
+		manifests[name] = "kind: ConfigMap\nmetadata:\n  name: " + n
+		want = append(want, name)
+	}
+
+	// Map iteration order varies, so sort a few times.
+	for i := 0; i < 10; i++ {
+		_, generic, err := sortManifests(manifests, chartutil.NewVersionSet("v1"), InstallOrder)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		for j, m := range generic {
+			if m.name != want[j] {
+				t.Fatalf("Expected %s at position %d, got %s", want[j], j, m.name)
+			}
+		}
+	}
+}
+
 func TestVersionSet(t *testing.T) {
 	vs := chartutil.NewVersionSet("v1", "v1beta1", "extensions/alpha5", "batch/v1")
 
@@ -32,9 +32,11 @@ var InstallOrder SortOrder = []string{
 	"LimitRange",
 	"Secret",
 	"ConfigMap",
+	"StorageClass",
 	"PersistentVolume",
 	"PersistentVolumeClaim",
 	"ServiceAccount",
+	"CustomResourceDefinition",
 	"ClusterRole",
 	"ClusterRoleBinding",
 	"Role",
@@ -69,9 +71,11 @@ var UninstallOrder SortOrder = []string{
 	"Role",
 	"ClusterRoleBinding",
 	"ClusterRole",
+	"CustomResourceDefinition",
 	"ServiceAccount",
 	"PersistentVolumeClaim",
 	"PersistentVolume",
+	"StorageClass",
 	"ConfigMap",
 	"Secret",
 	"LimitRange",
@@ -81,10 +85,10 @@ var UninstallOrder SortOrder = []string{
 
 // sortByKind does an in-place sort of manifests by Kind.
 //
-// Results are sorted by 'ordering'
+// Results are sorted by 'ordering'. Manifests of the same Kind keep their order.
 func sortByKind(manifests []manifest, ordering SortOrder) []manifest {
 	ks := newKindSorter(manifests, ordering)
-	sort.Sort(ks)
+	sort.Stable(ks)
 	return ks.manifests
 }
 
@@ -45,6 +45,11 @@ func TestKindSorter(t *testing.T) {
 			content: "",
 			head:    &util.SimpleHead{Kind: "CronJob"},
 		},
+		{
+			name:    "C",
+			content: "",
+			head:    &util.SimpleHead{Kind: "CustomResourceDefinition"},
+		},
 		{
 			name:    "n",
 			content: "",
@@ -140,6 +145,11 @@ func TestKindSorter(t *testing.T) {
 			content: "",
 			head:    &util.SimpleHead{Kind: "StatefulSet"},
 		},
+		{
+			name:    "S",
+			content: "",
+			head:    &util.SimpleHead{Kind: "StorageClass"},
+		},
 	}
 
 	for _, test := range []struct {
@@ -147,8 +157,8 @@ func TestKindSorter(t *testing.T) {
 		order       SortOrder
 		expected    string
 	}{
-		{"install", InstallOrder, "abcdefghijklmnopqrstuv!"},
-		{"uninstall", UninstallOrder, "vmutsrqponlkjihgfedcba!"},
+		{"install", InstallOrder, "abcdeSfghCijklmnopqrstuv!"},
+		{"uninstall", UninstallOrder, "vmutsrqponlkjiChgfSedcba!"},
 	} {
 		var buf bytes.Buffer
 		t.Run(test.description, func(t *testing.T) {
@@ -165,3 +175,22 @@ func TestKindSorter(t *testing.T) {
 		})
 	}
 }
+
+func TestKindSorterKeepsOrderOfKind(t *testing.T) {
+	manifests := []manifest{
+		{name: "d", head: &util.SimpleHead{Kind: "Deployment"}},
+		{name: "b", head: &util.SimpleHead{Kind: "ServiceAccount"}},
+		{name: "c", head: &util.SimpleHead{Kind: "Deployment"}},
+		{name: "a", head: &util.SimpleHead{Kind: "ServiceAccount"}},
+		{name: "z", head: &util.SimpleHead{Kind: "CronTab"}},
+		{name: "y", head: &util.SimpleHead{Kind: "CronTab"}},
+	}
+
+	var buf bytes.Buffer
+	for _, r := range sortByKind(manifests, InstallOrder) {
+		buf.WriteString(r.name)
+	}
+	if got, expect := buf.String(), "badczy"; got != expect {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+	"k8s.io/kubernetes/pkg/client/leaderelection"
+	"k8s.io/kubernetes/pkg/client/leaderelection/resourcelock"
+	"k8s.io/kubernetes/pkg/client/record"
+)
+
+// LeaderLockName is the name of the Endpoints object that the replicas of
+// Tiller in a namespace elect their leader with.
+const LeaderLockName = "tiller-leader"
+
+// leaderStatus reports the outcome of the leader election.
+type leaderStatus interface {
+	// IsLeader reports whether this Tiller is the leader.
+	IsLeader() bool
+	// GetLeader returns the identity of the leader.
+	GetLeader() string
+}
+
+// leader is the leader election of the server. Every Tiller serves all
+// requests when it is nil.
+var leader leaderStatus
+
+// leaderMethods are the RPCs that change releases, which only the leader
+// serves so that replicas never write the same release concurrently.
+var leaderMethods = map[string]bool{
+	"InstallRelease":   true,
+	"UpdateRelease":    true,
+	"RollbackRelease":  true,
+	"UninstallRelease": true,
+	"RunReleaseTest":   true,
+}
+
+// EnableLeaderElection makes Tiller, named identity, run for leader among
+// the Tillers in namespace. Only the leader serves the RPCs that change
+// releases; the others serve the read-only RPCs. onStoppedLeading is called
+// when the leader loses its lease.
+//
+// It must be called before the server is started.
+func EnableLeaderElection(client internalclientset.Interface, namespace, identity string, onStoppedLeading func()) error {
+	lock := &resourcelock.EndpointsLock{
+		EndpointsMeta: api.ObjectMeta{Namespace: namespace, Name: LeaderLockName},
+		Client:        client,
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: record.NewBroadcaster().NewRecorder(api.EventSource{Component: "tiller"}),
+		},
+	}
+	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaderelection.DefaultLeaseDuration,
+		RenewDeadline: leaderelection.DefaultRenewDeadline,
+		RetryPeriod:   leaderelection.DefaultRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(<-chan struct{}) { log.Printf("%s is the leader", identity) },
+			OnStoppedLeading: onStoppedLeading,
+			OnNewLeader:      func(id string) { log.Printf("new leader elected: %s", id) },
+		},
+	})
+	if err != nil {
+		return err
+	}
+	leader = le
+	go le.Run()
+	return nil
+}
+
+// checkLeader returns an error if the RPC named by fullMethod changes
+// releases and this Tiller is not the leader.
+//
+// Requests are not forwarded to the leader. helm connects to the leader when
+// it opens its tunnel, so the error reaches clients that connect to a replica
+// directly, like through the tiller-deploy Service with --host, which is not
+// supported with several replicas, or that connected to a leader that has
+// lost its lease since. The error is Unavailable, as the request succeeds
+// when it is retried on the leader.
+func checkLeader(fullMethod string) error {
+	if leader == nil {
+		return nil
+	}
+	if _, m := splitMethod(fullMethod); !leaderMethods[m] || leader.IsLeader() {
+		return nil
+	}
+	return grpc.Errorf(codes.Unavailable, "this Tiller is not the leader, %q is; run the command again to connect to the leader (--host is not supported with several replicas of Tiller)", leader.GetLeader())
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type fakeLeaderStatus struct {
+	isLeader bool
+}
+
+func (l fakeLeaderStatus) IsLeader() bool    { return l.isLeader }
+func (l fakeLeaderStatus) GetLeader() string { return "tiller-deploy-1" }
+
+func TestCheckLeader(t *testing.T) {
+	defer func() { leader = nil }()
+
+	tests := []struct {
+		leader  leaderStatus
+		method  string
+		wantErr bool
+	}{
+		{nil, "/hapi.services.tiller.ReleaseService/InstallRelease", false},
+		{fakeLeaderStatus{true}, "/hapi.services.tiller.ReleaseService/InstallRelease", false},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/InstallRelease", true},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/UpdateRelease", true},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/RollbackRelease", true},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/UninstallRelease", true},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/RunReleaseTest", true},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/ListReleases", false},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/GetReleaseStatus", false},
+		{fakeLeaderStatus{false}, "/hapi.services.tiller.ReleaseService/GetVersion", false},
+	}
+	for _, tt := range tests {
+		leader = tt.leader
+		err := checkLeader(tt.method)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s with leader %v: expected error %t, got %v", tt.method, tt.leader, tt.wantErr, err)
+		}
+		if err != nil && grpc.Code(err) != codes.Unavailable {
+			t.Errorf("%s with leader %v: expected an Unavailable error, got %v", tt.method, tt.leader, err)
+		}
+	}
+}
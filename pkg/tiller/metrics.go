@@ -0,0 +1,148 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+var (
+	// requestsTotal counts the RPCs handled by Tiller, such as installs and
+	// upgrades, by method and resulting gRPC code.
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tiller",
+		Name:      "requests_total",
+		Help:      "Number of RPCs handled by Tiller, by method and gRPC code.",
+	}, []string{"method", "code"})
+
+	// requestDuration observes how long Tiller takes to handle RPCs.
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tiller",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to handle RPCs, by method.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 15),
+	}, []string{"method"})
+
+	// hookDuration observes how long it takes to run the hooks of an event.
+	hookDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tiller",
+		Name:      "hook_duration_seconds",
+		Help:      "Time taken to run the hooks of a release event, by event and result.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"hook", "result"})
+
+	// storageDuration observes the latency of the storage driver.
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tiller",
+		Name:      "storage_duration_seconds",
+		Help:      "Time taken by storage driver operations, by driver, operation and result.",
+	}, []string{"driver", "operation", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(requestsTotal)
+	prometheus.MustRegister(requestDuration)
+	prometheus.MustRegister(hookDuration)
+	prometheus.MustRegister(storageDuration)
+}
+
+// result returns the value of the result label for err.
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// observeRequest records the outcome of an RPC that started at start.
+func observeRequest(fullMethod string, start time.Time, err error) {
+	_, m := splitMethod(fullMethod)
+	requestsTotal.WithLabelValues(m, grpc.Code(err).String()).Inc()
+	requestDuration.WithLabelValues(m).Observe(time.Since(start).Seconds())
+}
+
+// observeHook records the outcome of running the hooks of an event.
+func observeHook(hook string, start time.Time, err error) {
+	hookDuration.WithLabelValues(hook, result(err)).Observe(time.Since(start).Seconds())
+}
+
+// instrumentedDriver records the latency of the operations of a storage driver.
+type instrumentedDriver struct {
+	driver.Driver
+}
+
+// InstrumentDriver returns a driver that records the latency of the
+// operations of d in the Tiller metrics.
+func InstrumentDriver(d driver.Driver) driver.Driver {
+	return &instrumentedDriver{Driver: d}
+}
+
+func (d *instrumentedDriver) observe(op string, start time.Time, err error) {
+	if err == driver.ErrReleaseNotFound || err == driver.ErrReleaseExists {
+		// these are answers, not failures of the storage backend
+		err = nil
+	}
+	storageDuration.WithLabelValues(d.Name(), op, result(err)).Observe(time.Since(start).Seconds())
+}
+
+func (d *instrumentedDriver) Create(key string, rls *release.Release) error {
+	start := time.Now()
+	err := d.Driver.Create(key, rls)
+	d.observe("create", start, err)
+	return err
+}
+
+func (d *instrumentedDriver) Update(key string, rls *release.Release) error {
+	start := time.Now()
+	err := d.Driver.Update(key, rls)
+	d.observe("update", start, err)
+	return err
+}
+
+func (d *instrumentedDriver) Delete(key string) (*release.Release, error) {
+	start := time.Now()
+	rls, err := d.Driver.Delete(key)
+	d.observe("delete", start, err)
+	return rls, err
+}
+
+func (d *instrumentedDriver) Get(key string) (*release.Release, error) {
+	start := time.Now()
+	rls, err := d.Driver.Get(key)
+	d.observe("get", start, err)
+	return rls, err
+}
+
+func (d *instrumentedDriver) List(filter func(*release.Release) bool) ([]*release.Release, error) {
+	start := time.Now()
+	rls, err := d.Driver.List(filter)
+	d.observe("list", start, err)
+	return rls, err
+}
+
+func (d *instrumentedDriver) Query(labels map[string]string) ([]*release.Release, error) {
+	start := time.Now()
+	rls, err := d.Driver.Query(labels)
+	d.observe("query", start, err)
+	return rls, err
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func TestInstrumentDriver(t *testing.T) {
+	d := InstrumentDriver(driver.NewMemory())
+	if d.Name() != driver.MemoryDriverName {
+		t.Errorf("Expected name %q, got %q", driver.MemoryDriverName, d.Name())
+	}
+
+	rel := releaseStub()
+	if err := d.Create("angry-panda.v1", rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if err := d.Create("angry-panda.v1", rel); err != driver.ErrReleaseExists {
+		t.Errorf("Expected %v, got %v", driver.ErrReleaseExists, err)
+	}
+	got, err := d.Get("angry-panda.v1")
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if got.Name != rel.Name {
+		t.Errorf("Expected release %q, got %q", rel.Name, got.Name)
+	}
+	if _, err := d.Get("angry-panda.v2"); err != driver.ErrReleaseNotFound {
+		t.Errorf("Expected %v, got %v", driver.ErrReleaseNotFound, err)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// pendingReleaseLease is how long a pending revision in storage keeps other
+// operations off its release. It bounds how long a release stays locked when
+// the Tiller that performed the operation died before recording its outcome.
+var pendingReleaseLease = 15 * time.Minute
+
+// releaseLocks tracks the releases with an operation in progress in this
+// Tiller. The zero value is ready to use.
+type releaseLocks struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func (l *releaseLocks) acquire(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held[name] {
+		return false
+	}
+	if l.held == nil {
+		l.held = map[string]bool{}
+	}
+	l.held[name] = true
+	return true
+}
+
+func (l *releaseLocks) release(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.held, name)
+}
+
+// errOperationInProgress returns the error for an operation on a release
+// that is being modified by another operation.
+func errOperationInProgress(name string) error {
+	return fmt.Errorf("another operation (install/upgrade/rollback/delete) is in progress for release %q", name)
+}
+
+// errPendingRelease returns the error for an operation on a release whose
+// pending revision rls keeps other operations off it until expiry.
+func errPendingRelease(rls *release.Release, expiry time.Time) error {
+	return fmt.Errorf("another operation (install/upgrade/rollback/delete) is in progress for release %q until %s at the latest; if it was interrupted, recover with 'helm rollback' or 'helm delete --purge'",
+		rls.Name, expiry.Format(time.RFC3339))
+}
+
+// lockRelease makes sure that no other operation modifies the named release
+// until the returned function is called. It fails fast if an operation is in
+// progress, either in this Tiller or, as recorded by a recent pending
+// revision in storage, in another one.
+//
+// Recovery operations, which roll back or purge a release that an
+// interrupted operation left pending, take over the pending revision.
+func (s *ReleaseServer) lockRelease(name string, recovery bool) (func(), error) {
+	if name == "" {
+		// a name will be generated, so there is nothing to conflict with
+		return func() {}, nil
+	}
+	if !s.locks.acquire(name) {
+		return nil, errOperationInProgress(name)
+	}
+
+	if last, err := s.env.Releases.Last(name); err == nil && isPending(last) {
+		expiry := timeconv.Time(last.Info.LastDeployed).Add(pendingReleaseLease)
+		if time.Now().Before(expiry) {
+			if !recovery {
+				s.locks.release(name)
+				return nil, errPendingRelease(last, expiry)
+			}
+			log.Printf("taking over release %s left %s by an interrupted operation", name, last.Info.Status.Code)
+		}
+	}
+	return func() { s.locks.release(name) }, nil
+}
+
+// isPending reports whether the operation that recorded rls is in progress.
+func isPending(rls *release.Release) bool {
+	if rls.Info == nil || rls.Info.Status == nil {
+		return false
+	}
+	switch rls.Info.Status.Code {
+	case release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK:
+		return true
+	}
+	return false
+}
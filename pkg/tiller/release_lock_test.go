@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+func TestUpdateReleaseInProgress(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{{Name: "templates/hello", Data: []byte("hello: world")}},
+		},
+	}
+
+	if !rs.locks.acquire(rel.Name) {
+		t.Fatal("Expected to acquire the release lock")
+	}
+	if _, err := rs.UpdateRelease(c, req); err == nil || !strings.Contains(err.Error(), "in progress") {
+		t.Errorf("Expected an operation in progress error, got %v", err)
+	}
+	if _, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name}); err == nil || !strings.Contains(err.Error(), "in progress") {
+		t.Errorf("Expected an operation in progress error, got %v", err)
+	}
+	rs.locks.release(rel.Name)
+
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Errorf("Expected update to succeed once the lock is released, got %s", err)
+	}
+	if !rs.locks.acquire(rel.Name) {
+		t.Error("Expected the release lock to be released after the update")
+	}
+}
+
+func TestLockReleasePending(t *testing.T) {
+	rs := rsFixture()
+
+	stale := namedReleaseStub("stale-pending", release.Status_PENDING_UPGRADE)
+	rs.env.Releases.Create(stale)
+	unlock, err := rs.lockRelease(stale.Name, false)
+	if err != nil {
+		t.Fatalf("Expected a stale pending release not to be locked, got %s", err)
+	}
+	unlock()
+
+	recent := namedReleaseStub("recent-pending", release.Status_PENDING_UPGRADE)
+	recent.Info.LastDeployed = timeconv.Now()
+	rs.env.Releases.Create(recent)
+	if _, err := rs.lockRelease(recent.Name, false); err == nil || !strings.Contains(err.Error(), "until") {
+		t.Fatalf("Expected a recent pending release to be locked until its lease expires, got %v", err)
+	}
+	if !rs.locks.acquire(recent.Name) {
+		t.Error("Expected a failed lock to leave the release unlocked in this Tiller")
+	}
+	rs.locks.release(recent.Name)
+
+	unlock, err = rs.lockRelease(recent.Name, true)
+	if err != nil {
+		t.Fatalf("Expected a recovery operation to take over a recent pending release, got %s", err)
+	}
+	unlock()
+}
+
+func TestRecoverPendingRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	deployed := namedReleaseStub("interrupted", release.Status_SUPERSEDED)
+	rs.env.Releases.Create(deployed)
+	pending := namedReleaseStub("interrupted", release.Status_PENDING_UPGRADE)
+	pending.Version = 2
+	pending.Info.LastDeployed = timeconv.Now()
+	rs.env.Releases.Create(pending)
+
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: pending.Name}); err == nil {
+		t.Error("Expected delete without --purge to be rejected on a pending release")
+	}
+	if _, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: pending.Name, Version: 1}); err != nil {
+		t.Fatalf("Expected rollback to take over a pending release, got %s", err)
+	}
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: pending.Name, Purge: true}); err != nil {
+		t.Fatalf("Expected delete --purge to take over a pending release, got %s", err)
+	}
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	ctx "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"k8s.io/kubernetes/pkg/api"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/rudder"
+	"k8s.io/helm/pkg/tiller/environment"
+	"k8s.io/helm/pkg/version"
+)
+
+// ReleaseModule manages the Kubernetes resources of releases.
+//
+// Tiller manages the resources itself with a LocalReleaseModule, or delegates
+// them to a rudder service, which may roll them out differently, with a
+// RemoteReleaseModule.
+type ReleaseModule interface {
+	// Create creates the resources of r.
+	Create(r *release.Release, timeout int64, wait bool) error
+	// Update updates the resources of current to those of target and returns
+	// the resources it created, as kind/namespace/name, also when it fails.
+	Update(current, target *release.Release, force, recreate bool, timeout int64, wait bool) ([]string, error)
+	// Rollback updates the resources of current to those of the earlier
+	// revision target.
+	Rollback(current, target *release.Release, force, recreate bool, timeout int64, wait bool) error
+	// Status returns the status of the resources of r as kubectl prints it
+	// and the resources as they exist in the cluster.
+	Status(r *release.Release) (string, []*release.Resource, error)
+	// Delete deletes the resources in manifests, in order, from the namespace
+	// of r and returns the failures.
+	Delete(r *release.Release, manifests []string) []error
+	// WaitForConditions waits until the resources of r report the status
+	// conditions, given by kind.
+	WaitForConditions(r *release.Release, timeout int64, conditions map[string]string) error
+	// ExecHook creates the resources of the hook h, executed for event, in
+	// namespace and waits until they are ready.
+	ExecHook(namespace string, h *release.Hook, event release.Hook_Event, timeout int64) error
+	// DeleteHook deletes the resources of the hook h from namespace.
+	DeleteHook(namespace string, h *release.Hook) error
+	// CreateTestPod creates the test pod in manifest in namespace.
+	CreateTestPod(namespace, manifest string, timeout int64) error
+	// WaitForTestPod waits until the test pod in manifest completed and
+	// returns the phase it completed in.
+	WaitForTestPod(namespace, manifest string, timeout int64) (api.PodPhase, error)
+	// TestPodLogs returns the logs of the test pod in manifest.
+	TestPodLogs(namespace, manifest string) (string, error)
+	// DeleteTestPod deletes the test pod in manifest from namespace.
+	DeleteTestPod(namespace, manifest string) error
+}
+
+// LocalReleaseModule manages the resources of releases with a KubeClient.
+type LocalReleaseModule struct {
+	KubeClient environment.KubeClient
+}
+
+// Create implements ReleaseModule Create.
+func (m *LocalReleaseModule) Create(r *release.Release, timeout int64, wait bool) error {
+	b := bytes.NewBufferString(r.Manifest)
+	return m.KubeClient.Create(r.Namespace, b, timeout, wait)
+}
+
+// Update implements ReleaseModule Update.
+func (m *LocalReleaseModule) Update(current, target *release.Release, force, recreate bool, timeout int64, wait bool) ([]string, error) {
+	c := bytes.NewBufferString(current.Manifest)
+	t := bytes.NewBufferString(target.Manifest)
+	return m.KubeClient.Update(target.Namespace, c, t, force, recreate, timeout, wait)
+}
+
+// Rollback implements ReleaseModule Rollback.
+func (m *LocalReleaseModule) Rollback(current, target *release.Release, force, recreate bool, timeout int64, wait bool) error {
+	_, err := m.Update(current, target, force, recreate, timeout, wait)
+	return err
+}
+
+// Status implements ReleaseModule Status.
+func (m *LocalReleaseModule) Status(r *release.Release) (string, []*release.Resource, error) {
+	status, err := m.KubeClient.Get(r.Namespace, bytes.NewBufferString(r.Manifest))
+	if err != nil {
+		return "", nil, err
+	}
+	kr, err := m.KubeClient.GetResources(r.Namespace, bytes.NewBufferString(r.Manifest))
+	if err != nil {
+		return "", nil, err
+	}
+	resources := make([]*release.Resource, 0, len(kr))
+	for _, res := range kr {
+		resources = append(resources, &release.Resource{
+			ApiVersion: res.APIVersion,
+			Kind:       res.Kind,
+			Name:       res.Name,
+			Namespace:  res.Namespace,
+			Object:     res.Object,
+		})
+	}
+	return status, resources, nil
+}
+
+// Delete implements ReleaseModule Delete.
+func (m *LocalReleaseModule) Delete(r *release.Release, manifests []string) []error {
+	var errs []error
+	for _, manifest := range manifests {
+		if err := m.KubeClient.Delete(r.Namespace, bytes.NewBufferString(manifest)); err != nil {
+			if err == kube.ErrNoObjectsVisited {
+				// Rewrite the message from "no objects visited"
+				err = errors.New("object not found, skipping delete")
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// WaitForConditions implements ReleaseModule WaitForConditions.
+func (m *LocalReleaseModule) WaitForConditions(r *release.Release, timeout int64, conditions map[string]string) error {
+	return m.KubeClient.WaitForConditions(r.Namespace, bytes.NewBufferString(r.Manifest), timeout, conditions)
+}
+
+// ExecHook implements ReleaseModule ExecHook.
+func (m *LocalReleaseModule) ExecHook(namespace string, h *release.Hook, event release.Hook_Event, timeout int64) error {
+	if err := m.KubeClient.Create(namespace, bytes.NewBufferString(h.Manifest), timeout, false); err != nil {
+		return err
+	}
+	if err := m.KubeClient.WatchUntilReady(namespace, bytes.NewBufferString(h.Manifest), timeout, false); err != nil {
+		return err
+	}
+	if event == release.Hook_CRD_INSTALL {
+		// Custom resources can only be created once their definition
+		// is established.
+		return m.KubeClient.WaitForConditions(namespace, bytes.NewBufferString(h.Manifest), timeout, map[string]string{"CustomResourceDefinition": "Established"})
+	}
+	return nil
+}
+
+// DeleteHook implements ReleaseModule DeleteHook.
+func (m *LocalReleaseModule) DeleteHook(namespace string, h *release.Hook) error {
+	return m.KubeClient.Delete(namespace, bytes.NewBufferString(h.Manifest))
+}
+
+// CreateTestPod implements ReleaseModule CreateTestPod.
+func (m *LocalReleaseModule) CreateTestPod(namespace, manifest string, timeout int64) error {
+	return m.KubeClient.Create(namespace, bytes.NewBufferString(manifest), timeout, false)
+}
+
+// WaitForTestPod implements ReleaseModule WaitForTestPod.
+func (m *LocalReleaseModule) WaitForTestPod(namespace, manifest string, timeout int64) (api.PodPhase, error) {
+	return m.KubeClient.WaitAndGetCompletedPodPhase(namespace, bytes.NewBufferString(manifest), time.Duration(timeout)*time.Second)
+}
+
+// TestPodLogs implements ReleaseModule TestPodLogs.
+func (m *LocalReleaseModule) TestPodLogs(namespace, manifest string) (string, error) {
+	return m.KubeClient.GetPodLogs(namespace, bytes.NewBufferString(manifest))
+}
+
+// DeleteTestPod implements ReleaseModule DeleteTestPod.
+func (m *LocalReleaseModule) DeleteTestPod(namespace, manifest string) error {
+	return m.KubeClient.Delete(namespace, bytes.NewBufferString(manifest))
+}
+
+// RemoteReleaseModule manages the resources of releases with a rudder
+// service.
+type RemoteReleaseModule struct {
+	client rudder.ReleaseModuleServiceClient
+}
+
+// NewRemoteReleaseModule returns a release module that delegates to the
+// rudder service at addr.
+func NewRemoteReleaseModule(addr string) (*RemoteReleaseModule, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteReleaseModule{client: rudder.NewReleaseModuleServiceClient(conn)}, nil
+}
+
+// remoteCallTimeout bounds how long a call to the rudder service may take on
+// top of the timeout of the call, if any.
+const remoteCallTimeout = 5 * time.Minute
+
+// remoteContext returns the context of a call to the rudder service that waits
+// for up to timeout seconds.
+func remoteContext(timeout int64) (ctx.Context, ctx.CancelFunc) {
+	return ctx.WithTimeout(ctx.Background(), time.Duration(timeout)*time.Second+remoteCallTimeout)
+}
+
+// Version returns the name and version of the rudder service.
+func (m *RemoteReleaseModule) Version() (*rudder.VersionReleaseResponse, error) {
+	c, cancel := remoteContext(0)
+	defer cancel()
+	return m.client.Version(c, &rudder.VersionReleaseRequest{})
+}
+
+// Create implements ReleaseModule Create.
+func (m *RemoteReleaseModule) Create(r *release.Release, timeout int64, wait bool) error {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	_, err := m.client.InstallRelease(c, &rudder.InstallReleaseRequest{
+		Release: r,
+		Timeout: timeout,
+		Wait:    wait,
+	})
+	return err
+}
+
+// Update implements ReleaseModule Update.
+func (m *RemoteReleaseModule) Update(current, target *release.Release, force, recreate bool, timeout int64, wait bool) ([]string, error) {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	res, err := m.client.UpgradeRelease(c, &rudder.UpgradeReleaseRequest{
+		Current:  current,
+		Target:   target,
+		Force:    force,
+		Recreate: recreate,
+		Timeout:  timeout,
+		Wait:     wait,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return res.Created, errors.New(res.Error)
+	}
+	return res.Created, nil
+}
+
+// Rollback implements ReleaseModule Rollback.
+func (m *RemoteReleaseModule) Rollback(current, target *release.Release, force, recreate bool, timeout int64, wait bool) error {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	_, err := m.client.RollbackRelease(c, &rudder.RollbackReleaseRequest{
+		Current:  current,
+		Target:   target,
+		Force:    force,
+		Recreate: recreate,
+		Timeout:  timeout,
+		Wait:     wait,
+	})
+	return err
+}
+
+// Status implements ReleaseModule Status.
+func (m *RemoteReleaseModule) Status(r *release.Release) (string, []*release.Resource, error) {
+	c, cancel := remoteContext(0)
+	defer cancel()
+	res, err := m.client.ReleaseStatus(c, &rudder.ReleaseStatusRequest{Release: r})
+	if err != nil {
+		return "", nil, err
+	}
+	return res.Resources, res.ResourceList, nil
+}
+
+// Delete implements ReleaseModule Delete.
+func (m *RemoteReleaseModule) Delete(r *release.Release, manifests []string) []error {
+	c, cancel := remoteContext(0)
+	defer cancel()
+	res, err := m.client.DeleteRelease(c, &rudder.DeleteReleaseRequest{
+		Release:   r,
+		Manifests: manifests,
+	})
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range res.Errors {
+		errs = append(errs, errors.New(e))
+	}
+	return errs
+}
+
+// WaitForConditions implements ReleaseModule WaitForConditions.
+func (m *RemoteReleaseModule) WaitForConditions(r *release.Release, timeout int64, conditions map[string]string) error {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	_, err := m.client.WaitForConditions(c, &rudder.WaitForConditionsRequest{
+		Release:    r,
+		Timeout:    timeout,
+		Conditions: conditions,
+	})
+	return err
+}
+
+// ExecHook implements ReleaseModule ExecHook.
+func (m *RemoteReleaseModule) ExecHook(namespace string, h *release.Hook, event release.Hook_Event, timeout int64) error {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	_, err := m.client.ExecHook(c, &rudder.ExecHookRequest{
+		Namespace: namespace,
+		Hook:      h,
+		Event:     event,
+		Timeout:   timeout,
+	})
+	return err
+}
+
+// DeleteHook implements ReleaseModule DeleteHook.
+func (m *RemoteReleaseModule) DeleteHook(namespace string, h *release.Hook) error {
+	c, cancel := remoteContext(0)
+	defer cancel()
+	_, err := m.client.DeleteHook(c, &rudder.DeleteHookRequest{Namespace: namespace, Hook: h})
+	return err
+}
+
+// CreateTestPod implements ReleaseModule CreateTestPod.
+func (m *RemoteReleaseModule) CreateTestPod(namespace, manifest string, timeout int64) error {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	_, err := m.client.CreateTestPod(c, &rudder.CreateTestPodRequest{
+		Namespace: namespace,
+		Manifest:  manifest,
+		Timeout:   timeout,
+	})
+	return err
+}
+
+// WaitForTestPod implements ReleaseModule WaitForTestPod.
+func (m *RemoteReleaseModule) WaitForTestPod(namespace, manifest string, timeout int64) (api.PodPhase, error) {
+	c, cancel := remoteContext(timeout)
+	defer cancel()
+	res, err := m.client.WaitForTestPod(c, &rudder.WaitForTestPodRequest{
+		Namespace: namespace,
+		Manifest:  manifest,
+		Timeout:   timeout,
+	})
+	if err != nil {
+		return api.PodUnknown, err
+	}
+	return api.PodPhase(res.Phase), nil
+}
+
+// TestPodLogs implements ReleaseModule TestPodLogs.
+func (m *RemoteReleaseModule) TestPodLogs(namespace, manifest string) (string, error) {
+	c, cancel := remoteContext(0)
+	defer cancel()
+	res, err := m.client.TestPodLogs(c, &rudder.TestPodLogsRequest{Namespace: namespace, Manifest: manifest})
+	if err != nil {
+		return "", err
+	}
+	return res.Logs, nil
+}
+
+// DeleteTestPod implements ReleaseModule DeleteTestPod.
+func (m *RemoteReleaseModule) DeleteTestPod(namespace, manifest string) error {
+	c, cancel := remoteContext(0)
+	defer cancel()
+	_, err := m.client.DeleteTestPod(c, &rudder.DeleteTestPodRequest{Namespace: namespace, Manifest: manifest})
+	return err
+}
+
+// NewReleaseModuleServer returns a rudder service that manages the resources
+// of releases with module.
+func NewReleaseModuleServer(module ReleaseModule) rudder.ReleaseModuleServiceServer {
+	return &releaseModuleServer{module: module}
+}
+
+type releaseModuleServer struct {
+	module ReleaseModule
+}
+
+func (s *releaseModuleServer) Version(c ctx.Context, req *rudder.VersionReleaseRequest) (*rudder.VersionReleaseResponse, error) {
+	return &rudder.VersionReleaseResponse{Name: "rudder", Version: version.GetVersion()}, nil
+}
+
+func (s *releaseModuleServer) InstallRelease(c ctx.Context, req *rudder.InstallReleaseRequest) (*rudder.InstallReleaseResponse, error) {
+	return &rudder.InstallReleaseResponse{}, s.module.Create(req.Release, req.Timeout, req.Wait)
+}
+
+func (s *releaseModuleServer) UpgradeRelease(c ctx.Context, req *rudder.UpgradeReleaseRequest) (*rudder.UpgradeReleaseResponse, error) {
+	created, err := s.module.Update(req.Current, req.Target, req.Force, req.Recreate, req.Timeout, req.Wait)
+	res := &rudder.UpgradeReleaseResponse{Created: created}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res, nil
+}
+
+func (s *releaseModuleServer) RollbackRelease(c ctx.Context, req *rudder.RollbackReleaseRequest) (*rudder.RollbackReleaseResponse, error) {
+	return &rudder.RollbackReleaseResponse{}, s.module.Rollback(req.Current, req.Target, req.Force, req.Recreate, req.Timeout, req.Wait)
+}
+
+func (s *releaseModuleServer) ReleaseStatus(c ctx.Context, req *rudder.ReleaseStatusRequest) (*rudder.ReleaseStatusResponse, error) {
+	status, resources, err := s.module.Status(req.Release)
+	return &rudder.ReleaseStatusResponse{Resources: status, ResourceList: resources}, err
+}
+
+func (s *releaseModuleServer) DeleteRelease(c ctx.Context, req *rudder.DeleteReleaseRequest) (*rudder.DeleteReleaseResponse, error) {
+	res := &rudder.DeleteReleaseResponse{}
+	for _, err := range s.module.Delete(req.Release, req.Manifests) {
+		res.Errors = append(res.Errors, err.Error())
+	}
+	return res, nil
+}
+
+func (s *releaseModuleServer) WaitForConditions(c ctx.Context, req *rudder.WaitForConditionsRequest) (*rudder.WaitForConditionsResponse, error) {
+	return &rudder.WaitForConditionsResponse{}, s.module.WaitForConditions(req.Release, req.Timeout, req.Conditions)
+}
+
+func (s *releaseModuleServer) ExecHook(c ctx.Context, req *rudder.ExecHookRequest) (*rudder.ExecHookResponse, error) {
+	return &rudder.ExecHookResponse{}, s.module.ExecHook(req.Namespace, req.Hook, req.Event, req.Timeout)
+}
+
+func (s *releaseModuleServer) DeleteHook(c ctx.Context, req *rudder.DeleteHookRequest) (*rudder.DeleteHookResponse, error) {
+	return &rudder.DeleteHookResponse{}, s.module.DeleteHook(req.Namespace, req.Hook)
+}
+
+func (s *releaseModuleServer) CreateTestPod(c ctx.Context, req *rudder.CreateTestPodRequest) (*rudder.CreateTestPodResponse, error) {
+	return &rudder.CreateTestPodResponse{}, s.module.CreateTestPod(req.Namespace, req.Manifest, req.Timeout)
+}
+
+func (s *releaseModuleServer) WaitForTestPod(c ctx.Context, req *rudder.WaitForTestPodRequest) (*rudder.WaitForTestPodResponse, error) {
+	phase, err := s.module.WaitForTestPod(req.Namespace, req.Manifest, req.Timeout)
+	return &rudder.WaitForTestPodResponse{Phase: string(phase)}, err
+}
+
+func (s *releaseModuleServer) TestPodLogs(c ctx.Context, req *rudder.TestPodLogsRequest) (*rudder.TestPodLogsResponse, error) {
+	logs, err := s.module.TestPodLogs(req.Namespace, req.Manifest)
+	return &rudder.TestPodLogsResponse{Logs: logs}, err
+}
+
+func (s *releaseModuleServer) DeleteTestPod(c ctx.Context, req *rudder.DeleteTestPodRequest) (*rudder.DeleteTestPodResponse, error) {
+	return &rudder.DeleteTestPodResponse{}, s.module.DeleteTestPod(req.Namespace, req.Manifest)
+}
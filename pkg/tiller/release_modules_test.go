@@ -0,0 +1,269 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/kubernetes/pkg/api"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/rudder"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// moduleKubeClient records the operations of a release module.
+type moduleKubeClient struct {
+	environment.PrintingKubeClient
+	actions []string
+}
+
+func (k *moduleKubeClient) record(action, ns string, r io.Reader) {
+	b, _ := ioutil.ReadAll(r)
+	k.actions = append(k.actions, action+" "+ns+" "+string(b))
+}
+
+func (k *moduleKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	k.record("create", ns, r)
+	return nil
+}
+
+func (k *moduleKubeClient) Update(ns string, current, modified io.Reader, force, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
+	k.record("update", ns, modified)
+	return nil, nil
+}
+
+func (k *moduleKubeClient) Get(ns string, r io.Reader) (string, error) {
+	k.record("get", ns, r)
+	return "RESOURCES", nil
+}
+
+func (k *moduleKubeClient) GetResources(ns string, r io.Reader) ([]*kube.Resource, error) {
+	k.record("resources", ns, r)
+	return []*kube.Resource{{APIVersion: "v1", Kind: "Pod", Name: "otter", Namespace: ns, Object: []byte(`{"kind":"Pod"}`)}}, nil
+}
+
+func (k *moduleKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	k.record("watch", ns, r)
+	return nil
+}
+
+func (k *moduleKubeClient) WaitForConditions(ns string, r io.Reader, timeout int64, conditions map[string]string) error {
+	k.record("conditions", ns, r)
+	return nil
+}
+
+func (k *moduleKubeClient) WaitAndGetCompletedPodPhase(ns string, r io.Reader, timeout time.Duration) (api.PodPhase, error) {
+	k.record("phase", ns, r)
+	return api.PodSucceeded, nil
+}
+
+func (k *moduleKubeClient) GetPodLogs(ns string, r io.Reader) (string, error) {
+	k.record("logs", ns, r)
+	return "LOGS", nil
+}
+
+func (k *moduleKubeClient) Delete(ns string, r io.Reader) error {
+	b, _ := ioutil.ReadAll(r)
+	k.actions = append(k.actions, "delete "+ns+" "+string(b))
+	if strings.Contains(string(b), "fail") {
+		return errors.New("delete failed")
+	}
+	return nil
+}
+
+func TestRemoteReleaseModule(t *testing.T) {
+	kc := &moduleKubeClient{}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	rudder.RegisterReleaseModuleServiceServer(srv, NewReleaseModuleServer(&LocalReleaseModule{KubeClient: kc}))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	m, err := NewRemoteReleaseModule(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := m.Version()
+	if err != nil {
+		t.Fatalf("Failed to get the version: %s", err)
+	}
+	if v.Name != "rudder" {
+		t.Errorf("Expected the rudder module, got %q", v.Name)
+	}
+
+	current := &release.Release{Name: "angry-panda", Namespace: "spaced", Manifest: "v1"}
+	target := &release.Release{Name: "angry-panda", Namespace: "spaced", Manifest: "v2"}
+	if err := m.Create(current, 10, false); err != nil {
+		t.Fatalf("Failed create: %s", err)
+	}
+	if _, err := m.Update(current, target, false, false, 10, false); err != nil {
+		t.Fatalf("Failed update: %s", err)
+	}
+	if err := m.Rollback(target, current, false, false, 10, false); err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	status, resources, err := m.Status(current)
+	if err != nil {
+		t.Fatalf("Failed status: %s", err)
+	}
+	if status != "RESOURCES" {
+		t.Errorf("Expected the status of the resources, got %q", status)
+	}
+	expectResources := []*release.Resource{{ApiVersion: "v1", Kind: "Pod", Name: "otter", Namespace: "spaced", Object: []byte(`{"kind":"Pod"}`)}}
+	if !reflect.DeepEqual(resources, expectResources) {
+		t.Errorf("Expected resources %v, got %v", expectResources, resources)
+	}
+	errs := m.Delete(current, []string{"a", "fail", "b"})
+	if len(errs) != 1 || errs[0].Error() != "delete failed" {
+		t.Errorf("Expected the failed deletion to be reported, got %v", errs)
+	}
+	if err := m.WaitForConditions(current, 10, map[string]string{"Job": "Complete"}); err != nil {
+		t.Fatalf("Failed waiting for conditions: %s", err)
+	}
+	hook := &release.Hook{Name: "crd", Manifest: "hook"}
+	if err := m.ExecHook("spaced", hook, release.Hook_CRD_INSTALL, 10); err != nil {
+		t.Fatalf("Failed executing hook: %s", err)
+	}
+	if err := m.DeleteHook("spaced", hook); err != nil {
+		t.Fatalf("Failed deleting hook: %s", err)
+	}
+	if err := m.CreateTestPod("spaced", "test", 10); err != nil {
+		t.Fatalf("Failed creating test pod: %s", err)
+	}
+	phase, err := m.WaitForTestPod("spaced", "test", 10)
+	if err != nil {
+		t.Fatalf("Failed waiting for test pod: %s", err)
+	}
+	if phase != api.PodSucceeded {
+		t.Errorf("Expected the test pod to succeed, got %s", phase)
+	}
+	logs, err := m.TestPodLogs("spaced", "test")
+	if err != nil {
+		t.Fatalf("Failed getting test pod logs: %s", err)
+	}
+	if logs != "LOGS" {
+		t.Errorf("Expected the test pod logs, got %q", logs)
+	}
+	if err := m.DeleteTestPod("spaced", "test"); err != nil {
+		t.Fatalf("Failed deleting test pod: %s", err)
+	}
+
+	expect := []string{
+		"create spaced v1",
+		"update spaced v2",
+		"update spaced v1",
+		"get spaced v1",
+		"resources spaced v1",
+		"delete spaced a",
+		"delete spaced fail",
+		"delete spaced b",
+		"conditions spaced v1",
+		"create spaced hook",
+		"watch spaced hook",
+		"conditions spaced hook",
+		"delete spaced hook",
+		"create spaced test",
+		"phase spaced test",
+		"logs spaced test",
+		"delete spaced test",
+	}
+	if !reflect.DeepEqual(kc.actions, expect) {
+		t.Errorf("Expected actions %v, got %v", expect, kc.actions)
+	}
+}
+
+// recordingReleaseModule records the releases whose resources it manages.
+type recordingReleaseModule struct {
+	LocalReleaseModule
+	actions []string
+}
+
+func (m *recordingReleaseModule) Create(r *release.Release, timeout int64, wait bool) error {
+	m.actions = append(m.actions, "create "+r.Name)
+	return nil
+}
+
+func (m *recordingReleaseModule) Delete(r *release.Release, manifests []string) []error {
+	m.actions = append(m.actions, "delete "+r.Name)
+	return nil
+}
+
+func (m *recordingReleaseModule) ExecHook(namespace string, h *release.Hook, event release.Hook_Event, timeout int64) error {
+	m.actions = append(m.actions, "hook "+h.Name+" "+event.String())
+	return nil
+}
+
+func (m *recordingReleaseModule) DeleteHook(namespace string, h *release.Hook) error {
+	m.actions = append(m.actions, "delete hook "+h.Name)
+	return nil
+}
+
+func TestRemoteContext(t *testing.T) {
+	c, cancel := remoteContext(10)
+	defer cancel()
+	deadline, ok := c.Deadline()
+	if !ok {
+		t.Fatal("expected remote calls to have a deadline")
+	}
+	if d := deadline.Sub(time.Now()); d <= remoteCallTimeout || d > 10*time.Second+remoteCallTimeout {
+		t.Errorf("expected a deadline of the timeout plus %s, got %s", remoteCallTimeout, d)
+	}
+}
+
+func TestReleaseServerWithReleaseModule(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	m := &recordingReleaseModule{}
+	rs.ReleaseModule = m
+
+	res, err := rs.InstallRelease(c, &services.InstallReleaseRequest{
+		Chart: chartStub(),
+		Name:  "angry-panda",
+	})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: res.Release.Name}); err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+
+	expect := []string{
+		"create angry-panda",
+		"hook test-cm POST_INSTALL",
+		"hook test-cm PRE_DELETE",
+		"delete angry-panda",
+	}
+	if !reflect.DeepEqual(m.actions, expect) {
+		t.Errorf("Expected actions %v, got %v", expect, m.actions)
+	}
+}
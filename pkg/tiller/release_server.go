@@ -20,20 +20,26 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"math"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/technosophos/moniker"
 	ctx "golang.org/x/net/context"
+	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/client/typed/discovery"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
-	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -83,8 +89,13 @@ var ValidName = regexp.MustCompile("^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])+
 
 // ReleaseServer implements the server-side gRPC endpoint for the HAPI services.
 type ReleaseServer struct {
+	// ReleaseModule manages the resources of releases. The KubeClient of the
+	// environment manages them if it is nil.
+	ReleaseModule ReleaseModule
+
 	env       *environment.Environment
 	clientset internalclientset.Interface
+	locks     releaseLocks
 }
 
 // NewReleaseServer creates a new release server.
@@ -95,6 +106,14 @@ func NewReleaseServer(env *environment.Environment, clientset internalclientset.
 	}
 }
 
+// module returns the release module that manages the resources of releases.
+func (s *ReleaseServer) module() ReleaseModule {
+	if s.ReleaseModule != nil {
+		return s.ReleaseModule
+	}
+	return &LocalReleaseModule{KubeClient: s.env.KubeClient}
+}
+
 // ListReleases lists the releases found by the server.
 func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream services.ReleaseService_ListReleasesServer) error {
 	if len(req.StatusCodes) == 0 {
@@ -254,8 +273,7 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 
 	// Ok, we got the status of the release as we had jotted down, now we need to match the
 	// manifest we stashed away with reality from the cluster.
-	kubeCli := s.env.KubeClient
-	resp, err := kubeCli.Get(rel.Namespace, bytes.NewBufferString(rel.Manifest))
+	resp, resources, err := s.module().Status(rel)
 	if sc == release.Status_DELETED || sc == release.Status_FAILED {
 		// Skip errors if this is already deleted or failed.
 		return statusResp, nil
@@ -264,6 +282,7 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 		return nil, err
 	}
 	rel.Info.Status.Resources = resp
+	rel.Info.Resources = resources
 	return statusResp, nil
 }
 
@@ -284,18 +303,32 @@ func (s *ReleaseServer) GetReleaseContent(c ctx.Context, req *services.GetReleas
 
 // UpdateRelease takes an existing release and new information, and upgrades the release.
 func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+	unlock, err := s.lockRelease(req.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	currentRelease, updatedRelease, err := s.prepareUpdate(req)
 	if err != nil {
 		return nil, err
 	}
 
+	// An atomic upgrade is only considered successful once its resources are ready.
+	if req.Atomic {
+		req.Wait = true
+	}
+
 	res, err := s.performUpdate(currentRelease, updatedRelease, req)
 	if err != nil {
+		if req.Atomic && !req.DryRun {
+			return res, s.rollbackFailedUpdate(c, currentRelease, req, err)
+		}
 		return res, err
 	}
 
 	if !req.DryRun {
-		if err := s.env.Releases.Create(updatedRelease); err != nil {
+		if err := s.env.Releases.Update(updatedRelease); err != nil {
 			return res, err
 		}
 	}
@@ -303,6 +336,24 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 	return res, nil
 }
 
+// rollbackFailedUpdate rolls a release back to the revision that was deployed
+// before a failed atomic upgrade.
+func (s *ReleaseServer) rollbackFailedUpdate(c ctx.Context, current *release.Release, req *services.UpdateReleaseRequest, failure error) error {
+	log.Printf("Rolling back %q to revision %d after failed upgrade", current.Name, current.Version)
+	_, err := s.rollbackRelease(&services.RollbackReleaseRequest{
+		Name:         current.Name,
+		Version:      current.Version,
+		DisableHooks: req.DisableHooks,
+		Recreate:     req.Recreate,
+		Timeout:      req.Timeout,
+		Wait:         true,
+	})
+	if err != nil {
+		return fmt.Errorf("%s; rollback to revision %d also failed: %s", failure, current.Version, err)
+	}
+	return fmt.Errorf("%s; release was rolled back to revision %d", failure, current.Version)
+}
+
 func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.Release, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
 	res := &services.UpdateReleaseResponse{Release: updatedRelease}
 
@@ -312,27 +363,45 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 		return res, nil
 	}
 
+	// Record the pending release so that an interrupted upgrade remains visible.
+	s.recordRelease(updatedRelease, false)
+
 	// pre-upgrade hooks
 	if !req.DisableHooks {
 		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PreUpgrade, req.Timeout); err != nil {
+			s.failRelease(updatedRelease, fmt.Sprintf("Upgrade %q failed pre-upgrade: %s", updatedRelease.Name, err))
 			return res, err
 		}
 	}
 
-	if err := s.performKubeUpdate(originalRelease, updatedRelease, req.Recreate, req.Timeout, req.Wait); err != nil {
+	start := time.Now()
+	created, err := s.performKubeUpdate(originalRelease, updatedRelease, req.Force, req.Recreate, req.Timeout, req.Wait)
+	if err == nil {
+		err = s.waitForConditions(updatedRelease, req.Wait, req.Timeout, start, req.WaitConditions)
+	}
+	if err != nil {
 		msg := fmt.Sprintf("Upgrade %q failed: %s", updatedRelease.Name, err)
 		log.Printf("warning: %s", msg)
+		if req.CleanupOnFail {
+			s.deleteCreatedResources(updatedRelease, created)
+		}
 		originalRelease.Info.Status.Code = release.Status_SUPERSEDED
 		updatedRelease.Info.Status.Code = release.Status_FAILED
 		updatedRelease.Info.Description = msg
 		s.recordRelease(originalRelease, true)
-		s.recordRelease(updatedRelease, false)
+		s.recordRelease(updatedRelease, true)
 		return res, err
 	}
 
 	// post-upgrade hooks
 	if !req.DisableHooks {
 		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout); err != nil {
+			if req.CleanupOnFail {
+				s.deleteCreatedResources(updatedRelease, created)
+			}
+			originalRelease.Info.Status.Code = release.Status_SUPERSEDED
+			s.recordRelease(originalRelease, true)
+			s.failRelease(updatedRelease, fmt.Sprintf("Upgrade %q failed post-upgrade: %s", updatedRelease.Name, err))
 			return res, err
 		}
 	}
@@ -354,6 +423,12 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 //
 // This is skipped if the req.ResetValues flag is set, in which case the
 // request values are not altered.
+//
+// If req.ResetThenReuseValues is set, the new chart's default values are kept
+// and the user-supplied values of the current release are merged underneath the
+// request values. The resulting precedence, from lowest to highest, is: the new
+// chart's values.yaml, the current release's user-supplied values, and the
+// values in the request.
 func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current *release.Release) error {
 	if req.ResetValues {
 		// If ResetValues is set, we comletely ignore current.Config.
@@ -361,6 +436,32 @@ func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current
 		return nil
 	}
 
+	if req.ResetThenReuseValues {
+		log.Print("Reusing the old release's user-supplied values on top of the new chart's defaults")
+
+		newVals := chartutil.Values{}
+		if req.Values != nil {
+			vals, err := chartutil.ReadValues([]byte(req.Values.Raw))
+			if err != nil {
+				return fmt.Errorf("failed to parse new values: %s", err)
+			}
+			newVals = vals
+		}
+		if current.Config != nil {
+			oldVals, err := chartutil.ReadValues([]byte(current.Config.Raw))
+			if err != nil {
+				return fmt.Errorf("failed to parse old values: %s", err)
+			}
+			chartutil.CoalesceTables(newVals, oldVals)
+		}
+		nv, err := newVals.YAML()
+		if err != nil {
+			return err
+		}
+		req.Values = &chart.Config{Raw: nv}
+		return nil
+	}
+
 	// If the ReuseValues flag is set, we always copy the old values over the new config's values.
 	if req.ReuseValues {
 		log.Print("Reusing the old release's values")
@@ -435,7 +536,7 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		return nil, nil, err
 	}
 
-	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions)
+	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions, req.SubNotes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -449,7 +550,7 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		Info: &release.Info{
 			FirstDeployed: currentRelease.Info.FirstDeployed,
 			LastDeployed:  ts,
-			Status:        &release.Status{Code: release.Status_UNKNOWN},
+			Status:        &release.Status{Code: release.Status_PENDING_UPGRADE},
 			Description:   "Preparing upgrade", // This should be overwritten later.
 		},
 		Version:  revision,
@@ -466,6 +567,17 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 
 // RollbackRelease rolls back to a previous version of the given release.
 func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
+	unlock, err := s.lockRelease(req.Name, true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.rollbackRelease(req)
+}
+
+// rollbackRelease rolls back a release whose lock is held by the caller.
+func (s *ReleaseServer) rollbackRelease(req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
 	currentRelease, targetRelease, err := s.prepareRollback(req)
 	if err != nil {
 		return nil, err
@@ -477,7 +589,7 @@ func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackRel
 	}
 
 	if !req.DryRun {
-		if err := s.env.Releases.Create(targetRelease); err != nil {
+		if err := s.env.Releases.Update(targetRelease); err != nil {
 			return res, err
 		}
 	}
@@ -493,27 +605,34 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 		return res, nil
 	}
 
+	// Record the pending release so that an interrupted rollback remains visible.
+	s.recordRelease(targetRelease, false)
+
 	// pre-rollback hooks
 	if !req.DisableHooks {
 		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PreRollback, req.Timeout); err != nil {
+			s.failRelease(targetRelease, fmt.Sprintf("Rollback %q failed pre-rollback: %s", targetRelease.Name, err))
 			return res, err
 		}
 	}
 
-	if err := s.performKubeUpdate(currentRelease, targetRelease, req.Recreate, req.Timeout, req.Wait); err != nil {
+	if err := s.module().Rollback(currentRelease, targetRelease, req.Force, req.Recreate, req.Timeout, req.Wait); err != nil {
 		msg := fmt.Sprintf("Rollback %q failed: %s", targetRelease.Name, err)
 		log.Printf("warning: %s", msg)
 		currentRelease.Info.Status.Code = release.Status_SUPERSEDED
 		targetRelease.Info.Status.Code = release.Status_FAILED
 		targetRelease.Info.Description = msg
 		s.recordRelease(currentRelease, true)
-		s.recordRelease(targetRelease, false)
+		s.recordRelease(targetRelease, true)
 		return res, err
 	}
 
 	// post-rollback hooks
 	if !req.DisableHooks {
 		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PostRollback, req.Timeout); err != nil {
+			currentRelease.Info.Status.Code = release.Status_SUPERSEDED
+			s.recordRelease(currentRelease, true)
+			s.failRelease(targetRelease, fmt.Sprintf("Rollback %q failed post-rollback: %s", targetRelease.Name, err))
 			return res, err
 		}
 	}
@@ -526,11 +645,81 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 	return res, nil
 }
 
-func (s *ReleaseServer) performKubeUpdate(currentRelease, targetRelease *release.Release, recreate bool, timeout int64, shouldWait bool) error {
-	kubeCli := s.env.KubeClient
-	current := bytes.NewBufferString(currentRelease.Manifest)
-	target := bytes.NewBufferString(targetRelease.Manifest)
-	return kubeCli.Update(targetRelease.Namespace, current, target, recreate, timeout, shouldWait)
+// deleteCreatedResources deletes the resources in the manifest of the updated
+// release that the failed upgrade created, as reported by the release module.
+// Resources that existed before the upgrade, also those not managed by the
+// release, are left alone. Failures are logged only, as the upgrade has failed
+// already.
+func (s *ReleaseServer) deleteCreatedResources(updated *release.Release, created []string) {
+	wasCreated := map[string]bool{}
+	for _, key := range created {
+		wasCreated[key] = true
+	}
+	for _, doc := range relutil.SplitManifests(updated.Manifest) {
+		key, ok := resourceKey(doc, updated.Namespace)
+		if !ok || !wasCreated[key] {
+			continue
+		}
+		log.Printf("Deleting %s created by the failed upgrade of %q", key, updated.Name)
+		for _, err := range s.module().Delete(updated, []string{doc}) {
+			log.Printf("warning: Failed to delete %s: %s", key, err)
+		}
+	}
+}
+
+// resourceKey identifies the resource of a manifest document by its kind,
+// namespace and name. It reports false for documents without a resource.
+func resourceKey(doc, namespace string) (string, bool) {
+	var head relutil.SimpleHead
+	if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" || head.Metadata == nil || head.Metadata.Name == "" {
+		return "", false
+	}
+	if head.Metadata.Namespace != "" {
+		namespace = head.Metadata.Namespace
+	}
+	return head.Kind + "/" + namespace + "/" + head.Metadata.Name, true
+}
+
+func (s *ReleaseServer) performKubeUpdate(currentRelease, targetRelease *release.Release, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
+	return s.module().Update(currentRelease, targetRelease, force, recreate, timeout, shouldWait)
+}
+
+// waitForConditions waits until the resources of a release report the requested
+// status conditions. It does nothing unless wait is set and conditions are given.
+// The wait shares the timeout with the rollout that began at start, so that
+// both together take no longer than timeout.
+func (s *ReleaseServer) waitForConditions(r *release.Release, wait bool, timeout int64, start time.Time, conditions map[string]string) error {
+	if !wait || len(conditions) == 0 {
+		return nil
+	}
+	remaining := time.Duration(timeout)*time.Second - time.Since(start)
+	if remaining <= 0 {
+		return fmt.Errorf("timed out after %ds before waiting for status conditions", timeout)
+	}
+	return s.module().WaitForConditions(r, int64(math.Ceil(remaining.Seconds())), conditions)
+}
+
+// previousDeployedVersion returns the most recent revision older than current
+// that was deployed or superseded, skipping failed and pending ones.
+func (s *ReleaseServer) previousDeployedVersion(current *release.Release) (int32, error) {
+	h, err := s.env.Releases.History(current.Name)
+	if err != nil {
+		return 0, err
+	}
+	var v int32
+	for _, r := range h {
+		if r.Version >= current.Version || r.Version <= v {
+			continue
+		}
+		switch r.Info.Status.Code {
+		case release.Status_DEPLOYED, release.Status_SUPERSEDED:
+			v = r.Version
+		}
+	}
+	if v == 0 {
+		return 0, fmt.Errorf("release %q has no previous revision to roll back to", current.Name)
+	}
+	return v, nil
 }
 
 // prepareRollback finds the previous release and prepares a new release object with
@@ -548,9 +737,17 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 		return nil, nil, err
 	}
 
+	// Revision 0 is the most recent revision before the current one that was
+	// deployed successfully. Deleting a release does not add a revision, so a
+	// deleted release is restored from its own revision rather than from an
+	// earlier one.
 	rbv := req.Version
 	if req.Version == 0 {
-		rbv = crls.Version - 1
+		if crls.Info.Status.Code == release.Status_DELETED {
+			rbv = crls.Version
+		} else if rbv, err = s.previousDeployedVersion(crls); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	log.Printf("rolling back %s (current: v%d, target: v%d)", req.Name, crls.Version, rbv)
@@ -570,7 +767,7 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 			FirstDeployed: crls.Info.FirstDeployed,
 			LastDeployed:  timeconv.Now(),
 			Status: &release.Status{
-				Code:  release.Status_UNKNOWN,
+				Code:  release.Status_PENDING_ROLLBACK,
 				Notes: prls.Info.Status.Notes,
 			},
 			// Because we lose the reference to rbv elsewhere, we set the
@@ -644,6 +841,12 @@ func (s *ReleaseServer) engine(ch *chart.Chart) environment.Engine {
 
 // InstallRelease installs a release and stores the release record.
 func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	unlock, err := s.lockRelease(req.Name, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	rel, err := s.prepareRelease(req)
 	if err != nil {
 		log.Printf("Failed install prepare step: %s", err)
@@ -657,13 +860,38 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		return res, err
 	}
 
+	// An atomic install is only considered successful once its resources are ready.
+	if req.Atomic {
+		req.Wait = true
+	}
+
 	res, err := s.performRelease(rel, req)
 	if err != nil {
 		log.Printf("Failed install perform step: %s", err)
+		if req.Atomic && !req.DryRun {
+			return res, s.deleteFailedInstall(c, rel, req, err)
+		}
 	}
 	return res, err
 }
 
+// deleteFailedInstall deletes a release after a failed atomic install. A first
+// install is purged; when the install replaced a previous release, the history
+// is kept and the release is marked as deleted.
+func (s *ReleaseServer) deleteFailedInstall(c ctx.Context, r *release.Release, req *services.InstallReleaseRequest, failure error) error {
+	log.Printf("Deleting %q after failed install", r.Name)
+	_, err := s.uninstallRelease(&services.UninstallReleaseRequest{
+		Name:         r.Name,
+		DisableHooks: req.DisableHooks,
+		Purge:        r.Version == 1,
+		Timeout:      req.Timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("%s; deleting the release also failed: %s", failure, err)
+	}
+	return fmt.Errorf("%s; release was deleted", failure)
+}
+
 // capabilities builds a Capabilities from discovery information.
 func capabilities(disc discovery.DiscoveryInterface) (*chartutil.Capabilities, error) {
 	sv, err := disc.ServerVersion()
@@ -711,7 +939,7 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		return nil, err
 	}
 
-	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions)
+	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions, req.SubNotes)
 	if err != nil {
 		// Return a release with partial data so that client can show debugging
 		// information.
@@ -743,7 +971,7 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		Info: &release.Info{
 			FirstDeployed: ts,
 			LastDeployed:  ts,
-			Status:        &release.Status{Code: release.Status_UNKNOWN},
+			Status:        &release.Status{Code: release.Status_PENDING_INSTALL},
 			Description:   "Initial install underway", // Will be overwritten.
 		},
 		Manifest: manifestDoc.String(),
@@ -754,10 +982,33 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		rel.Info.Status.Notes = notesTxt
 	}
 
+	// The custom resources of the release cannot be built before the
+	// crd-install hooks created their definitions, so only the hooks are
+	// validated here and the manifest is validated once they ran.
+	if crds := crdInstallManifest(hooks); len(crds) > 0 {
+		err = validateManifest(s.env.KubeClient, req.Namespace, crds)
+		return rel, err
+	}
+
 	err = validateManifest(s.env.KubeClient, req.Namespace, manifestDoc.Bytes())
 	return rel, err
 }
 
+// crdInstallManifest returns the manifests of the crd-install hooks in hs.
+func crdInstallManifest(hs []*release.Hook) []byte {
+	b := bytes.NewBuffer(nil)
+	for _, h := range hs {
+		for _, e := range h.Events {
+			if e == release.Hook_CRD_INSTALL {
+				b.WriteString("\n---\n# Source: " + h.Path + "\n")
+				b.WriteString(h.Manifest)
+				break
+			}
+		}
+	}
+	return b.Bytes()
+}
+
 func getVersionSet(client discovery.ServerGroupsInterface) (chartutil.VersionSet, error) {
 	groups, err := client.ServerGroups()
 	if err != nil {
@@ -776,7 +1027,7 @@ func getVersionSet(client discovery.ServerGroupsInterface) (chartutil.VersionSet
 	return chartutil.NewVersionSet(versions...), nil
 }
 
-func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, vs chartutil.VersionSet) ([]*release.Hook, *bytes.Buffer, string, error) {
+func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, vs chartutil.VersionSet, subNotes bool) ([]*release.Hook, *bytes.Buffer, string, error) {
 	renderer := s.engine(ch)
 	files, err := renderer.Render(ch, values)
 	if err != nil {
@@ -789,16 +1040,35 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 	// look for terminating NOTES.txt. We also remove it from the files so that we don't have to skip
 	// it in the sortHooks.
 	notes := ""
+	subchartNotes := map[string]string{}
 	for k, v := range files {
 		if strings.HasSuffix(k, notesFileSuffix) {
-			// Only apply the notes if it belongs to the parent chart
+			// Only apply the notes if it belongs to the parent chart, or subNotes is set
 			// Note: Do not use filePath.Join since it creates a path with \ which is not expected
 			if k == path.Join(ch.Metadata.Name, "templates", notesFileSuffix) {
 				notes = v
+			} else if subNotes {
+				subchartNotes[k] = v
 			}
 			delete(files, k)
 		}
 	}
+	if len(subchartNotes) > 0 {
+		// Subchart notes follow the notes of the parent in a stable order.
+		keys := make([]string, 0, len(subchartNotes))
+		for k := range subchartNotes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		all := []string{}
+		if notes != "" {
+			all = append(all, notes)
+		}
+		for _, k := range keys {
+			all = append(all, subchartNotes[k])
+		}
+		notes = strings.Join(all, "\n")
+	}
 
 	// Sort hooks, manifests, and partials. Only hooks and manifests are returned,
 	// as partials are not used after renderer.Render. Empty manifests are also
@@ -841,6 +1111,14 @@ func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
 	}
 }
 
+// failRelease marks a previously recorded release as failed.
+func (s *ReleaseServer) failRelease(r *release.Release, msg string) {
+	log.Printf("warning: %s", msg)
+	r.Info.Status.Code = release.Status_FAILED
+	r.Info.Description = msg
+	s.recordRelease(r, true)
+}
+
 // performRelease runs a release.
 func (s *ReleaseServer) performRelease(r *release.Release, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
 	res := &services.InstallReleaseResponse{Release: r}
@@ -851,51 +1129,68 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		return res, nil
 	}
 
+	// if this is a replace operation, append to the release history
+	var old *release.Release
+	if h, err := s.env.Releases.History(req.Name); req.ReuseName && err == nil && len(h) >= 1 {
+		// get latest release revision
+		relutil.Reverse(h, relutil.SortByRevision)
+		old = h[0]
+
+		// update new release with next revision number
+		// so as to append to the old release's history
+		r.Version = old.Version + 1
+	}
+
+	// Record the pending release so that an interrupted install remains visible.
+	s.recordRelease(r, false)
+
+	// crd-install hooks, which create the definitions of the custom resources
+	// that the release and its other hooks may use
+	if !req.DisableHooks {
+		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.CRDInstall, req.Timeout); err != nil {
+			s.failRelease(r, fmt.Sprintf("Release %q failed crd-install: %s", r.Name, err))
+			return res, err
+		}
+	}
+	if len(crdInstallManifest(r.Hooks)) > 0 {
+		if err := validateManifest(s.env.KubeClient, r.Namespace, []byte(r.Manifest)); err != nil {
+			s.failRelease(r, fmt.Sprintf("Release %q failed: %s", r.Name, err))
+			return res, err
+		}
+	}
+
 	// pre-install hooks
 	if !req.DisableHooks {
 		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PreInstall, req.Timeout); err != nil {
+			s.failRelease(r, fmt.Sprintf("Release %q failed pre-install: %s", r.Name, err))
 			return res, err
 		}
 	}
 
-	switch h, err := s.env.Releases.History(req.Name); {
-	// if this is a replace operation, append to the release history
-	case req.ReuseName && err == nil && len(h) >= 1:
-		// get latest release revision
-		relutil.Reverse(h, relutil.SortByRevision)
-
-		// old release
-		old := h[0]
-
+	if old != nil {
 		// update old release status
 		old.Info.Status.Code = release.Status_SUPERSEDED
 		s.recordRelease(old, true)
 
-		// update new release with next revision number
-		// so as to append to the old release's history
-		r.Version = old.Version + 1
-
-		if err := s.performKubeUpdate(old, r, false, req.Timeout, req.Wait); err != nil {
-			msg := fmt.Sprintf("Release replace %q failed: %s", r.Name, err)
-			log.Printf("warning: %s", msg)
-			old.Info.Status.Code = release.Status_SUPERSEDED
-			r.Info.Status.Code = release.Status_FAILED
-			r.Info.Description = msg
-			s.recordRelease(old, true)
-			s.recordRelease(r, false)
+		start := time.Now()
+		_, err := s.performKubeUpdate(old, r, false, false, req.Timeout, req.Wait)
+		if err == nil {
+			err = s.waitForConditions(r, req.Wait, req.Timeout, start, req.WaitConditions)
+		}
+		if err != nil {
+			s.failRelease(r, fmt.Sprintf("Release replace %q failed: %s", r.Name, err))
 			return res, err
 		}
-
-	default:
+	} else {
 		// nothing to replace, create as normal
 		// regular manifests
-		b := bytes.NewBufferString(r.Manifest)
-		if err := s.env.KubeClient.Create(r.Namespace, b, req.Timeout, req.Wait); err != nil {
-			msg := fmt.Sprintf("Release %q failed: %s", r.Name, err)
-			log.Printf("warning: %s", msg)
-			r.Info.Status.Code = release.Status_FAILED
-			r.Info.Description = msg
-			s.recordRelease(r, false)
+		start := time.Now()
+		err := s.module().Create(r, req.Timeout, req.Wait)
+		if err == nil {
+			err = s.waitForConditions(r, req.Wait, req.Timeout, start, req.WaitConditions)
+		}
+		if err != nil {
+			s.failRelease(r, fmt.Sprintf("Release %q failed: %s", r.Name, err))
 			return res, fmt.Errorf("release %s failed: %s", r.Name, err)
 		}
 	}
@@ -903,11 +1198,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 	// post-install hooks
 	if !req.DisableHooks {
 		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PostInstall, req.Timeout); err != nil {
-			msg := fmt.Sprintf("Release %q failed post-install: %s", r.Name, err)
-			log.Printf("warning: %s", msg)
-			r.Info.Status.Code = release.Status_FAILED
-			r.Info.Description = msg
-			s.recordRelease(r, false)
+			s.failRelease(r, fmt.Sprintf("Release %q failed post-install: %s", r.Name, err))
 			return res, err
 		}
 	}
@@ -921,13 +1212,15 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 	//
 	// One possible strategy would be to do a timed retry to see if we can get
 	// this stored in the future.
-	s.recordRelease(r, false)
+	s.recordRelease(r, true)
 
 	return res, nil
 }
 
-func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64) error {
-	kubeCli := s.env.KubeClient
+func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64) (err error) {
+	start := time.Now()
+	defer func() { observeHook(hook, start, err) }()
+
 	code, ok := events[hook]
 	if !ok {
 		return fmt.Errorf("unknown hook %q", hook)
@@ -946,26 +1239,51 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	executingHooks = sortByHookWeight(executingHooks)
 
 	for _, h := range executingHooks {
-
-		b := bytes.NewBufferString(h.Manifest)
-		if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
-			log.Printf("warning: Release %q %s %s failed: %s", name, hook, h.Path, err)
-			return err
+		if hookHasDeletePolicy(h, release.Hook_BEFORE_HOOK_CREATION) {
+			s.deleteHook(h, name, namespace, hooks.BeforeHookCreation)
 		}
-		// No way to rewind a bytes.Buffer()?
-		b.Reset()
-		b.WriteString(h.Manifest)
-		if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
+
+		if err := s.module().ExecHook(namespace, h, code, timeout); err != nil {
 			log.Printf("warning: Release %q %s %s could not complete: %s", name, hook, h.Path, err)
+			if hookHasDeletePolicy(h, release.Hook_FAILED) {
+				s.deleteHook(h, name, namespace, hooks.HookFailed)
+			}
 			return err
 		}
 		h.LastRun = timeconv.Now()
 	}
 
+	// Hooks are only deleted once all of them succeeded, so that a later hook
+	// can still rely on the resources of an earlier one.
+	for _, h := range executingHooks {
+		if hookHasDeletePolicy(h, release.Hook_SUCCEEDED) {
+			s.deleteHook(h, name, namespace, hooks.HookSucceeded)
+		}
+	}
+
 	log.Printf("Hooks complete for %s %s", hook, name)
 	return nil
 }
 
+// deleteHook deletes the resources of the hook h as its delete policy demands.
+// Failures are only logged, as the resources may well not exist.
+func (s *ReleaseServer) deleteHook(h *release.Hook, name, namespace, policy string) {
+	log.Printf("Deleting hook %s for release %s due to %q policy", h.Name, name, policy)
+	if err := s.module().DeleteHook(namespace, h); err != nil {
+		log.Printf("warning: Release %q failed to delete hook %s: %s", name, h.Path, err)
+	}
+}
+
+// hookHasDeletePolicy reports whether the hook h has the delete policy.
+func hookHasDeletePolicy(h *release.Hook, policy release.Hook_DeletePolicy) bool {
+	for _, p := range h.DeletePolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *ReleaseServer) purgeReleases(rels ...*release.Release) error {
 	for _, rel := range rels {
 		if _, err := s.env.Releases.Delete(rel.Name, rel.Version); err != nil {
@@ -982,6 +1300,18 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 		return nil, errMissingRelease
 	}
 
+	unlock, err := s.lockRelease(req.Name, req.Purge)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.uninstallRelease(req)
+}
+
+// uninstallRelease deletes a release whose lock is held by the caller.
+func (s *ReleaseServer) uninstallRelease(req *services.UninstallReleaseRequest) (*services.UninstallReleaseResponse, error) {
+
 	if len(req.Name) > releaseNameMaxLen {
 		return nil, fmt.Errorf("release name %q exceeds max length of %d", req.Name, releaseNameMaxLen)
 	}
@@ -1051,16 +1381,13 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 
 	// Collect the errors, and return them later.
 	es := []string{}
+	contents := make([]string, 0, len(filesToDelete))
 	for _, file := range filesToDelete {
-		b := bytes.NewBufferString(file.content)
-		if err := s.env.KubeClient.Delete(rel.Namespace, b); err != nil {
-			log.Printf("uninstall: Failed deletion of %q: %s", req.Name, err)
-			if err == kube.ErrNoObjectsVisited {
-				// Rewrite the message from "no objects visited"
-				err = errors.New("object not found, skipping delete")
-			}
-			es = append(es, err.Error())
-		}
+		contents = append(contents, file.content)
+	}
+	for _, err := range s.module().Delete(rel, contents) {
+		log.Printf("uninstall: Failed deletion of %q: %s", req.Name, err)
+		es = append(es, err.Error())
 	}
 
 	if !req.DisableHooks {
@@ -1110,10 +1437,13 @@ func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream
 	}
 
 	testEnv := &reltesting.Environment{
-		Namespace:  rel.Namespace,
-		KubeClient: s.env.KubeClient,
-		Timeout:    req.Timeout,
-		Stream:     stream,
+		Namespace:   rel.Namespace,
+		KubeClient:  &testKubeClient{module: s.module()},
+		Timeout:     req.Timeout,
+		Stream:      stream,
+		Parallel:    req.Parallel,
+		MaxParallel: int(req.MaxParallel),
+		Logs:        req.Logs,
 	}
 
 	tSuite, err := reltesting.NewTestSuite(rel)
@@ -1139,3 +1469,40 @@ func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream
 
 	return s.env.Releases.Update(rel)
 }
+
+// testKubeClient runs the test pods of release tests with a release module.
+type testKubeClient struct {
+	module ReleaseModule
+}
+
+func (c *testKubeClient) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return c.module.CreateTestPod(namespace, string(manifest), timeout)
+}
+
+func (c *testKubeClient) Delete(namespace string, reader io.Reader) error {
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return c.module.DeleteTestPod(namespace, string(manifest))
+}
+
+func (c *testKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (api.PodPhase, error) {
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return api.PodUnknown, err
+	}
+	return c.module.WaitForTestPod(namespace, string(manifest), int64(timeout/time.Second))
+}
+
+func (c *testKubeClient) GetPodLogs(namespace string, reader io.Reader) (string, error) {
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return c.module.TestPodLogs(namespace, string(manifest))
+}
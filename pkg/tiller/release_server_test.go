@@ -17,21 +17,27 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -515,6 +521,47 @@ func TestInstallReleaseWithChartAndDependencyNotes(t *testing.T) {
 	}
 }
 
+func TestInstallReleaseWithSubchartNotes(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/NOTES.txt", Data: []byte(notesText)},
+			},
+			Dependencies: []*chart.Chart{
+				{
+					Metadata: &chart.Metadata{Name: "world"},
+					Templates: []*chart.Template{
+						{Name: "templates/NOTES.txt", Data: []byte(notesText + " world")},
+					},
+				},
+				{
+					Metadata: &chart.Metadata{Name: "alpine"},
+					Templates: []*chart.Template{
+						{Name: "templates/NOTES.txt", Data: []byte(notesText + " alpine")},
+					},
+				},
+			},
+		},
+		SubNotes: true,
+	}
+
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	expect := notesText + "\n" + notesText + " alpine\n" + notesText + " world"
+	if res.Release.Info.Status.Notes != expect {
+		t.Fatalf("Expected '%s', got '%s'", expect, res.Release.Info.Status.Notes)
+	}
+}
+
 func TestInstallReleaseDryRun(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -604,6 +651,132 @@ func TestInstallReleaseFailedHooks(t *testing.T) {
 	if hl := res.Release.Info.Status.Code; hl != release.Status_FAILED {
 		t.Errorf("Expected FAILED release. Got %d", hl)
 	}
+
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatalf("Expected failed release in storage: %s", err)
+	}
+	if rel.Info.Status.Code != release.Status_FAILED {
+		t.Errorf("Expected stored release to be FAILED, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestInstallReleasePending(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &pendingCheckingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+		releases:           rs.env.Releases,
+		name:               "pending-install",
+	}
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Chart: chartStub(),
+		Name:  "pending-install",
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	if kc.status != release.Status_PENDING_INSTALL {
+		t.Errorf("Expected PENDING_INSTALL while resources were created, got %s", kc.status)
+	}
+
+	rel, err := rs.env.Releases.Get(req.Name, 1)
+	if err != nil {
+		t.Fatalf("Expected release in storage: %s", err)
+	}
+	if rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected DEPLOYED after install, got %s", rel.Info.Status.Code)
+	}
+}
+
+func TestInstallReleaseWaitConditions(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &conditionKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout}}
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Chart:          chartStub(),
+		Wait:           true,
+		Timeout:        300,
+		WaitConditions: map[string]string{"Database": "Ready"},
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if kc.conditions["Database"] != "Ready" {
+		t.Errorf("Expected wait conditions to be passed to the kube client, got %v", kc.conditions)
+	}
+	if kc.timeout <= 0 || kc.timeout > req.Timeout {
+		t.Errorf("Expected the wait to share the timeout of %ds, got %ds", req.Timeout, kc.timeout)
+	}
+
+	kc.err = errors.New("timed out waiting for the condition")
+	res, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected failed install")
+	}
+	if res.Release.Info.Status.Code != release.Status_FAILED {
+		t.Errorf("Expected FAILED release, got %s", res.Release.Info.Status.Code)
+	}
+}
+
+func TestInstallReleaseWaitConditionsDeadline(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &conditionKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+		createDelay:        1100 * time.Millisecond,
+	}
+	rs.env.KubeClient = kc
+
+	// The rollout uses up the timeout, which leaves none for the conditions.
+	req := &services.InstallReleaseRequest{
+		Chart:          chartStub(),
+		Wait:           true,
+		Timeout:        1,
+		WaitConditions: map[string]string{"Database": "Ready"},
+	}
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected failed install")
+	}
+	if kc.conditions != nil {
+		t.Errorf("Expected no wait for conditions after the timeout, got %v", kc.conditions)
+	}
+}
+
+func TestInstallReleaseAtomic(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &conditionKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+		err:                errors.New("timed out waiting for the condition"),
+	}
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Chart:          chartStub(),
+		Name:           "atomic-install",
+		DisableHooks:   true,
+		Atomic:         true,
+		WaitConditions: map[string]string{"Database": "Ready"},
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected failed install")
+	}
+	if !strings.Contains(err.Error(), "release was deleted") {
+		t.Errorf("Expected error to report the deletion, got %q", err)
+	}
+	if !req.Wait {
+		t.Error("Expected atomic install to wait for resources")
+	}
+	if h, err := rs.env.Releases.History(req.Name); err == nil && len(h) > 0 {
+		t.Error("Expected failed release to be purged")
+	}
 }
 
 func TestInstallReleaseReuseName(t *testing.T) {
@@ -780,6 +953,42 @@ func TestUpdateRelease_ReuseValues(t *testing.T) {
 	}
 }
 
+func TestUpdateRelease_ResetThenReuseValues(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Config = &chart.Config{Raw: "name: value\nkeep: old\n"}
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/hooks", Data: []byte(manifestWithUpgradeHooks)},
+			},
+			// The new chart's defaults must be preserved.
+			Values: &chart.Config{Raw: "foo: bar\n"},
+		},
+		Values:               &chart.Config{Raw: "name: override\n"},
+		ResetThenReuseValues: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	expect := "foo: bar\n"
+	if res.Release.Chart.Values.Raw != expect {
+		t.Errorf("Expected chart values to be %q, got %q", expect, res.Release.Chart.Values.Raw)
+	}
+	// Old user-supplied values are kept, but the request values take precedence.
+	expect = "keep: old\nname: override\n"
+	if res.Release.Config.Raw != expect {
+		t.Errorf("Expected request config to be %q, got %q", expect, res.Release.Config.Raw)
+	}
+}
+
 func TestUpdateRelease_ResetReuseValues(t *testing.T) {
 	// This verifies that when both reset and reuse are set, reset wins.
 	c := helm.NewContext()
@@ -850,6 +1059,211 @@ func TestUpdateReleaseFailure(t *testing.T) {
 	}
 }
 
+func TestUpdateReleaseCleanupOnFail(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Manifest = "---\n# Source: hello/templates/cm\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test-cm\n"
+	rs.env.Releases.Create(rel)
+	kc := &deleteRecordingKubeClient{}
+	kc.Out = os.Stdout
+	// The update created the secret, then failed on the service, which
+	// already existed in the cluster without being part of the release.
+	kc.created = []string{"Secret/" + rel.Namespace + "/test-secret"}
+	rs.env.KubeClient = kc
+
+	req := &services.UpdateReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/cm", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test-cm\n")},
+				{Name: "templates/secret", Data: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: test-secret\n")},
+				{Name: "templates/svc", Data: []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: test-svc\n")},
+			},
+		},
+		CleanupOnFail: true,
+	}
+
+	if _, err := rs.UpdateRelease(c, req); err == nil {
+		t.Fatal("Expected failed update")
+	}
+	if len(kc.deleted) != 1 || !strings.Contains(kc.deleted[0], "name: test-secret") {
+		t.Errorf("Expected only the created secret to be deleted, got %q", kc.deleted)
+	}
+
+	// Nothing is deleted when the update created nothing, even though the
+	// new manifest has resources the old one has not.
+	kc.deleted = nil
+	kc.created = nil
+	if _, err := rs.UpdateRelease(c, req); err == nil {
+		t.Fatal("Expected failed update")
+	}
+	if len(kc.deleted) != 0 {
+		t.Errorf("Expected no pre-existing resources to be deleted, got %q", kc.deleted)
+	}
+
+	// Without CleanupOnFail, nothing is deleted.
+	kc.deleted = nil
+	req.CleanupOnFail = false
+	if _, err := rs.UpdateRelease(c, req); err == nil {
+		t.Fatal("Expected failed update")
+	}
+	if len(kc.deleted) != 0 {
+		t.Errorf("Expected no resources to be deleted, got %q", kc.deleted)
+	}
+}
+
+func TestUpdateReleaseAtomic(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	rs.env.KubeClient = &updateFailingOnceKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+	}
+
+	req := &services.UpdateReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Atomic:       true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/something", Data: []byte("hello: world")},
+			},
+		},
+	}
+
+	_, err := rs.UpdateRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected failed update")
+	}
+	if !strings.Contains(err.Error(), "release was rolled back to revision 1") {
+		t.Errorf("Expected error to report the rollback, got %q", err)
+	}
+
+	failed, err := rs.env.Releases.Get(rel.Name, 2)
+	if err != nil {
+		t.Fatalf("Expected failed upgrade in storage: %s", err)
+	}
+	if failed.Info.Status.Code != release.Status_SUPERSEDED {
+		t.Errorf("Expected failed upgrade to be SUPERSEDED, got %s", failed.Info.Status.Code)
+	}
+
+	last, err := rs.env.Releases.Last(rel.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Version != 3 || last.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected DEPLOYED rollback as revision 3, got %s revision %d", last.Info.Status.Code, last.Version)
+	}
+	if last.Manifest != rel.Manifest {
+		t.Errorf("Expected rollback to restore the manifest of revision 1")
+	}
+}
+
+func TestUpdateReleaseForce(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &forceRecordingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout}}
+	rs.env.KubeClient = kc
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+			},
+		},
+		Force: true,
+	}
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Fatalf("Failed update: %s", err)
+	}
+	if !kc.force {
+		t.Error("Expected force to be passed to the kube client")
+	}
+}
+
+func TestRollbackReleaseForce(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &forceRecordingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout}}
+	rs.env.KubeClient = kc
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	upgradedRel := upgradeReleaseVersion(rel)
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(upgradedRel)
+
+	req := &services.RollbackReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+		Force:        true,
+	}
+	if _, err := rs.RollbackRelease(c, req); err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if !kc.force {
+		t.Error("Expected force to be passed to the kube client")
+	}
+}
+
+func TestRollbackDeletedRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	upgradedRel := upgradeReleaseVersion(rel)
+	upgradedRel.Manifest = "kind: ConfigMap\nmetadata:\n  name: upgraded\n"
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(upgradedRel)
+
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: rel.Name, DisableHooks: true}); err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+
+	hist, err := rs.GetHistory(c, &services.GetHistoryRequest{Name: rel.Name, Max: 10})
+	if err != nil {
+		t.Fatalf("Failed to get history of deleted release: %s", err)
+	}
+	if len(hist.Releases) != 2 || hist.Releases[0].Info.Status.Code != release.Status_DELETED {
+		t.Fatalf("Expected history with the deleted revision first, got %v", hist.Releases)
+	}
+
+	res, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name, DisableHooks: true})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if res.Release.Version != 3 {
+		t.Errorf("Expected revision 3, got %d", res.Release.Version)
+	}
+	if res.Release.Manifest != upgradedRel.Manifest {
+		t.Errorf("Expected the deleted revision to be restored, got manifest %q", res.Release.Manifest)
+	}
+
+	last, err := rs.env.Releases.Last(rel.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected DEPLOYED release, got %s", last.Info.Status.Code)
+	}
+	deleted, err := rs.env.Releases.Get(rel.Name, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted.Info.Status.Code != release.Status_SUPERSEDED {
+		t.Errorf("Expected deleted revision to be SUPERSEDED, got %s", deleted.Info.Status.Code)
+	}
+}
+
 func TestRollbackReleaseFailure(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -930,6 +1344,26 @@ func TestUpdateReleaseNoChanges(t *testing.T) {
 	}
 }
 
+func TestRollbackReleaseSkipsFailedRevisions(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	for v, code := range []release.Status_Code{release.Status_SUPERSEDED, release.Status_SUPERSEDED, release.Status_FAILED, release.Status_FAILED} {
+		rel := namedReleaseStub("angry-bird", code)
+		rel.Version = int32(v + 1)
+		rel.Manifest = fmt.Sprintf("revision: %d", rel.Version)
+		rs.env.Releases.Create(rel)
+	}
+
+	res, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: "angry-bird"})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if res.Release.Info.Description != "Rollback to 2" {
+		t.Errorf("Expected a rollback to revision 2, got %q", res.Release.Info.Description)
+	}
+}
+
 func TestRollbackReleaseNoHooks(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -987,6 +1421,22 @@ func TestRollbackWithReleaseVersion(t *testing.T) {
 	}
 }
 
+func TestRollbackWithoutPreviousRevision(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.RollbackReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+	}
+	_, err := rs.RollbackRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "no previous revision") {
+		t.Errorf("Expected an error about the missing previous revision, got %v", err)
+	}
+}
+
 func TestRollbackRelease(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -1276,6 +1726,170 @@ func TestUninstallReleaseNoHooks(t *testing.T) {
 	}
 }
 
+func TestInstallReleaseCRDInstallHook(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &hookRecordingKubeClient{}
+	rs.env.KubeClient = kc
+
+	// The fake cluster only serves v1.
+	crd := strings.Replace(crdManifest, "apiextensions.k8s.io/v1beta1", "v1", 1)
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/crd", Data: []byte(crd)},
+				{Name: "templates/crontab", Data: []byte(crManifest)},
+				{Name: "templates/hooks", Data: []byte(strings.Replace(manifestWithHook, "post-install,pre-delete", "pre-install", 1))},
+			},
+		},
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	var got []string
+	for _, a := range kc.actions {
+		got = append(got, strings.Join(strings.SplitN(a, "\n", 3)[:2], "\n"))
+	}
+	expect := []string{
+		"create apiVersion: v1\nkind: CustomResourceDefinition",
+		"watch apiVersion: v1\nkind: CustomResourceDefinition",
+		"wait apiVersion: v1\nkind: CustomResourceDefinition",
+		"create apiVersion: v1\nkind: ConfigMap",
+		"watch apiVersion: v1\nkind: ConfigMap",
+		"create \n---",
+	}
+	if strings.Join(got, ", ") != strings.Join(expect, ", ") {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+	if last := kc.actions[len(kc.actions)-1]; !strings.Contains(last, "kind: CronTab") {
+		t.Errorf("Expected the custom resource to be created with the release, got %q", last)
+	}
+}
+
+// crdKubeClient only builds custom resources once their definition was
+// created, like a cluster without the CustomResourceDefinition.
+type crdKubeClient struct {
+	hookRecordingKubeClient
+	crdCreated bool
+}
+
+func (k *crdKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	b, _ := ioutil.ReadAll(r)
+	if strings.Contains(string(b), "kind: CustomResourceDefinition") {
+		k.crdCreated = true
+	}
+	return k.hookRecordingKubeClient.Create(ns, bytes.NewReader(b), timeout, shouldWait)
+}
+
+func (k *crdKubeClient) BuildUnstructured(ns string, r io.Reader) (kube.Result, error) {
+	b, _ := ioutil.ReadAll(r)
+	if strings.Contains(string(b), "\nkind: CronTab") && !k.crdCreated {
+		return nil, errors.New(`no matches for kind "CronTab"`)
+	}
+	return []*resource.Info{}, nil
+}
+
+func TestInstallReleaseValidatesAfterCRDInstall(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &crdKubeClient{}
+	rs.env.KubeClient = kc
+
+	crd := strings.Replace(crdManifest, "apiextensions.k8s.io/v1beta1", "v1", 1)
+	chartWith := func(crd string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/crd", Data: []byte(crd)},
+				{Name: "templates/crontab", Data: []byte(crManifest)},
+			},
+		}
+	}
+
+	res, err := rs.InstallRelease(c, &services.InstallReleaseRequest{Namespace: "spaced", Chart: chartWith(crd)})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if code := res.Release.Info.Status.Code; code != release.Status_DEPLOYED {
+		t.Errorf("Expected the release to be deployed, got %s", code)
+	}
+
+	// Without the crd-install hooks, the custom resource is rejected before
+	// anything is created.
+	kc = &crdKubeClient{}
+	rs.env.KubeClient = kc
+	req := &services.InstallReleaseRequest{Name: "crd-less", Namespace: "spaced", Chart: chartWith(crd), DisableHooks: true}
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected the custom resource to fail validation")
+	}
+	if len(kc.actions) != 0 {
+		t.Errorf("Expected nothing to be created, got %q", kc.actions)
+	}
+}
+
+func TestHookDeletePolicies(t *testing.T) {
+	hook := func(name string, policies ...release.Hook_DeletePolicy) *release.Hook {
+		return &release.Hook{
+			Name:           name,
+			Path:           name,
+			Manifest:       name,
+			Events:         []release.Hook_Event{release.Hook_POST_INSTALL},
+			DeletePolicies: policies,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		hooks     []*release.Hook
+		failWatch bool
+		expect    []string
+	}{
+		{
+			name:   "no policies",
+			hooks:  []*release.Hook{hook("a")},
+			expect: []string{"create a", "watch a"},
+		},
+		{
+			name:   "hook-succeeded",
+			hooks:  []*release.Hook{hook("a", release.Hook_SUCCEEDED), hook("b")},
+			expect: []string{"create a", "watch a", "create b", "watch b", "delete a"},
+		},
+		{
+			name:      "hook-succeeded on failure",
+			hooks:     []*release.Hook{hook("a", release.Hook_SUCCEEDED)},
+			failWatch: true,
+			expect:    []string{"create a", "watch a"},
+		},
+		{
+			name:      "hook-failed",
+			hooks:     []*release.Hook{hook("a", release.Hook_FAILED)},
+			failWatch: true,
+			expect:    []string{"create a", "watch a", "delete a"},
+		},
+		{
+			name:   "before-hook-creation",
+			hooks:  []*release.Hook{hook("a", release.Hook_BEFORE_HOOK_CREATION, release.Hook_SUCCEEDED)},
+			expect: []string{"delete a", "create a", "watch a", "delete a"},
+		},
+	}
+
+	for _, tt := range tests {
+		rs := rsFixture()
+		kc := &hookRecordingKubeClient{failWatch: tt.failWatch}
+		rs.env.KubeClient = kc
+		err := rs.execHook(tt.hooks, "angry-panda", "default", hooks.PostInstall, 600)
+		if tt.failWatch != (err != nil) {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if strings.Join(kc.actions, ", ") != strings.Join(tt.expect, ", ") {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, kc.actions)
+		}
+	}
+}
+
 func TestGetReleaseContent(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -1315,6 +1929,27 @@ func TestGetReleaseStatus(t *testing.T) {
 	}
 }
 
+func TestGetReleaseStatusResources(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = &moduleKubeClient{}
+	rel := releaseStub()
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Error getting release status: %s", err)
+	}
+	if res.Info.Status.Resources != "RESOURCES" {
+		t.Errorf("Expected the status of the resources, got %q", res.Info.Status.Resources)
+	}
+	if l := len(res.Info.Resources); l != 1 || res.Info.Resources[0].Name != "otter" {
+		t.Errorf("Expected the resources of the release, got %v", res.Info.Resources)
+	}
+}
+
 func TestGetReleaseStatusDeleted(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -1570,10 +2205,86 @@ func newUpdateFailingKubeClient() *updateFailingKubeClient {
 
 type updateFailingKubeClient struct {
 	environment.PrintingKubeClient
+	// created are the resources the failed update reports as created.
+	created []string
+}
+
+func (u *updateFailingKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
+	return u.created, errors.New("Failed update in kube client")
+}
+
+// updateFailingOnceKubeClient fails the first update only.
+type updateFailingOnceKubeClient struct {
+	environment.PrintingKubeClient
+	failed bool
+}
+
+func (u *updateFailingOnceKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
+	if !u.failed {
+		u.failed = true
+		return nil, errors.New("Failed update in kube client")
+	}
+	return nil, nil
+}
+
+// hookRecordingKubeClient records the calls made to run hooks, whose
+// manifests are just their names.
+type hookRecordingKubeClient struct {
+	environment.PrintingKubeClient
+	failWatch bool
+	actions   []string
+}
+
+func (h *hookRecordingKubeClient) record(action string, r io.Reader) {
+	b, _ := ioutil.ReadAll(r)
+	h.actions = append(h.actions, action+" "+string(b))
+}
+
+func (h *hookRecordingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	h.record("create", r)
+	return nil
+}
+
+func (h *hookRecordingKubeClient) Delete(ns string, r io.Reader) error {
+	h.record("delete", r)
+	return nil
+}
+
+func (h *hookRecordingKubeClient) WaitForConditions(ns string, r io.Reader, timeout int64, conditions map[string]string) error {
+	h.record("wait", r)
+	return nil
+}
+
+func (h *hookRecordingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	h.record("watch", r)
+	if h.failWatch {
+		return errors.New("Failed watch")
+	}
+	return nil
+}
+
+// deleteRecordingKubeClient fails updates and records the manifests it is
+// asked to delete.
+type deleteRecordingKubeClient struct {
+	updateFailingKubeClient
+	deleted []string
+}
+
+func (d *deleteRecordingKubeClient) Delete(ns string, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	d.deleted = append(d.deleted, string(b))
+	return err
+}
+
+// forceRecordingKubeClient records whether an update was forced.
+type forceRecordingKubeClient struct {
+	environment.PrintingKubeClient
+	force bool
 }
 
-func (u *updateFailingKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, recreate bool, timeout int64, shouldWait bool) error {
-	return errors.New("Failed update in kube client")
+func (f *forceRecordingKubeClient) Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) ([]string, error) {
+	f.force = force
+	return nil, nil
 }
 
 func newHookFailingKubeClient() *hookFailingKubeClient {
@@ -1590,6 +2301,42 @@ func (h *hookFailingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout
 	return errors.New("Failed watch")
 }
 
+// pendingCheckingKubeClient records the stored status of a release at the
+// time its resources are created.
+type pendingCheckingKubeClient struct {
+	environment.PrintingKubeClient
+	releases *storage.Storage
+	name     string
+	status   release.Status_Code
+}
+
+func (p *pendingCheckingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	if rel, err := p.releases.Last(p.name); err == nil {
+		p.status = rel.Info.Status.Code
+	}
+	return nil
+}
+
+type conditionKubeClient struct {
+	environment.PrintingKubeClient
+	// createDelay is how long creating the resources takes.
+	createDelay time.Duration
+	conditions  map[string]string
+	timeout     int64
+	err         error
+}
+
+func (c *conditionKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	time.Sleep(c.createDelay)
+	return c.PrintingKubeClient.Create(ns, r, timeout, shouldWait)
+}
+
+func (c *conditionKubeClient) WaitForConditions(ns string, r io.Reader, timeout int64, conditions map[string]string) error {
+	c.conditions = conditions
+	c.timeout = timeout
+	return c.err
+}
+
 type mockListServer struct {
 	val *services.ListReleasesResponse
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -48,6 +49,12 @@ func NewServer(opts ...grpc.ServerOption) *grpc.Server {
 
 func newUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		start := time.Now()
+		defer func() {
+			observeRequest(info.FullMethod, start, err)
+			auditRequest(ctx, info.FullMethod, req, resp, err)
+		}()
+
 		if err := checkClientVersion(ctx); err != nil {
 			// whitelist GetVersion() from the version check
 			if _, m := splitMethod(info.FullMethod); m != "GetVersion" {
@@ -55,16 +62,25 @@ func newUnaryInterceptor() grpc.UnaryServerInterceptor {
 				return nil, err
 			}
 		}
+		if err := checkLeader(info.FullMethod); err != nil {
+			return nil, err
+		}
 		return handler(ctx, req)
 	}
 }
 
 func newStreamInterceptor() grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		defer func() { observeRequest(info.FullMethod, start, err) }()
+
 		if err := checkClientVersion(ss.Context()); err != nil {
 			log.Println(err)
 			return err
 		}
+		if err := checkLeader(info.FullMethod); err != nil {
+			return err
+		}
 		return handler(srv, ss)
 	}
 }
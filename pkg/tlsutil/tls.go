@@ -24,19 +24,30 @@ import (
 )
 
 // NewClientTLS returns tls.Config appropriate for client auth.
+//
+// The client certificate is only loaded if both certFile and keyFile are
+// set, and the server certificate is verified against caFile if it is set,
+// or the system roots otherwise.
 func NewClientTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
-	cert, err := CertFromFilePair(certFile, keyFile)
-	if err != nil {
-		return nil, err
+	config := &tls.Config{}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("both a certificate and a key file are required for client authentication")
+		}
+		cert, err := CertFromFilePair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{*cert}
 	}
-	cp, err := CertPoolFromFile(caFile)
-	if err != nil {
-		return nil, err
+	if caFile != "" {
+		cp, err := CertPoolFromFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = cp
 	}
-	return &tls.Config{
-		Certificates: []tls.Certificate{*cert},
-		RootCAs:      cp,
-	}, nil
+	return config, nil
 }
 
 // CertPoolFromFile returns an x509.CertPool containing the certificates